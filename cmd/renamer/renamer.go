@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/winpath"
+)
+
+func main() {
+	if len(os.Args) != 4 {
+		fmt.Println("Usage: namaprogram <directory> <oldString> <newString>")
+		return
+	}
+
+	// Path panjang dan path relatif terhadap drive (d:data) di Windows
+	directory, err := winpath.Resolve("", os.Args[1])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	oldString := os.Args[2]
+	newString := os.Args[3]
+
+	// renamed mencatat nama baru yang sudah dipakai, agar dua file tidak
+	// di-rename ke nama yang sama (di Windows tanpa membedakan huruf besar/kecil)
+	renamed := make(map[string]bool)
+
+	err = filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Only process files
+		if !info.IsDir() {
+			oldName := filepath.Base(path)
+			if strings.Contains(oldName, oldString) {
+				newName := strings.Replace(oldName, oldString, newString, -1)
+				newPath := filepath.Join(filepath.Dir(path), newName)
+				if renamed[winpath.Key(newPath)] || targetExists(path, newPath) {
+					fmt.Printf("Skipped: %s -> %s (target already exists)\n", oldName, newName)
+					return nil
+				}
+				err := os.Rename(path, newPath)
+				if err != nil {
+					return err
+				}
+				renamed[winpath.Key(newPath)] = true
+				fmt.Printf("Renamed: %s -> %s\n", oldName, newName)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+}
+
+// targetExists mengembalikan true bila newPath sudah ada dan bukan file path
+// itu sendiri. os.Rename menimpa file tujuan, sedangkan rename yang hanya
+// mengubah huruf besar/kecil di Windows menunjuk file yang sama.
+func targetExists(path, newPath string) bool {
+	target, err := os.Lstat(newPath)
+	if err != nil {
+		return false
+	}
+	source, err := os.Lstat(path)
+	return err != nil || !os.SameFile(source, target)
+}
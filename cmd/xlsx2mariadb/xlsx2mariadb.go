@@ -0,0 +1,10171 @@
+package main
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-sql-driver/mysql"
+	"github.com/klauspost/compress/zstd"
+	"github.com/segmentio/kafka-go"
+	"html"
+	"io"
+	"log"
+	"math"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"net/smtp"
+	"net/textproto"
+	"net/url"
+	"os"
+	"os/exec"
+	"os/signal"
+	"os/user"
+	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"runtime/trace"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+
+	"github.com/MuhaeminSidiq/GOLearnbyAI/converterpb"
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql"
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/ddl"
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/inference"
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/loader"
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/winpath"
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/writer"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/pkg/sftp"
+	"github.com/xuri/excelize/v2"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var (
+	runID          = time.Now().Format("20060102-150405")
+	totalFiles     int
+	processedFiles int
+	// fileStatus menyimpan status terakhir setiap file yang diproses
+	fileStatus = make(map[string]string)
+	mu         sync.Mutex
+	logMu      sync.Mutex
+	wg         sync.WaitGroup
+)
+
+const dbConfigPath = "db.cfg"
+
+// version diisi saat build, misalnya go build -ldflags "-X main.version=1.4.0"
+var version = "dev"
+
+// runOptions menampung opsi baris perintah program.
+type runOptions struct {
+	audit      bool
+	lang       string
+	logDir     string
+	logName    string
+	logMaxSize int64
+	logDaily   bool
+	logKeep    int
+
+	dbConnMaxLifetime time.Duration
+	dbConnMaxIdle     time.Duration
+	dbRetries         int
+	targets           string
+
+	verify        bool
+	verifySamples int
+	replica       string
+	replicaWait   time.Duration
+
+	fileTimeout time.Duration
+	stmtTimeout time.Duration
+
+	stats bool
+
+	anomalyThreshold float64
+	anomalyHistory   int
+	anomalyHold      bool
+
+	checkpoint string
+	resume     bool
+
+	drift string
+
+	stateFile string
+	force     bool
+
+	approval       bool
+	approvers      string
+	approverTokens string
+	reviewRows     int
+
+	watch      bool
+	watchLoad  bool
+	watchDelay time.Duration
+
+	schedule string
+
+	preview     string
+	previewRows int
+
+	metricsAddr string
+	pprofAddr   string
+	traceFile   string
+
+	prescan bool
+
+	listen     string
+	grpcListen string
+	apiToken   string
+
+	xlsxUnzipLimit int64
+	xlsxXMLLimit   int64
+	lowMemory      bool
+
+	tmpDir         string
+	spillThreshold int64
+	memoryBudget   int64
+
+	compress string
+
+	pidFile string
+
+	stdout      bool
+	stdoutStage string
+
+	progress string
+
+	stdinTable string
+
+	gsheets            string
+	gsheetsCredentials string
+
+	input      string
+	upload     string
+	s3Endpoint string
+	s3Region   string
+
+	sftp           string
+	sftpKey        string
+	sftpKnownHosts string
+	sftpProcessed  string
+
+	manifest string
+
+	report string
+
+	smtpHost     string
+	smtpPort     int
+	smtpUser     string
+	smtpPassword string
+	smtpFrom     string
+	smtpTo       string
+	smtpOn       string
+
+	lock     string
+	lockWait time.Duration
+
+	webhookURL string
+	webhookOn  string
+
+	readRetries    int
+	readRetryDelay time.Duration
+	copyRemote     string
+
+	readRate int
+	maxProcs int
+	nice     int
+	ionice   string
+
+	stableWindow  time.Duration
+	stableTimeout time.Duration
+
+	inferRows     int
+	inferSample   string
+	numberFormats bool
+	fillMerged    bool
+	minConfidence float64
+	quarantine    string
+
+	xmlRows string
+
+	sheets       string
+	sheetWorkers int
+	headerRow    int
+	headerRows   int
+	skipRows     int
+	noHeader     bool
+	columnNames  string
+	cellRange    string
+	excelTables  bool
+
+	idColumn   string
+	idType     string
+	idUnsigned bool
+	timestamps bool
+	createMode string
+	naming     string
+
+	engine       string
+	rowFormat    string
+	keyBlockSize int
+	tableOptions string
+	columnstore  bool
+	dialect      string
+	copyLocation string
+
+	xlsxDir     string
+	sqlTableDir string
+	sqlDataDir  string
+	mirrorDirs  bool
+	ndjson      string
+	kafkaKey    string
+
+	xlsxPassword string
+	passwords    string
+	tenants      string
+
+	encryptColumns string
+	encryptionKey  string
+
+	include string
+	exclude string
+
+	workers    int
+	dbWorkers  int
+	loadChunks int
+
+	adaptiveWorkers   int
+	maxThreadsRunning int
+	maxReplicaLag     time.Duration
+	maxHistoryLength  int
+	loadCheckInterval time.Duration
+
+	provenance bool
+
+	warnColumns int
+	warnGrowth  float64
+
+	indexAdvisor string
+	indexApply   bool
+	indexSample  int
+
+	benchRows  int
+	benchCols  int
+	benchFiles int
+}
+
+var opts runOptions
+
+func parseFlags() {
+	flag.BoolVar(&opts.audit, "audit", false, "validasi struktur file INSERT pada SQLData sebelum dieksekusi")
+	flag.StringVar(&opts.lang, "lang", "", "bahasa pesan: id atau en (default mengikuti locale sistem)")
+	flag.StringVar(&opts.logDir, "log-dir", "log", "direktori file log, relatif terhadap direktori kerja atau path absolut")
+	flag.StringVar(&opts.logName, "log-name", "", "nama dasar yang ditambahkan di depan nama file log, misalnya xlsx2mariadb-error.log")
+	flag.Int64Var(&opts.logMaxSize, "log-max-size", 10, "ukuran maksimum file log dalam MB sebelum dirotasi (0 = tanpa batas)")
+	flag.BoolVar(&opts.logDaily, "log-daily", true, "rotasi file log setiap hari")
+	flag.IntVar(&opts.logKeep, "log-keep", 7, "jumlah file log hasil rotasi yang disimpan per jenis log (0 = simpan semua)")
+	flag.DurationVar(&opts.dbConnMaxLifetime, "db-conn-max-lifetime", 3*time.Minute, "umur maksimum koneksi database sebelum diganti")
+	flag.DurationVar(&opts.dbConnMaxIdle, "db-conn-max-idle", time.Minute, "lama maksimum koneksi database menganggur sebelum ditutup")
+	flag.IntVar(&opts.dbRetries, "db-retries", 3, "jumlah percobaan ulang batch ketika koneksi database terputus sebelum batch terkirim atau batch dibatalkan karena deadlock; batch yang koneksinya terputus setelah terkirim tidak diulang karena mungkin sudah tersimpan")
+	flag.StringVar(&opts.targets, "targets", "", "daftar file konfigurasi database tambahan (format sama dengan db.cfg), dipisahkan koma")
+	flag.BoolVar(&opts.verify, "verify", false, "verifikasi jumlah baris dan sampel data setelah pemuatan")
+	flag.IntVar(&opts.verifySamples, "verify-samples", 3, "jumlah baris sampel yang dicari kembali per tabel saat verifikasi")
+	flag.StringVar(&opts.replica, "replica", "", "file konfigurasi replika (format sama dengan db.cfg), verifikasi dijalankan pada replika setelah replika menyusul")
+	flag.DurationVar(&opts.replicaWait, "replica-wait", 5*time.Minute, "batas waktu menunggu replika menyusul primary")
+	flag.DurationVar(&opts.fileTimeout, "file-timeout", 0, "batas waktu konversi satu file Excel (0 = tanpa batas)")
+	flag.DurationVar(&opts.stmtTimeout, "stmt-timeout", 0, "batas waktu eksekusi satu pernyataan SQL (0 = tanpa batas)")
+	flag.BoolVar(&opts.stats, "stats", false, "simpan statistik per tabel (jumlah baris, ukuran, NULL per kolom, min/max kolom tanggal) ke tabel _import_stats")
+	flag.Float64Var(&opts.anomalyThreshold, "anomaly-threshold", 0, "batas penyimpangan dari riwayat _import_stats, misalnya 0.3 = 30% (0 = deteksi anomali nonaktif)")
+	flag.IntVar(&opts.anomalyHistory, "anomaly-history", 10, "jumlah impor sebelumnya yang dipakai sebagai pembanding anomali")
+	flag.BoolVar(&opts.anomalyHold, "anomaly-hold", false, "minta konfirmasi sebelum memuat file yang terdeteksi anomali")
+	flag.StringVar(&opts.checkpoint, "checkpoint", "checkpoint.json", "file checkpoint yang mencatat file yang sudah dikonversi dan dieksekusi (kosong = nonaktif)")
+	flag.BoolVar(&opts.resume, "resume", false, "lanjutkan run sebelumnya berdasarkan file checkpoint, melewati pekerjaan yang sudah selesai")
+	flag.StringVar(&opts.drift, "drift", "off", "kebijakan bila kolom file berubah dari impor sebelumnya: off, ask (minta persetujuan), block (tidak dimuat) atau alter (ALTER TABLE otomatis)")
+	flag.StringVar(&opts.stateFile, "state", "state.json", "file state berisi hash SHA-256 file Excel pada konversi terakhir (kosong = nonaktif)")
+	flag.BoolVar(&opts.force, "force", false, "konversi ulang semua file walaupun hash-nya tidak berubah")
+	flag.BoolVar(&opts.approval, "approval", false, "tulis skema dan contoh data ke direktori review dan muat hanya tabel yang sudah disetujui")
+	flag.StringVar(&opts.approvers, "approvers", "", "daftar pengguna yang berwenang menyetujui pemuatan, dipisahkan koma (kosong = semua)")
+	flag.StringVar(&opts.approverTokens, "approver-tokens", "", "file CSV berkolom user,token berisi token pribadi setiap penyetuju untuk POST /approve/ pada mode serve (header X-Approver-Token); tanpa file ini persetujuan lewat API ditolak")
+	flag.IntVar(&opts.reviewRows, "review-rows", 10, "jumlah baris contoh pada file review")
+	flag.BoolVar(&opts.watch, "watch", false, "pantau direktori xlsx dan konversi file Excel yang baru masuk atau berubah")
+	flag.BoolVar(&opts.watchLoad, "watch-load", false, "pada mode -watch, langsung buat tabel dan muat data setelah file dikonversi")
+	flag.DurationVar(&opts.watchDelay, "watch-delay", 2*time.Second, "jeda tanpa perubahan sebelum file yang masuk pada mode -watch diproses")
+	flag.StringVar(&opts.schedule, "schedule", "", "jalankan konversi dan pemuatan secara berkala dengan jadwal format cron, misalnya \"0 2 * * *\"")
+	flag.StringVar(&opts.preview, "preview", "", "tulis pratinjau beberapa baris pertama setiap tabel ke direktori preview: md atau html (kosong = nonaktif)")
+	flag.IntVar(&opts.previewRows, "preview-rows", 50, "jumlah baris pada file pratinjau")
+	flag.StringVar(&opts.metricsAddr, "metrics-addr", "", "alamat endpoint /metrics Prometheus, misalnya :9100 (kosong = nonaktif)")
+	flag.StringVar(&opts.pprofAddr, "pprof", "", "alamat endpoint /debug/pprof untuk profiling CPU dan heap, misalnya localhost:6060 (kosong = nonaktif)")
+	flag.StringVar(&opts.traceFile, "trace", "", "tulis execution trace Go ke file ini selama program berjalan, dibuka dengan go tool trace")
+	flag.BoolVar(&opts.prescan, "prescan", false, "baca metadata dimensi sheet setiap file sebelum diproses untuk melaporkan jumlah baris dan kolom serta menghitung persentase kemajuan per sel")
+	flag.StringVar(&opts.listen, "listen", "localhost:8080", "alamat HTTP untuk subperintah serve; alamat selain loopback membutuhkan -api-token")
+	flag.StringVar(&opts.grpcListen, "grpc-listen", "", "alamat API gRPC (converterpb/converter.proto) untuk subperintah serve, misalnya :9090 (kosong = tanpa gRPC)")
+	flag.StringVar(&opts.apiToken, "api-token", "", "token yang wajib dikirim klien API HTTP dan gRPC subperintah serve (Authorization: Bearer <token>), sebaiknya diisi api_token pada db.cfg; wajib bila -listen atau -grpc-listen bukan alamat loopback")
+	flag.Int64Var(&opts.xlsxUnzipLimit, "xlsx-unzip-limit", 0, "batas ukuran total isi file xlsx setelah diekstrak dalam MB (0 = default excelize, 16384)")
+	flag.Int64Var(&opts.xlsxXMLLimit, "xlsx-xml-limit", 0, "bagian XML sheet dan shared strings yang lebih besar dari batas ini (MB) diekstrak ke file sementara, bukan ke memori (0 = default excelize, 16)")
+	flag.BoolVar(&opts.lowMemory, "low-memory", false, "mode hemat memori untuk file xlsx berukuran besar, setara dengan -xlsx-xml-limit 1")
+	flag.StringVar(&opts.tmpDir, "tmp-dir", "", "direktori file sementara, termasuk file sementara excelize (default direktori sementara sistem)")
+	flag.Int64Var(&opts.spillThreshold, "spill-threshold", 64, "pindahkan buffer data ke file sementara di -tmp-dir bila ukurannya melewati batas ini dalam MB (0 = selalu di memori)")
+	flag.Int64Var(&opts.memoryBudget, "memory-budget", 0, "batas total buffer data di memori untuk semua file yang dikonversi bersamaan dalam MB; buffer yang membuat total melewati batas dipindah ke file sementara (0 = tanpa batas)")
+	flag.StringVar(&opts.compress, "compress", "", "kompres file data di direktori SQLData: gzip atau zstd (kosong = tanpa kompresi)")
+	flag.StringVar(&opts.pidFile, "pid-file", "", "tulis PID proses ke file ini dan tolak berjalan bila proses lain dengan file PID yang sama masih hidup (default xlsx2mariadb.pid pada perintah daemon)")
+	flag.BoolVar(&opts.stdout, "stdout", false, "tulis SQL hasil konversi ke standard output, bukan ke file, misalnya untuk disalurkan ke klien mysql")
+	flag.StringVar(&opts.stdoutStage, "stdout-stage", "all", "bagian SQL yang ditulis pada mode -stdout: all (tabel lalu data), schema atau data")
+	flag.StringVar(&opts.progress, "progress", "text", "format kemajuan: text atau json (satu baris JSON per kejadian di standard output)")
+	flag.StringVar(&opts.stdinTable, "stdin-table", "stdin", "nama tabel untuk data yang dibaca dari standard input (argumen file -)")
+	flag.StringVar(&opts.gsheets, "gsheets", "", "ID atau URL Google Sheets yang diimpor seperti file xlsx, dipisahkan koma")
+	flag.StringVar(&opts.gsheetsCredentials, "gsheets-credentials", "", "file JSON kredensial service account untuk -gsheets (default Application Default Credentials, misalnya GOOGLE_APPLICATION_CREDENTIALS)")
+	flag.StringVar(&opts.input, "input", "", "sumber input selain direktori xlsx: s3://bucket/awalan untuk semua file input di bucket S3 atau MinIO dengan awalan tersebut")
+	flag.StringVar(&opts.upload, "upload", "", "unggah juga file SQLTable dan SQLData hasil konversi ke s3://bucket/awalan")
+	flag.StringVar(&opts.s3Endpoint, "s3-endpoint", "", "endpoint S3 untuk -input dan -upload, misalnya http://localhost:9000 untuk MinIO (default AWS_ENDPOINT_URL atau https://s3.amazonaws.com)")
+	flag.StringVar(&opts.s3Region, "s3-region", "", "region bucket S3 (default dideteksi dari bucket)")
+	flag.StringVar(&opts.sftp, "sftp", "", "ambil file input baru dari server SFTP: sftp://user@host[:port]/direktori/remote; file disalin ke direktori sftp/<host> dan file yang sudah pernah diunduh tanpa perubahan dilewati")
+	flag.StringVar(&opts.sftpKey, "sftp-key", "", "file private key untuk autentikasi -sftp (default ~/.ssh/id_ed25519 atau ~/.ssh/id_rsa)")
+	flag.StringVar(&opts.sftpKnownHosts, "sftp-known-hosts", "", "file known_hosts untuk memverifikasi host key server -sftp (default ~/.ssh/known_hosts)")
+	flag.StringVar(&opts.sftpProcessed, "sftp-processed", "", "direktori remote (relatif terhadap direktori -sftp, misalnya processed) tujuan file yang berhasil diproses dipindahkan; kosong berarti file tidak dipindahkan")
+	flag.StringVar(&opts.manifest, "manifest", "", "file manifest CSV atau JSON berisi daftar file yang diproses beserta nama tabel, sheet, baris header dan mode per file")
+	flag.StringVar(&opts.report, "report", ".", "direktori tujuan report.json dan report.html yang merangkum setiap run (kosong = nonaktif)")
+	flag.StringVar(&opts.smtpHost, "smtp-host", "", "server SMTP untuk email ringkasan run (kosong = tidak mengirim email)")
+	flag.IntVar(&opts.smtpPort, "smtp-port", 587, "port server SMTP")
+	flag.StringVar(&opts.smtpUser, "smtp-user", "", "nama pengguna SMTP (kosong = tanpa autentikasi)")
+	flag.StringVar(&opts.smtpPassword, "smtp-password", "", "kata sandi SMTP")
+	flag.StringVar(&opts.smtpFrom, "smtp-from", "", "alamat pengirim email")
+	flag.StringVar(&opts.smtpTo, "smtp-to", "", "alamat penerima email, dipisahkan koma")
+	flag.StringVar(&opts.smtpOn, "smtp-on", "always", "kapan email dikirim: always atau failure")
+	flag.StringVar(&opts.lock, "lock", "abort", "bila run lain sedang memproses direktori kerja atau database yang sama: abort (berhenti), wait (antre menunggu) atau off")
+	flag.DurationVar(&opts.lockWait, "lock-wait", 0, "batas waktu menunggu run lain pada -lock wait (0 = tanpa batas)")
+	flag.StringVar(&opts.webhookURL, "webhook-url", "", "URL webhook (misalnya Slack incoming webhook) untuk ringkasan run dan file yang gagal (kosong = nonaktif)")
+	flag.StringVar(&opts.webhookOn, "webhook-on", "always", "kapan ringkasan run dikirim ke webhook: always atau failure")
+	flag.IntVar(&opts.readRetries, "read-retries", 3, "jumlah percobaan ulang ketika membaca file Excel gagal, misalnya karena share jaringan terputus")
+	flag.DurationVar(&opts.readRetryDelay, "read-retry-delay", time.Second, "jeda sebelum percobaan ulang pertama membaca file, dilipatgandakan pada setiap percobaan berikutnya")
+	flag.StringVar(&opts.copyRemote, "copy-remote", "auto", "salin file Excel ke -tmp-dir sebelum diproses: auto (hanya file di share jaringan SMB/NFS), always atau off")
+	flag.IntVar(&opts.readRate, "read-rate", 0, "batasi laju baca file input gabungan semua worker dalam MB per detik, agar disk server bersama tidak habis dipakai konversi besar; file disalin dengan laju ini ke -tmp-dir lalu salinannya yang dikonversi (0 berarti tanpa batas)")
+	flag.IntVar(&opts.maxProcs, "max-procs", 0, "jumlah core CPU paling banyak yang dipakai bersamaan (GOMAXPROCS), 0 berarti semua core")
+	flag.IntVar(&opts.nice, "nice", 0, "jalankan program dengan prioritas CPU lebih rendah melalui nice, 1 sampai 19 (Linux)")
+	flag.StringVar(&opts.ionice, "ionice", "", "jalankan program dengan prioritas I/O lebih rendah melalui ionice: idle (hanya saat disk menganggur) atau best-effort[:0-7] (Linux)")
+	flag.DurationVar(&opts.stableWindow, "stable-window", time.Second, "file Excel baru diproses setelah ukuran dan waktu modifikasinya tidak berubah selama jeda ini, agar file yang masih disalin tidak dibaca (0 = nonaktif)")
+	flag.DurationVar(&opts.stableTimeout, "stable-timeout", 2*time.Minute, "batas waktu menunggu file Excel berhenti berubah sebelum file dilewati dengan status unstable")
+	flag.IntVar(&opts.inferRows, "infer-rows", 0, "tentukan tipe kolom dari sampel sejumlah baris ini, bukan dari seluruh baris (0 = semua baris)")
+	flag.StringVar(&opts.inferSample, "infer-sample", "first", "cara memilih sampel -infer-rows: first (baris-baris pertama) atau random (acak dari seluruh sheet)")
+	flag.BoolVar(&opts.numberFormats, "number-formats", true, "pakai format angka sel xlsx (tanggal, mata uang, persen, desimal tetap, teks) sebagai petunjuk tipe kolom dan tulis nilai mentah sel, bukan teks yang ditampilkan")
+	flag.BoolVar(&opts.fillMerged, "fill-merged", false, "isi setiap sel yang tertutup merged cell xlsx dengan nilai sel kiri atasnya sebelum inferensi dan INSERT, sehingga baris di bawah sel gabungan seperti nama wilayah atau tanggal tidak diimpor kosong")
+	flag.Float64Var(&opts.minConfidence, "min-confidence", 1, "proporsi minimal sel tidak kosong suatu kolom yang cocok dengan tipe angka, tanggal atau boolean agar kolom diberi tipe itu, misalnya 0.995; sel lain, biasanya salah ketik, ditulis NULL dan dicatat sebagai peringatan (default 1 = semua sel harus cocok)")
+	flag.StringVar(&opts.quarantine, "quarantine", "", "direktori tempat sel yang ditulis NULL karena -min-confidence dicatat sebagai <tabel>.csv berisi posisi sel, kolom, tipe, nilai asli dan saran perbaikan")
+	flag.StringVar(&opts.xmlRows, "xml-rows", "", "XPath elemen baris pada file XML, misalnya /data/record, atau nama elemennya saja (default: setiap anak elemen root)")
+	flag.StringVar(&opts.sheets, "sheets", "active", "sheet yang dikonversi dari setiap workbook: active (sheet aktif), all (setiap sheet yang tidak disembunyikan menjadi tabel <file><sheet>), atau daftar nama sheet, pola glob dan regex /.../ dipisahkan koma seperti \"Data*,Summary\" untuk sheet tidak tersembunyi yang cocok saja")
+	flag.IntVar(&opts.sheetWorkers, "sheet-workers", runtime.NumCPU(), "jumlah sheet satu workbook yang dikonversi bersamaan pada -sheets all")
+	flag.IntVar(&opts.headerRow, "header-row", 1, "nomor baris header (mulai 1) yang menjadi nama kolom; baris judul di atasnya diabaikan. Kolom manifest header_row menggantikan nilai ini")
+	flag.IntVar(&opts.headerRows, "header-rows", 1, "jumlah baris header bertingkat mulai dari -header-row yang digabung menjadi satu nama kolom, misalnya Sales di atas Q1 menjadi Sales|Q1 (sales_q1 pada -naming snake_case). Kolom manifest header_rows menggantikan nilai ini")
+	flag.IntVar(&opts.skipRows, "skip-rows", 0, "jumlah baris tepat di bawah header yang diabaikan sebelum baris data, misalnya baris satuan. Kolom manifest skip_rows menggantikan nilai ini")
+	flag.BoolVar(&opts.noHeader, "no-header", false, "input tanpa baris header, misalnya dump data mentah: baris -header-row sudah menjadi data dan kolom diberi nama col_1..col_n")
+	flag.StringVar(&opts.columnNames, "column-names", "", "nama kolom dipisahkan koma untuk -no-header, menggantikan col_1, col_2 dan seterusnya; kolom yang tidak disebutkan tetap diberi nama col_n")
+	flag.StringVar(&opts.cellRange, "range", "", "baca hanya rentang sel ini, misalnya B3:F100 atau Data!B3:F100, atau nama yang terdefinisi pada workbook xlsx (named range) seperti DataPenjualan; baris pertama rentang menjadi baris 1 untuk -header-row. Kolom manifest range menggantikan nilai ini")
+	flag.BoolVar(&opts.excelTables, "excel-tables", true, "baca setiap Excel Table (ListObject) pada sheet xlsx sebagai tabel database tersendiri bernama sesuai nama Table, beserta headernya dan tanpa baris total, bukan seluruh baris sheet; sheet tanpa Table tetap dibaca utuh. Diabaikan bila -range atau kolom manifest range diisi")
+	flag.StringVar(&opts.idColumn, "id-column", "", "nama kolom primary key AUTO_INCREMENT (default <tabel>_id)")
+	flag.StringVar(&opts.idType, "id-type", "INT", "tipe kolom primary key: TINYINT, SMALLINT, MEDIUMINT, INT atau BIGINT")
+	flag.BoolVar(&opts.idUnsigned, "id-unsigned", false, "buat kolom primary key UNSIGNED")
+	flag.StringVar(&opts.createMode, "create-mode", "plain", "perilaku CREATE TABLE bila tabel sudah ada: plain (gagal), if-not-exists (tabel dan isinya dipertahankan) atau replace (CREATE OR REPLACE TABLE)")
+	flag.BoolVar(&opts.timestamps, "timestamps", false, "tambahkan kolom created_at (DEFAULT CURRENT_TIMESTAMP) dan updated_at (juga ON UPDATE CURRENT_TIMESTAMP) di akhir setiap tabel")
+	flag.StringVar(&opts.naming, "naming", "compact", "bentuk nama tabel dan kolom: compact (huruf dan angka saja, kolom huruf kecil), snake_case, lowerCamel, atau original (teks asli dikutip dengan backtick)")
+	flag.StringVar(&opts.engine, "engine", "INNODB", "storage engine tabel, misalnya INNODB, Aria, MyISAM atau ColumnStore")
+	flag.StringVar(&opts.rowFormat, "row-format", "", "ROW_FORMAT tabel, misalnya DYNAMIC atau COMPRESSED (default bawaan engine)")
+	flag.IntVar(&opts.keyBlockSize, "key-block-size", 0, "KEY_BLOCK_SIZE tabel dalam KB (0 = bawaan engine)")
+	flag.StringVar(&opts.tableOptions, "table-options", "", "opsi tabel tambahan yang ditulis apa adanya setelah ENGINE, misalnya \"DEFAULT CHARSET=utf8mb4\"")
+	flag.BoolVar(&opts.columnstore, "columnstore", false, "bentuk tabel MariaDB ColumnStore (tanpa primary key dan indeks) dan tulis data sebagai file teks berbatas .tbl untuk cpimport")
+	flag.StringVar(&opts.dialect, "dialect", "mariadb", "database tujuan: mariadb, atau vertica, redshift dan postgres yang menghasilkan file CSV di SQLData dan perintah COPY di file SQLTable (tanpa pemuatan ke MariaDB)")
+	flag.StringVar(&opts.copyLocation, "copy-location", "", "awalan lokasi file CSV pada perintah COPY, misalnya s3://bucket/impor/ untuk -dialect redshift (default direktori -sql-data-dir)")
+	flag.StringVar(&opts.xlsxDir, "xlsx-dir", "xlsx", "direktori file input, relatif terhadap direktori kerja atau path absolut")
+	flag.StringVar(&opts.sqlTableDir, "sql-table-dir", "SQLTable", "direktori output file SQL pembuatan tabel, relatif terhadap direktori kerja atau path absolut")
+	flag.StringVar(&opts.sqlDataDir, "sql-data-dir", "SQLData", "direktori output file data, relatif terhadap direktori kerja atau path absolut")
+	flag.BoolVar(&opts.mirrorDirs, "mirror-dirs", false, "tulis output file di subdirektori -xlsx-dir ke subdirektori yang sama pada -sql-table-dir dan -sql-data-dir, dan baca output secara rekursif saat memuat")
+	flag.StringVar(&opts.ndjson, "ndjson", "", "kirim juga setiap baris data sebagai NDJSON: direktori untuk file <tabel>.ndjson, - untuk standard output, atau kafka://broker:9092[,broker2:9092]/topic (tanpa topic, nama tabel menjadi topic)")
+	flag.StringVar(&opts.kafkaKey, "kafka-key", "", "kolom (nama kolom atau teks header) yang nilainya menjadi key pesan Kafka pada -ndjson kafka://")
+	flag.StringVar(&opts.xlsxPassword, "xlsx-password", "", "password untuk membuka workbook xlsx terenkripsi, dapat juga diisi xlsx_password pada db.cfg")
+	flag.StringVar(&opts.passwords, "passwords", "", "file CSV berkolom file,password berisi password per workbook; kolom file berupa path atau pola nama file seperti keuangan_*.xlsx")
+	flag.StringVar(&opts.tenants, "tenants", "", "file CSV berkolom file,tenant: nilai tenant yang ditambahkan sebagai kolom tenant_id (dengan indeks) pada tabel dan setiap baris; kolom file berupa direktori input, path atau pola nama file seperti cabang_*.csv")
+	flag.StringVar(&opts.encryptColumns, "encrypt-columns", "", "kolom sensitif (nama kolom atau teks header, dipisahkan koma) yang disimpan terenkripsi: kolom dibuat VARBINARY/BLOB, nilainya ditulis dengan AES_ENCRYPT(nilai, @xlsx2sql_key) dan view <tabel>_decrypted dibuat untuk membacanya kembali. Kolom manifest encrypt menggantikan nilai ini")
+	flag.StringVar(&opts.encryptionKey, "encryption-key", "", "kunci AES untuk -encrypt-columns, sebaiknya diisi encryption_key pada db.cfg; kunci diisi ke @xlsx2sql_key pada setiap koneksi pemuatan dan tidak ditulis ke file SQL")
+	flag.StringVar(&opts.include, "include", "", "pola glob path relatif terhadap direktori xlsx (dipisahkan koma) untuk file yang diproses, misalnya **/*.xlsx; ** cocok dengan sejumlah subdirektori sehingga subdirektori ikut ditelusuri (default: workbook langsung di direktori xlsx)")
+	flag.StringVar(&opts.exclude, "exclude", "", "pola glob path relatif terhadap direktori xlsx (dipisahkan koma) untuk file dan direktori yang dilewati, misalnya **/~$* untuk file lock Excel")
+	flag.IntVar(&opts.workers, "workers", runtime.NumCPU(), "jumlah file Excel yang dikonversi bersamaan")
+	flag.IntVar(&opts.dbWorkers, "db-workers", 1, "jumlah file SQLData yang dimuat bersamaan ke setiap database")
+	flag.IntVar(&opts.loadChunks, "load-chunks", 1, "pecah tuple setiap file SQLData menjadi N potongan yang tidak beririsan dan muat bersamaan lewat N koneksi, untuk tabel sangat besar pada server yang masih longgar")
+	flag.IntVar(&opts.adaptiveWorkers, "adaptive-workers", 0, "batas atas batch INSERT yang dieksekusi bersamaan ke setiap database, diatur otomatis antara 1 dan N menurut beban server mulai dari -db-workers (0 = tetap -db-workers)")
+	flag.IntVar(&opts.maxThreadsRunning, "max-threads-running", 32, "batas Threads_running server untuk -adaptive-workers")
+	flag.DurationVar(&opts.maxReplicaLag, "max-replica-lag", 30*time.Second, "batas Seconds_Behind_Master replika -replica untuk -adaptive-workers")
+	flag.IntVar(&opts.maxHistoryLength, "max-history-length", 1000000, "batas panjang history list InnoDB (trx_rseg_history_len) untuk -adaptive-workers")
+	flag.DurationVar(&opts.loadCheckInterval, "load-check-interval", 5*time.Second, "selang pemeriksaan beban server untuk -adaptive-workers")
+	flag.BoolVar(&opts.provenance, "provenance", true, "awali setiap file SQL dengan komentar asal file: versi program, file sumber, sheet, jumlah baris, hash konfigurasi dan waktu pembuatan")
+	flag.IntVar(&opts.warnColumns, "warn-columns", 200, "peringatkan tabel dengan kolom lebih dari N (0 = tanpa peringatan lebar tabel)")
+	flag.Float64Var(&opts.warnGrowth, "warn-growth", 100, "peringatkan tabel yang perkiraan ukurannya di InnoDB lebih dari N kali ukuran file sumber (0 = tanpa peringatan)")
+	flag.StringVar(&opts.indexAdvisor, "index-advisor", "", "file SQL saran indeks: setelah pemuatan, kardinalitas kolom setiap tabel dianalisis untuk menyarankan indeks, menggantikan indeks bawaan pada kolom data pertama")
+	flag.BoolVar(&opts.indexApply, "index-apply", false, "jalankan juga saran -index-advisor pada setiap database tujuan")
+	flag.IntVar(&opts.indexSample, "index-sample", 100000, "jumlah baris pertama setiap tabel yang dianalisis -index-advisor (0 = semua baris)")
+	flag.IntVar(&opts.benchRows, "bench-rows", 100000, "jumlah baris data setiap workbook sintetis pada perintah bench")
+	flag.IntVar(&opts.benchCols, "bench-cols", 10, "jumlah kolom setiap workbook sintetis pada perintah bench")
+	flag.IntVar(&opts.benchFiles, "bench-files", 4, "jumlah workbook sintetis pada perintah bench")
+	flag.Parse()
+}
+
+// messageCatalogs memetakan teks pesan sumber (bahasa Indonesia) ke
+// terjemahannya per bahasa. Pesan yang tidak ada pada katalog ditampilkan apa adanya.
+var messageCatalogs = map[string]map[string]string{
+	"en": englishMessages,
+}
+
+var englishMessages = map[string]string{
+	// log dan konversi file Excel
+	"Error menulis ke file error log: %v":   "Error writing to error log file: %v",
+	"Error merotasi file log %s: %v":        "Error rotating log file %s: %v",
+	"Error membaca file %s":                 "Error reading file %s",
+	"Error mendapatkan baris pada sheet %s": "Error getting rows of sheet %s",
+	"Error menulis ke file SQL untuk %s":    "Error writing SQL file for %s",
+	"Peringatan: tabel %s memiliki %d kolom, melebihi batas InnoDB %d kolom sehingga CREATE TABLE akan gagal; pecah sheet menjadi beberapa tabel atau ubah kolom berulang (misalnya per bulan) menjadi baris":                                                                                             "Warning: table %s has %d columns, more than the InnoDB limit of %d columns, so CREATE TABLE will fail; split the sheet into several tables or turn repeated columns (such as one per month) into rows",
+	"Peringatan: tabel %s sangat lebar (%d kolom); pertimbangkan memecahnya menjadi beberapa tabel atau mengubah kolom berulang (misalnya per bulan) menjadi baris":                                                                                                                                       "Warning: table %s is very wide (%d columns); consider splitting it into several tables or turning repeated columns (such as one per month) into rows",
+	"Peringatan: ukuran baris tabel %s menurut definisi kolom %d byte melebihi batas MariaDB %d byte sehingga CREATE TABLE akan gagal (Row size too large); ubah kolom VARCHAR panjang menjadi TEXT atau kurangi jumlah kolom":                                                                            "Warning: the declared row size of table %s is %d bytes, more than the MariaDB limit of %d bytes, so CREATE TABLE will fail (Row size too large); change long VARCHAR columns to TEXT or reduce the number of columns",
+	"Peringatan: perkiraan ukuran tabel %s sampai %.1f MB, %.0f kali file sumber, karena nilai %d kolom teks panjang disimpan di halaman overflow %d KB masing-masing; pertimbangkan VARCHAR untuk kolom teks yang pendek, memindahkan kolom teks panjang ke tabel terpisah, atau -row-format COMPRESSED": "Warning: table %s may take up to %.1f MB, %.0f times the source file, because the values of %d long text columns are stored on %d KB overflow pages of their own; consider VARCHAR for short text columns, moving long text columns to a separate table, or -row-format COMPRESSED",
+	"Error menulis data ke file SQL untuk %s":          "Error writing data to SQL file for %s",
+	"%s - %v - %s - %.2f%% selesai":                    "%s - %v - %s - %.2f%% done",
+	"Error menulis ke file read.log: %v":               "Error writing to read.log: %v",
+	"File: %s, Status: %s, Durasi: %v, %.2f%% selesai": "File: %s, Status: %s, Duration: %v, %.2f%% done",
+	"Error menulis ke file run.log: %v":                "Error writing to run.log: %v",
+
+	// konfigurasi
+	"baris konfigurasi tidak valid: %q": "invalid configuration line: %q",
+	"nilai %q untuk %s tidak valid: %v": "invalid value %q for %s: %v",
+
+	// koneksi database dan pembuatan tabel
+	"Koneksi database terputus (%v), mencoba ulang (%d/%d)": "Database connection lost (%v), retrying (%d/%d)",
+	"Deadlock pada database (%v), mencoba ulang (%d/%d)":    "Database deadlock (%v), retrying (%d/%d)",
+	"Melanjutkan %s dalam %d potongan":                      "Resuming %s in %d chunks",
+	"Gagal membuka ulang koneksi ke database":               "Failed to reopen the database connection",
+	"Mulai memproses file %s":                               "Started processing file %s",
+	"Selesai memproses file %s":                             "Finished processing file %s",
+	"Gagal membaca file SQL pembuatan tabel pada direktori": "Failed to read the table creation SQL files in the directory",
+
+	// database tujuan
+	"Target %s: tabel %d sukses, %d gagal; file data %d sukses, %d gagal": "Target %s: tables %d succeeded, %d failed; data files %d succeeded, %d failed",
+	"Membuat tabel pada target %s":                                        "Creating tables on target %s",
+	"Mengisi data pada target %s":                                         "Loading data into target %s",
+
+	// audit file INSERT
+	"escape tidak lengkap pada akhir string":            "incomplete escape at end of string",
+	"string tidak ditutup":                              "unterminated string",
+	"nama dengan backtick tidak ditutup":                "unterminated backtick-quoted name",
+	"literal angka tidak valid: %q":                     "invalid numeric literal: %q",
+	"karakter tidak terduga %q":                         "unexpected character %q",
+	"diharapkan %s, ditemukan %q":                       "expected %s, found %q",
+	"diharapkan %q, ditemukan %q":                       "expected %q, found %q",
+	"diharapkan INSERT, ditemukan %q":                   "expected INSERT, found %q",
+	"nama tabel %q tidak sesuai dengan %q":              "table name %q does not match %q",
+	"diharapkan nama kolom, ditemukan %q":               "expected column name, found %q",
+	"diharapkan \",\" atau \")\", ditemukan %q":         "expected \",\" or \")\", found %q",
+	"nilai bukan literal: %q":                           "value is not a literal: %q",
+	"diharapkan literal, ditemukan %q":                  "expected literal, found %q",
+	"jumlah nilai %d tidak sama dengan jumlah kolom %d": "value count %d does not match column count %d",
+	"diharapkan \",\" atau \";\", ditemukan %q":         "expected \",\" or \";\", found %q",
+	"tidak ada pernyataan INSERT":                       "no INSERT statement",
+
+	// pengisian data
+	"Paket terlalu besar untuk %d baris, ukuran batch diturunkan menjadi %d baris": "Packet too large for %d rows, batch size reduced to %d rows",
+	"Gagal membaca direktori SQLData: %v":                                          "Failed to read the SQLData directory: %v",
+	"Gagal menganalisis kardinalitas kolom tabel %s":                               "Failed to analyze the column cardinality of table %s",
+	"Gagal menulis saran indeks ke %s":                                             "Failed to write index advice to %s",
+	"%d saran indeks ditulis ke %s":                                                "%d index suggestions written to %s",
+	"Gagal menjalankan saran indeks pada %s: %s":                                   "Failed to apply index advice on %s: %s",
+	"Saran indeks dijalankan pada %s: %s":                                          "Index advice applied on %s: %s",
+	"Gagal membaca file %s: %v":                                                    "Failed to read file %s: %v",
+	"Audit file %s gagal, file tidak dieksekusi: %v":                               "Audit of file %s failed, file not executed: %v",
+	"Ping ke database gagal, koneksi akan dibuka ulang saat eksekusi":              "Database ping failed, the connection will be reopened on execution",
+	"Gagal mengeksekusi file %s: %v":                                               "Failed to execute file %s: %v",
+	"Sukses mengeksekusi file %s dalam waktu %s":                                   "Successfully executed file %s in %s",
+
+	// statistik impor
+	"Gagal menulis statistik tabel untuk %s":  "Failed to write table statistics for %s",
+	"Gagal mencatat statistik impor untuk %s": "Failed to record import statistics for %s",
+
+	// deteksi anomali
+	"Gagal membaca statistik tabel %s":                                                     "Failed to read table statistics for %s",
+	"Gagal memeriksa anomali untuk %s":                                                     "Failed to check anomalies for %s",
+	"Anomali terdeteksi pada %s (target %s)":                                               "Anomaly detected in %s (target %s)",
+	"Data %s menyimpang dari impor sebelumnya. Tetap muat ke %s? (Ya/Tidak, default Ya): ": "Data in %s deviates from previous imports. Load into %s anyway? (Yes/No, default Yes): ",
+	"Pemuatan %s ke %s ditahan karena anomali":                                             "Loading %s into %s held because of anomalies",
+	"jumlah baris %d menyimpang %.0f%% dari rata-rata %.0f":                                "row count %d deviates %.0f%% from the average %.0f",
+	"rasio NULL kolom %s %.1f%% berbeda dari rata-rata %.1f%%":                             "NULL rate of column %s %.1f%% differs from the average %.1f%%",
+
+	// perubahan kolom
+	"Gagal memeriksa perubahan kolom untuk %s":                                             "Failed to check column changes for %s",
+	"Kolom %s berubah dari impor sebelumnya (skema %s -> %s): %s":                          "Columns of %s changed since the previous import (schema %s -> %s): %s",
+	"Perubahan kolom terdeteksi pada target %s":                                            "Column changes detected on target %s",
+	"Gagal menyesuaikan kolom tabel %s":                                                    "Failed to adjust the columns of table %s",
+	"Kolom tabel %s pada %s disesuaikan otomatis":                                          "Columns of table %s on %s adjusted automatically",
+	"Setujui perubahan kolom %s dan lanjutkan pemuatan ke %s? (Ya/Tidak, default Tidak): ": "Approve the column changes of %s and continue loading into %s? (Yes/No, default No): ",
+	"Pemuatan %s ke %s ditahan karena perubahan kolom":                                     "Loading %s into %s held because of column changes",
+
+	// file state
+	"Gagal menyimpan file state %s": "Failed to save state file %s",
+	"Gagal membaca file state %s":   "Failed to read state file %s",
+
+	// jadwal
+	"Jadwal -schedule tidak valid: %v":                "Invalid -schedule: %v",
+	"jadwal harus terdiri dari 5 kolom, ditemukan %d": "schedule must have 5 fields, found %d",
+	"langkah tidak valid pada %q":                     "invalid step in %q",
+	"nilai tidak valid pada %q":                       "invalid value in %q",
+	"nilai di luar rentang %d-%d pada %q":             "value out of range %d-%d in %q",
+	"Jadwal -schedule tidak pernah tercapai.":         "The -schedule never fires.",
+	"Run berikutnya dijadwalkan pada %s.":             "Next run scheduled at %s.",
+	"Run terjadwal %s dimulai.":                       "Scheduled run %s started.",
+	"Run terjadwal %s selesai.":                       "Scheduled run %s finished.",
+	"Run terjadwal %s berhenti sebelum selesai.":      "Scheduled run %s stopped before finishing.",
+
+	// mode pantau
+	"Opsi -watch-load memerlukan -checkpoint.":            "The -watch-load option requires -checkpoint.",
+	"Gagal memantau direktori %s":                         "Failed to watch directory %s",
+	"Memantau direktori %s, tekan Ctrl+C untuk berhenti.": "Watching directory %s, press Ctrl+C to stop.",
+
+	// pre-scan
+	"metadata dimensi sheet tidak tersedia (%q)":  "sheet dimension metadata not available (%q)",
+	"Pre-scan %s gagal":                           "Pre-scan of %s failed",
+	"Pre-scan %s: %d baris, %d kolom":             "Pre-scan %s: %d rows, %d columns",
+	"Pre-scan selesai: %d file, %d baris, %d sel": "Pre-scan finished: %d files, %d rows, %d cells",
+
+	// server API
+	"Server API berjalan pada %s":                   "API server listening on %s",
+	"Gagal menjalankan server API pada %s":          "Failed to start the API server on %s",
+	"hanya file .xlsx, .xls dan .ods yang diterima": "only .xlsx, .xls and .ods files are accepted",
+	"Gagal menyimpan file unggahan %s":              "Failed to save uploaded file %s",
+	"File %s diunggah lewat API":                    "File %s uploaded through the API",
+	"jenis pekerjaan %q tidak dikenal":              "unknown job kind %q",
+	"antrean pekerjaan penuh":                       "job queue is full",
+	"pekerjaan %s tidak ditemukan":                  "job %s not found",
+	"file %s tidak ditemukan":                       "file %s not found",
+
+	// email
+	"Run %s selesai dengan sukses.":         "Run %s finished successfully.",
+	"Run %s selesai dengan kode keluar %d.": "Run %s finished with exit code %d.",
+	"File %s: %d":                           "Files %s: %d",
+	"Tabel: %d, baris dimuat: %d":           "Tables: %d, rows loaded: %d",
+	"Error: %d":                             "Errors: %d",
+	"Gagal mengirim email ringkasan run":    "Failed to send the run summary email",
+	"sukses":                                "success",
+	"gagal (kode %d)":                       "failed (code %d)",
+
+	// webhook
+	"Gagal mengirim ringkasan run ke webhook":          "Failed to send the run summary to the webhook",
+	"xlsx2mariadb run %s: file %s gagal (%s)":          "xlsx2mariadb run %s: file %s failed (%s)",
+	"xlsx2mariadb run %s: tabel %s gagal dimuat ke %s": "xlsx2mariadb run %s: table %s failed to load into %s",
+	"Gagal mengirim notifikasi %s ke webhook":          "Failed to send the notification for %s to the webhook",
+
+	// laporan run
+	"Gagal menulis laporan run ke %s": "Failed to write the run report to %s",
+
+	// manifest
+	"Gagal membaca manifest %s":                                         "Failed to read manifest %s",
+	"header_row %q tidak valid untuk %s":                                "invalid header_row %q for %s",
+	"header_rows %q tidak valid untuk %s":                               "invalid header_rows %q for %s",
+	"skip_rows %q tidak valid untuk %s":                                 "invalid skip_rows %q for %s",
+	"baris manifest tanpa kolom file":                                   "manifest row without a file column",
+	"mode %q tidak dikenal untuk %s":                                    "unknown mode %q for %s",
+	"priority %q tidak valid untuk %s":                                  "invalid priority %q for %s",
+	"dependensi %q untuk %s tidak ada pada manifest":                    "dependency %q of %s is not in the manifest",
+	"dependensi melingkar pada manifest: %s":                            "circular dependency in manifest: %s",
+	"tabel dependensi %s gagal":                                         "dependency table %s failed",
+	"File %s tidak diproses":                                            "File %s not processed",
+	"Pemuatan %s ke %s ditahan karena tabel dependensi %s gagal dimuat": "Loading %s into %s held because dependency table %s failed to load",
+
+	// argumen file
+	"Gagal membaca standard input":                                                           "Failed to read standard input",
+	"Gagal mengambil Google Sheets %s":                                                       "Failed to fetch Google Sheets %s",
+	"Google Sheets %s diambil ke %s":                                                         "Google Sheets %s fetched to %s",
+	"Gagal mengambil input %s":                                                               "Failed to fetch input %s",
+	"Objek s3://%s/%s diunduh ke %s":                                                         "Object s3://%s/%s downloaded to %s",
+	"%q bukan URL s3://bucket/awalan":                                                        "%q is not an s3://bucket/prefix URL",
+	"Tidak ada file input pada s3://%s/%s":                                                   "No input files at s3://%s/%s",
+	"Tujuan -upload %s tidak valid":                                                          "Invalid -upload destination %s",
+	"Gagal mengunggah file SQL untuk %s":                                                     "Failed to upload SQL files for %s",
+	"Gagal mengambil file SFTP %s":                                                           "Failed to fetch SFTP files %s",
+	"Gagal mengunduh %s":                                                                     "Failed to download %s",
+	"Gagal mengekstrak arsip ZIP %s":                                                         "Failed to extract ZIP archive %s",
+	"arsip ZIP %s tidak berisi file input":                                                   "ZIP archive %s contains no input files",
+	"URL %s diunduh ke %s":                                                                   "URL %s downloaded to %s",
+	"URL %s tidak berubah, memakai salinan %s":                                               "URL %s not modified, using copy %s",
+	"%q bukan URL sftp://user@host/direktori":                                                "%q is not an sftp://user@host/directory URL",
+	"File SFTP %s diunduh ke %s":                                                             "SFTP file %s downloaded to %s",
+	"Tidak ada file baru pada %s.":                                                           "No new files at %s.",
+	"File SFTP %s dipindahkan ke %s":                                                         "SFTP file %s moved to %s",
+	"Gagal memindahkan file SFTP %s ke %s":                                                   "Failed to move SFTP file %s to %s",
+	"private key untuk -sftp tidak ditemukan, isi -sftp-key":                                 "private key for -sftp not found, set -sftp-key",
+	"Perintah atau file %q tidak dikenal.":                                                   "Unknown command or file %q.",
+	"Gagal menyalin %s ke direktori sementara":                                               "Failed to copy %s to the temporary directory",
+	"Gagal membuat direktori output untuk %s":                                                "Failed to create the output directories for %s",
+	"File %s dilewati karena masih berubah":                                                  "File %s skipped because it is still changing",
+	"Opsi kolom id tidak valid":                                                              "Invalid id column option",
+	"tipe %q tidak didukung, gunakan salah satu dari %s":                                     "unsupported type %q, use one of %s",
+	"Nilai -create-mode %q tidak dikenal, gunakan plain, if-not-exists atau replace.":        "Unknown -create-mode value %q, use plain, if-not-exists or replace.",
+	"Nilai -lock %q tidak dikenal, gunakan abort, wait atau off.":                            "Unknown -lock value %q, use abort, wait or off.",
+	"Nilai -naming %q tidak dikenal, gunakan compact, snake_case, lowerCamel atau original.": "Unknown -naming value %q, use compact, snake_case, lowerCamel or original.",
+	"Pola glob %q tidak valid.":                                                              "Invalid glob pattern %q.",
+	"Nilai -load-chunks harus paling sedikit 1.":                                             "The -load-chunks value must be at least 1.",
+	"Nilai -header-row dan -header-rows harus paling sedikit 1 dan -skip-rows tidak boleh negatif.":         "The -header-row and -header-rows values must be at least 1 and -skip-rows must not be negative.",
+	"Opsi -column-names hanya berlaku dengan -no-header.":                                                   "The -column-names option only applies with -no-header.",
+	"Nilai -min-confidence harus lebih dari 0 dan paling besar 1.":                                          "The -min-confidence value must be greater than 0 and at most 1.",
+	"%s: %d sel tidak sesuai tipe kolomnya (-min-confidence) dan ditulis NULL":                              "%s: %d cells do not match their column type (-min-confidence) and were written as NULL",
+	"Gagal menulis karantina sel untuk %s":                                                                  "Failed to write the cell quarantine for %s",
+	"Sel yang ditulis NULL pada %s dicatat di %s":                                                           "Cells written as NULL in %s are recorded in %s",
+	"Nama kolom -column-names tidak boleh kosong.":                                                          "Column names in -column-names must not be empty.",
+	"Nilai -read-rate dan -max-procs tidak boleh negatif.":                                                  "-read-rate and -max-procs must not be negative.",
+	"Nilai -nice harus antara 0 dan 19.":                                                                    "-nice must be between 0 and 19.",
+	"Nilai -ionice %q tidak dikenal, gunakan idle atau best-effort[:0-7].":                                  "Unknown -ionice value %q, use idle or best-effort[:0-7].",
+	"Gagal menurunkan prioritas proses: %v":                                                                 "Failed to lower the process priority: %v",
+	"Opsi -encrypt-columns hanya berlaku untuk pernyataan INSERT MariaDB, tanpa -columnstore dan -dialect.": "The -encrypt-columns option only applies to MariaDB INSERT statements, without -columnstore and -dialect.",
+	"Kolom terenkripsi membutuhkan -encryption-key atau encryption_key pada db.cfg.":                        "Encrypted columns require -encryption-key or encryption_key in db.cfg.",
+	"Nilai -sheets %q tidak valid: %v":                                                                      "Invalid -sheets value %q: %v",
+	"tidak ada pola sheet":                                                                                  "no sheet patterns",
+	"Tidak ada sheet %s yang cocok dengan -sheets %q":                                                       "No sheet of %s matches -sheets %q",
+	"Nilai -adaptive-workers tidak boleh negatif dan -load-check-interval harus lebih dari 0.":              "The -adaptive-workers value must not be negative and -load-check-interval must be greater than 0.",
+	"Konkurensi pemuatan %s diturunkan dari %d menjadi %d: %s":                                              "Load concurrency for %s lowered from %d to %d: %s",
+	"Konkurensi pemuatan %s dinaikkan dari %d menjadi %d":                                                   "Load concurrency for %s raised from %d to %d",
+	"Konkurensi pemuatan %s diatur otomatis antara 1 dan %d, mulai dari %d":                                 "Load concurrency for %s is adjusted automatically between 1 and %d, starting at %d",
+	"Indikator beban %s tidak dapat dibaca dari %s, diabaikan":                                              "Load indicator %s cannot be read from %s, ignored",
+	"collation %q tidak valid untuk %s":                                                                     "invalid collation %q for %s",
+	"collation %q tidak valid":                                                                              "invalid collation %q",
+	"collation hanya dapat diatur pada kolom teks, bukan %s %s":                                             "collation can only be set on text columns, not %s %s",
+	"Gagal menjalankan endpoint pprof pada %s":                                                              "Failed to start the pprof endpoint on %s",
+	"Endpoint pprof tersedia pada %s/debug/pprof/":                                                          "pprof endpoint available at %s/debug/pprof/",
+	"Gagal membuat file trace %s":                                                                           "Failed to create trace file %s",
+	"Header %s tidak sesuai kontrak headers pada manifest":                                                  "Header of %s does not match the headers contract in the manifest",
+	"baris data %d":                         "data row %d",
+	"%s kolom %s nilai %s":                  "%s column %s value %s",
+	"saran: %s":                             "suggestion: %s",
+	"Server gRPC berjalan pada %s":          "gRPC server listening on %s",
+	"Gagal menjalankan server gRPC pada %s": "Failed to run gRPC server on %s",
+	"Workbook xls %s dilindungi password; simpan ulang tanpa password atau sebagai xlsx":          "The xls workbook %s is password protected; save it again without a password or as xlsx",
+	"Gagal mengirim baris NDJSON untuk %s":                                                        "Failed to send NDJSON rows for %s",
+	"broker Kafka tidak disebutkan":                                                               "no Kafka broker given",
+	"kolom key %s tidak ada pada tabel %s":                                                        "key column %s does not exist in table %s",
+	"Opsi -stdout dan -ndjson - tidak dapat dipakai bersamaan.":                                   "The -stdout and -ndjson - options cannot be used together.",
+	"Tujuan -ndjson %s tidak valid":                                                               "Invalid -ndjson destination %s",
+	"Nilai -dialect %q tidak dikenal, gunakan mariadb, vertica, redshift atau postgres.":          "Unknown -dialect value %q, use mariadb, vertica, redshift or postgres.",
+	"Opsi -columnstore hanya dapat dipakai dengan -dialect mariadb.":                              "The -columnstore option can only be used with -dialect mariadb.",
+	"File untuk %s ditulis di SQLTable dan SQLData; jalankan file SQLTable pada database tujuan.": "Files for %s written to SQLTable and SQLData; run the SQLTable files on the target database.",
+	"Opsi -watch-load hanya dapat dipakai dengan -dialect mariadb.":                               "The -watch-load option can only be used with -dialect mariadb.",
+	"file password harus memiliki kolom file dan password":                                        "the password file must have file and password columns",
+	"Password workbook %s salah atau tidak diisi (lihat -xlsx-password dan -passwords)":           "Workbook %s password is wrong or missing (see -xlsx-password and -passwords)",
+	"Gagal membaca file password %s":                                                              "Failed to read password file %s",
+	"file tenant harus memiliki kolom file dan tenant":                                            "the tenant file must have file and tenant columns",
+	"Gagal membaca file tenant %s":                                                                "Failed to read tenant file %s",
+	"tenant %q untuk %s tidak valid: harus diisi dan paling banyak 64 karakter":                   "tenant %q for %s is invalid: it must be non-empty and at most 64 characters",
+	"Penggunaan: xlsx2mariadb [-bench-rows N] [-bench-cols N] [-bench-files N] bench [load]":      "Usage: xlsx2mariadb [-bench-rows N] [-bench-cols N] [-bench-files N] bench [load]",
+	"Penggunaan: xlsx2mariadb [-report DIR] runs list | show <run id> | diff <run id> <run id>":   "Usage: xlsx2mariadb [-report DIR] runs list | show <run id> | diff <run id> <run id>",
+	"Gagal membaca riwayat run di %s":                                                             "Failed to read run history in %s",
+	"run %q tidak ditemukan":                                                                      "run %q not found",
+	"Belum ada run yang tercatat di %s.":                                                          "No runs recorded in %s yet.",
+	"RUN\tMULAI\tDURASI\tFILE\tGAGAL\tTABEL\tBARIS":                                               "RUN\tSTARTED\tDURATION\tFILES\tFAILED\tTABLES\tROWS",
+	"Run %s: %s - %s (%s)":                                                                        "Run %s: %s - %s (%s)",
+	"FILE\tSTATUS\tDURASI":                                                                        "FILE\tSTATUS\tDURATION",
+	"TABEL\tSUMBER\tBARIS\tDIMUAT\tDITOLAK":                                                       "TABLE\tSOURCE\tROWS\tLOADED\tREJECTED",
+	"+ file %s (%s)":                                                                              "+ file %s (%s)",
+	"~ file %s: %s -> %s":                                                                         "~ file %s: %s -> %s",
+	"- file %s (%s)":                                                                              "- file %s (%s)",
+	"+ tabel %s (%d baris)":                                                                       "+ table %s (%d rows)",
+	"- tabel %s (%d baris)":                                                                       "- table %s (%d rows)",
+	"~ tabel %s: %d -> %d baris (%+d)":                                                            "~ table %s: %d -> %d rows (%+d)",
+	"~ tabel %s: %d -> %d baris dimuat (%+d)":                                                     "~ table %s: %d -> %d rows loaded (%+d)",
+	"~ tabel %s: kolom baru %s %s":                                                                "~ table %s: new column %s %s",
+	"~ tabel %s: tipe kolom %s %s -> %s":                                                          "~ table %s: column %s type %s -> %s",
+	"~ tabel %s: kolom %s dihapus":                                                                "~ table %s: column %s removed",
+	"Perbedaan run %s terhadap run %s:":                                                           "Differences of run %s from run %s:",
+	"Tidak ada perbedaan.":                                                                        "No differences.",
+	"Gagal membuat direktori sementara":                                                           "Failed to create a temporary directory",
+	"Membuat %d workbook sintetis: %d baris x %d kolom":                                           "Creating %d synthetic workbooks: %d rows x %d columns",
+	"Gagal membuat workbook sintetis":                                                             "Failed to create a synthetic workbook",
+	"Benchmark konversi gagal":                                                                    "Conversion benchmark failed",
+	"Benchmark pemuatan gagal":                                                                    "Load benchmark failed",
+	"Konversi":                                                                                    "Conversion",
+	"Pemuatan":                                                                                    "Load",
+	"%s: %d baris dalam %s, %.0f baris/detik, %.1f MB/detik xlsx, %.1f MB/detik SQL":              "%s: %d rows in %s, %.0f rows/sec, %.1f MB/sec xlsx, %.1f MB/sec SQL",
+	"key_block_size %q tidak valid untuk %s":                                                      "invalid key_block_size %q for %s",
+	"engine %q tidak valid":                                                                       "invalid engine %q",
+	"row format %q tidak valid":                                                                   "invalid row format %q",
+	"opsi tabel tidak boleh berisi titik koma":                                                    "table options must not contain a semicolon",
+	"Opsi tabel tidak valid":                                                                      "Invalid table options",
+	"%s: %v, tipe kolom ditentukan ulang dari seluruh baris":                                      "%s: %v, inferring column types again from all rows",
+	"Gagal membaca %s (%v), mencoba ulang (%d/%d)":                                                "Failed to read %s (%v), retrying (%d/%d)",
+	"%s: hanya %d dari %d byte tersalin":                                                          "%s: only %d of %d bytes copied",
+	"File %s disebutkan lebih dari sekali, hanya diproses sekali":                                 "File %s is listed more than once, processing it once",
+	"File %s dan %s menghasilkan tabel yang sama (%s)":                                            "Files %s and %s produce the same table (%s)",
+	"Ganti nama salah satu file atau atur kolom table pada manifest":                              "Rename one of the files or set the table column in the manifest",
+
+	// kode keluar
+	"Run terjadwal %s selesai dengan kode keluar %d.": "Scheduled run %s finished with exit code %d.",
+
+	// stdout
+	"Error menulis SQL ke standard output untuk %s": "Error writing SQL to standard output for %s",
+
+	// layanan
+	"Program sudah berjalan dengan PID %d (file %s).":           "The program is already running with PID %d (file %s).",
+	"Penggunaan: xlsx2mariadb [opsi] service install|uninstall": "Usage: xlsx2mariadb [options] service install|uninstall",
+	"Gagal menjalankan service %s":                              "Failed to run service %s",
+	"Layanan %s berhasil di-%s.":                                "Service %s %sed successfully.",
+
+	// lock run
+	"File lock %s dari PID %d yang sudah berhenti diambil alih.":               "Took over lock file %s from stopped PID %d.",
+	"Run lain (PID %d di %s, run %s) sedang memproses direktori ini sejak %s.": "Another run (PID %d on %s, run %s) has been processing this directory since %s.",
+	"Menunggu run tersebut selesai...":                                         "Waiting for it to finish...",
+	"Menunggu lock database %s":                                                "Waiting for the database lock on %s",
+	"Run lain sedang memuat data ke database %s.":                              "Another run is loading data into database %s.",
+	"Gagal mengambil lock database":                                            "Failed to acquire the database lock",
+	"Gagal mengambil lock direktori kerja":                                     "Failed to acquire the working directory lock",
+
+	// kompresi file data
+	"file data tabel %s tidak ditemukan": "data file for table %s not found",
+
+	// skema lewat web
+	"Skema tabel %s diubah lewat API":               "Schema of table %s changed through the API",
+	"jumlah kolom tidak sama dengan skema tabel %s": "column count does not match the schema of table %s",
+	"urutan kolom tabel %s tidak boleh diubah":      "columns of table %s must not be reordered",
+	"nama kolom %q tidak valid":                     "invalid column name %q",
+	"tipe kolom %q tidak valid":                     "invalid column type %q",
+
+	// metrik
+	"Gagal menjalankan endpoint metrik pada %s": "Failed to start the metrics endpoint on %s",
+	"Endpoint metrik tersedia pada %s/metrics":  "Metrics endpoint available at %s/metrics",
+
+	// pratinjau
+	"Gagal menulis file pratinjau untuk %s": "Failed to write the preview file for %s",
+
+	// persetujuan pemuatan
+	"Gagal menulis file review untuk %s":                                              "Failed to write the review file for %s",
+	"pengguna %q tidak berwenang menyetujui pemuatan":                                 "user %q is not authorized to approve loading",
+	"Gagal menentukan akun sistem penyetuju":                                          "Failed to determine the approver's system account",
+	"file token penyetuju harus memiliki kolom user dan token":                        "the approver token file must have user and token columns",
+	"token penyetuju %q harus paling sedikit %d karakter":                             "approver token for %q must be at least %d characters",
+	"Gagal membaca file token penyetuju %s":                                           "Failed to read approver token file %s",
+	"persetujuan membutuhkan token penyetuju yang valid pada header X-Approver-Token": "approval requires a valid approver token in the X-Approver-Token header",
+	"Nilai -api-token harus paling sedikit %d karakter.":                              "-api-token must be at least %d characters.",
+	"Server pada %s dapat dijangkau dari jaringan; isi -api-token atau api_token pada db.cfg, atau pakai alamat loopback seperti localhost:8080.": "The server on %s is reachable from the network; set -api-token or api_token in db.cfg, or use a loopback address such as localhost:8080.",
+	"token API tidak valid":                              "invalid API token",
+	"host %s tidak diizinkan tanpa -api-token":           "host %s is not allowed without -api-token",
+	"permintaan dari origin lain ditolak":                "cross-origin request rejected",
+	"Gagal membaca file persetujuan %s":                  "Failed to read approvals file %s",
+	"Penggunaan: xlsx2mariadb [opsi] approve <tabel>...": "Usage: xlsx2mariadb [options] approve <table>...",
+	"Gagal menyetujui tabel %s":                          "Failed to approve table %s",
+	"Tabel %s disetujui oleh %s":                         "Table %s approved by %s",
+	"Tabel %s belum disetujui, pembuatan tabel dilewati": "Table %s is not approved yet, table creation skipped",
+	"Tabel %s belum disetujui, pemuatan data dilewati":   "Table %s is not approved yet, data loading skipped",
+
+	// checkpoint
+	"Gagal menyimpan checkpoint %s":                          "Failed to save checkpoint %s",
+	"Gagal membuka checkpoint %s":                            "Failed to open checkpoint %s",
+	"File %s sudah dieksekusi pada run sebelumnya, dilewati": "File %s was executed in a previous run, skipped",
+	"Melanjutkan %s dari tuple ke-%d":                        "Resuming %s from tuple %d",
+
+	// verifikasi
+	"Verifikasi %s pada %s dilewati, file data tidak dapat diurai":                            "Verification of %s on %s skipped, data file could not be parsed",
+	"Verifikasi %s pada %s gagal":                                                             "Verification of %s on %s failed",
+	"Verifikasi %s pada %s: %d baris diharapkan, %d baris ditemukan, %d dari %d sampel cocok": "Verification of %s on %s: %d rows expected, %d rows found, %d of %d samples matched",
+	"Verifikasi tidak cocok":                                                                  "Verification mismatch",
+	"replika belum menyusul posisi GTID %s dalam %v":                                          "replica did not reach GTID position %s within %v",
+	"Gagal membaca file konfigurasi replika %s":                                               "Failed to read replica configuration file %s",
+	"Gagal membuat koneksi ke replika":                                                        "Failed to connect to the replica",
+	"Menunggu replika menyusul primary":                                                       "Waiting for the replica to catch up with the primary",
+	"Replika tidak menyusul, verifikasi tetap dijalankan":                                     "Replica did not catch up, verification runs anyway",
+
+	// penghentian program
+	"Sinyal %v diterima, menghentikan proses yang sedang berjalan (tekan sekali lagi untuk berhenti paksa)...": "Signal %v received, stopping running work (press again to force quit)...",
+	"Sinyal kedua diterima, program dihentikan paksa.":                                                         "Second signal received, program forcibly stopped.",
+	"Program dihentikan oleh sinyal: %d dari %d file selesai diproses.":                                        "Program stopped by signal: %d of %d files processed.",
+
+	// alur utama program
+	"Gagal membaca opsi dari file konfigurasi: %v":                                                      "Failed to read options from the configuration file: %v",
+	"Direktori kerja tidak dapat disiapkan: %v":                                                         "Working directories could not be prepared: %v",
+	"Program mulai bekerja.":                                                                            "Program started.",
+	"Mulai memproses file-file Excel.":                                                                  "Started processing Excel files.",
+	"Selesai memproses file-file Excel.":                                                                "Finished processing Excel files.",
+	"Mulai membuat koneksi ke database":                                                                 "Started connecting to the database",
+	"Sukses membuat koneksi ke database.":                                                               "Successfully connected to the database.",
+	"Selesai membuat koneksi ke database":                                                               "Finished connecting to the database",
+	"Program selesai bekerja.":                                                                          "Program finished.",
+	"Error membaca direktori xlsx":                                                                      "Error reading the xlsx directory",
+	"Gagal membuat file template konfigurasi database.":                                                 "Failed to create the database configuration template file.",
+	"File konfigurasi database tidak ditemukan dan telah dibuat.":                                       "Database configuration file not found and has been created.",
+	"Gagal membaca file konfigurasi database.":                                                          "Failed to read the database configuration file.",
+	"Gagal membuat koneksi ke database.":                                                                "Failed to connect to the database.",
+	"Gagal menutup koneksi ke database.":                                                                "Failed to close the database connection.",
+	"Proses selesai.":                                                                                   "Process finished.",
+	"Program dihentikan.":                                                                               "Program stopped.",
+	"File konfigurasi database tidak ditemukan. Membuat file db.cfg...":                                 "Database configuration file not found. Creating db.cfg...",
+	"File db.cfg telah dibuat. Silakan ubah file db.cfg yang telah dibuat.":                             "db.cfg has been created. Please edit the created db.cfg file.",
+	"Proses pembuatan tabel database telah selesai.":                                                    "Database table creation finished.",
+	"Gagal mengeksekusi %s: %v":                                                                         "Error executing %s: %v",
+	"Selesai mengeksekusi %s dalam %s":                                                                  "Executed %s in %s",
+	"Manifest %s tidak berisi file yang diproses.":                                                      "Manifest %s lists no files to process.",
+	"Proses pengisian data dari file-file Excel ke database telah selesai.":                             "Loading data from the Excel files into the database finished.",
+	"Apakah akan melanjutkan membuat tabel atau tabel-tabel di database? (Ya/Tidak, default Ya): ":      "Continue creating the table(s) in the database? (Yes/No, default Yes): ",
+	"Apakah akan melanjutkan pengisian data dari file-file Excel ke database? (Ya/Tidak, default Ya): ": "Continue loading data from the Excel files into the database? (Yes/No, default Yes): ",
+}
+
+var language = "id"
+
+// detectLanguage memilih bahasa dari opsi -lang, atau dari LC_ALL, LC_MESSAGES
+// dan LANG bila opsi tidak diisi. Bahasa Indonesia dipakai bila tidak dikenali.
+func detectLanguage(lang string) string {
+	if lang == "" {
+		for _, env := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+			if lang = os.Getenv(env); lang != "" {
+				break
+			}
+		}
+	}
+	lang = strings.ToLower(lang)
+	for code := range messageCatalogs {
+		if strings.HasPrefix(lang, code) {
+			return code
+		}
+	}
+	return "id"
+}
+
+// tr menerjemahkan format pesan ke bahasa aktif lalu memformatnya dengan args.
+func tr(format string, args ...interface{}) string {
+	if translated, ok := messageCatalogs[language][format]; ok {
+		format = translated
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+func isNoAnswer(answer string) bool {
+	switch strings.TrimSpace(strings.ToLower(answer)) {
+	case "tidak", "t", "no", "n":
+		return true
+	}
+	return false
+}
+
+func logError(err error, message string) {
+	runMetrics.addError()
+	recordRunError(fmt.Sprintf("%s: %s: %v", time.Now().Format(time.RFC3339), message, err))
+	emitProgress("error", map[string]interface{}{"message": message, "error": fmt.Sprint(err)})
+	fmt.Printf("%s: %v\n", message, err)
+
+	logEntry := fmt.Sprintf("%s: %s: %v\n", time.Now().Format(time.RFC3339), message, err)
+	if err := writeLog("error.log", logEntry); err != nil {
+		fmt.Println(tr("Error menulis ke file error log: %v", err))
+	}
+}
+
+// writeLog menambahkan entry ke file log pada direktori log, setelah merotasi
+// file tersebut bila sudah melewati batas ukuran atau berasal dari hari sebelumnya.
+func writeLog(name, entry string) error {
+	logMu.Lock()
+	defer logMu.Unlock()
+
+	logDir := logDirPath()
+	if opts.logName != "" {
+		name = opts.logName + "-" + name
+	}
+	logFile := filepath.Join(logDir, name)
+
+	if err := os.MkdirAll(logDir, 0755); err != nil {
+		return err
+	}
+
+	if err := rotateLog(logFile); err != nil {
+		fmt.Println(tr("Error merotasi file log %s: %v", name, err))
+	}
+
+	file, err := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(entry)
+	return err
+}
+
+// logDirPath mengembalikan path absolut direktori -log-dir.
+func logDirPath() string {
+	return workPath(opts.logDir)
+}
+
+// workPath mengembalikan dir sebagai path absolut: path absolut apa adanya,
+// path relatif terhadap direktori kerja.
+func workPath(dir string) string {
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	currentDir, _ := os.Getwd()
+	return filepath.Join(currentDir, dir)
+}
+
+// mirroredDir mengembalikan subdirektori outDir untuk output file input path
+// pada -mirror-dirs, mengikuti letak path di bawah -xlsx-dir. File di luar
+// -xlsx-dir, misalnya dari URL atau arsip ZIP, ditulis langsung ke outDir.
+func mirroredDir(outDir, path string) string {
+	rel, err := filepath.Rel(workPath(opts.xlsxDir), filepath.Dir(path))
+	if err != nil || rel == "." || !filepath.IsLocal(rel) {
+		return outDir
+	}
+	return filepath.Join(outDir, rel)
+}
+
+// outputEntries membaca isi direktori output dir. Dengan -mirror-dirs
+// subdirektorinya ikut dibaca dan Name() setiap file berupa path relatif
+// terhadap dir, misalnya 2024/data_penjualan.sql.
+func outputEntries(dir string) ([]os.DirEntry, error) {
+	if !opts.mirrorDirs {
+		return os.ReadDir(dir)
+	}
+	var entries []os.DirEntry
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, nestedEntry{entry, rel})
+		return nil
+	})
+	return entries, err
+}
+
+// nestedEntry adalah file di subdirektori output dengan nama relatifnya.
+type nestedEntry struct {
+	os.DirEntry
+	name string
+}
+
+func (e nestedEntry) Name() string { return e.name }
+
+// outputFile mengembalikan path file name pada direktori output dir. Dengan
+// -mirror-dirs file dicari juga di subdirektorinya.
+func outputFile(dir, name string) (string, error) {
+	path := filepath.Join(dir, name)
+	_, err := os.Stat(path)
+	if err == nil || !opts.mirrorDirs {
+		return path, err
+	}
+	entries, walkErr := outputEntries(dir)
+	if walkErr != nil {
+		return path, err
+	}
+	for _, entry := range entries {
+		if filepath.Base(entry.Name()) == name {
+			return filepath.Join(dir, entry.Name()), nil
+		}
+	}
+	return path, err
+}
+
+// sqlTableName mengembalikan nama tabel dari nama file SQLTable, misalnya
+// 2024/penjualan.sql menjadi penjualan.
+func sqlTableName(name string) string {
+	return strings.TrimSuffix(filepath.Base(name), ".sql")
+}
+
+// ensureDirs membuat setiap direktori beserta induknya bila belum ada, lalu
+// memastikan direktori tersebut dapat ditulisi dengan membuat file percobaan.
+// Error pertama dikembalikan beserta path direktorinya.
+func ensureDirs(dirs ...string) error {
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
+		probe, err := os.CreateTemp(dir, ".write-test-*")
+		if err != nil {
+			return fmt.Errorf("%s: %w", dir, err)
+		}
+		probe.Close()
+		os.Remove(probe.Name())
+	}
+	return nil
+}
+
+func rotateLog(logFile string) error {
+	info, err := os.Stat(logFile)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	tooBig := opts.logMaxSize > 0 && info.Size() >= opts.logMaxSize*1024*1024
+	y1, m1, d1 := info.ModTime().Date()
+	y2, m2, d2 := now.Date()
+	stale := opts.logDaily && (y1 != y2 || m1 != m2 || d1 != d2)
+	if !tooBig && !stale {
+		return nil
+	}
+
+	// Rotasi kedua dalam detik yang sama (log cepat penuh atau beberapa
+	// proses) mendapat akhiran _01, _02 dan seterusnya agar arsip lama tidak
+	// tertimpa.
+	base := strings.TrimSuffix(logFile, filepath.Ext(logFile))
+	stamp := info.ModTime().Format("20060102-150405")
+	for n := 0; ; n++ {
+		suffix := ""
+		if n > 0 {
+			suffix = fmt.Sprintf("_%02d", n)
+		}
+		err = compressFile(logFile, fmt.Sprintf("%s-%s%s%s.gz", base, stamp, suffix, filepath.Ext(logFile)))
+		if !os.IsExist(err) || n == maxRotatedPerSecond {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(logFile); err != nil {
+		return err
+	}
+
+	return pruneLogs(base+"-*"+filepath.Ext(logFile)+".gz", opts.logKeep)
+}
+
+// maxRotatedPerSecond adalah jumlah akhiran terbanyak untuk arsip log yang
+// dirotasi dalam detik yang sama.
+const maxRotatedPerSecond = 99
+
+// compressFile menulis src terkompresi gzip ke dst. dst tidak boleh sudah
+// ada sehingga arsip log sebelumnya tidak tertimpa.
+func compressFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	zw := gzip.NewWriter(out)
+	if _, err := io.Copy(zw, in); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// pruneLogs menghapus file log hasil rotasi yang paling lama sehingga tersisa keep file.
+func pruneLogs(pattern string, keep int) error {
+	if keep <= 0 {
+		return nil
+	}
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return err
+	}
+	// Nama file memuat waktu rotasi, sehingga urutan leksikal sama dengan urutan waktu;
+	// akhiran _01 sesudah waktu terurut setelah arsip tanpa akhiran karena '_' > '.'.
+	sort.Strings(matches)
+	for len(matches) > keep {
+		if err := os.Remove(matches[0]); err != nil {
+			return err
+		}
+		matches = matches[1:]
+	}
+	return nil
+}
+
+func processFile(ctx context.Context, path string, sem chan struct{}, sqlDir, sqlDataDir string) {
+	defer wg.Done()
+	defer func() { <-sem }()
+
+	startTime := time.Now()
+	emitProgress("file_started", map[string]interface{}{"file": path})
+	if opts.mirrorDirs && !opts.stdout {
+		sqlDir, sqlDataDir = mirroredDir(sqlDir, path), mirroredDir(sqlDataDir, path)
+		if err := ensureDirs(sqlDir, sqlDataDir); err != nil {
+			logError(err, tr("Gagal membuat direktori output untuk %s", path))
+			logProcessing(path, "error", time.Since(startTime))
+			return
+		}
+	}
+	if opts.fileTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.fileTimeout)
+		defer cancel()
+	}
+
+	if err := waitStable(ctx, path); err != nil {
+		if ctx.Err() != nil {
+			logProcessing(path, interruptedStatus(ctx.Err()), time.Since(startTime))
+			return
+		}
+		logError(err, tr("File %s dilewati karena masih berubah", path))
+		logProcessing(path, "unstable", time.Since(startTime))
+		return
+	}
+
+	readPath, cleanup, err := localCopy(ctx, path)
+	if err != nil {
+		logError(err, tr("Gagal menyalin %s ke direktori sementara", path))
+		logProcessing(path, "error", time.Since(startTime))
+		return
+	}
+	defer cleanup()
+
+	var hash string
+	if fileStates != nil {
+		err := retryRead(ctx, path, func() (err error) {
+			hash, err = hashFile(readPath)
+			return err
+		})
+		if err != nil {
+			logError(err, tr("Error membaca file %s", path))
+			logProcessing(path, "error", time.Since(startTime))
+			return
+		}
+		if !opts.force && fileStates.unchanged(path, hash) {
+			logProcessing(path, "unchanged", time.Since(startTime))
+			return
+		}
+	}
+
+	jobs, err := sheetJobs(path, readPath)
+	if err != nil {
+		logError(err, readErrorMessage(path, err))
+		logProcessing(path, "error", time.Since(startTime))
+		return
+	}
+
+	// Sheet-sheet satu workbook dikonversi bersamaan dengan buffer masing-masing.
+	// Sheet pertama memakai slot file ini; sheet lain meminjam slot kosong dari
+	// sem, sehingga jumlah konversi tetap dibatasi worker pool. Bila sem penuh,
+	// sheet menunggu slot file ini sehingga tidak terjadi deadlock.
+	results := make([]sheetOutcome, len(jobs))
+	workers := make(chan struct{}, max(opts.sheetWorkers, 1))
+	own := make(chan struct{}, 1)
+	var sheetWG sync.WaitGroup
+	for i, job := range jobs {
+		sheetWG.Add(1)
+		workers <- struct{}{}
+		slot := own
+		select {
+		case own <- struct{}{}:
+		default:
+			select {
+			case own <- struct{}{}:
+			case sem <- struct{}{}:
+				slot = sem
+			}
+		}
+		go func() {
+			defer sheetWG.Done()
+			defer func() { <-workers }()
+			defer func() { <-slot }()
+			results[i] = convertSheet(ctx, path, readPath, job, sqlDir, sqlDataDir)
+		}()
+	}
+	sheetWG.Wait()
+
+	status := "empty"
+	var outputs []string
+	for _, result := range results {
+		outputs = append(outputs, result.outputs...)
+		switch {
+		case result.status == "success" && status == "empty":
+			status = "success"
+		case result.status != "success" && result.status != "empty" && !isFailedStatus(status):
+			status = result.status
+		}
+	}
+	if !isFailedStatus(status) {
+		runCheckpoint.markConverted(path, outputs...)
+		fileStates.record(path, hash)
+	}
+	logProcessing(path, status, time.Since(startTime))
+}
+
+// validateIDColumn memeriksa -id-column dan -id-type sebelum file diproses,
+// karena nilainya disisipkan langsung ke CREATE TABLE.
+func validateIDColumn() error {
+	if opts.idColumn != "" && !validIdentifier.MatchString(opts.idColumn) {
+		return errors.New(tr("nama kolom %q tidak valid", opts.idColumn))
+	}
+	for _, t := range ddl.IDTypes {
+		if strings.EqualFold(opts.idType, t) {
+			return nil
+		}
+	}
+	return errors.New(tr("tipe %q tidak didukung, gunakan salah satu dari %s", opts.idType, strings.Join(ddl.IDTypes, ", ")))
+}
+
+// tableOptionsFor mengembalikan opsi CREATE TABLE untuk file path: opsi
+// baris perintah, ditimpa kolom manifest yang diisi.
+func tableOptionsFor(path string) ddl.TableOptions {
+	options := ddl.TableOptions{
+		ID:           ddl.IDColumn{Name: opts.idColumn, Type: opts.idType, Unsigned: opts.idUnsigned},
+		Mode:         ddl.CreateMode(opts.createMode),
+		Naming:       tableNaming(),
+		Engine:       opts.engine,
+		RowFormat:    opts.rowFormat,
+		KeyBlockSize: opts.keyBlockSize,
+		Extra:        opts.tableOptions,
+		Timestamps:   opts.timestamps,
+	}
+	entry, _ := manifestEntryFor(path)
+	if entry.Engine != "" {
+		options.Engine = entry.Engine
+	}
+	if entry.RowFormat != "" {
+		options.RowFormat = entry.RowFormat
+	}
+	if entry.KeyBlockSize > 0 {
+		options.KeyBlockSize = entry.KeyBlockSize
+	}
+	if entry.TableOptions != "" {
+		options.Extra = entry.TableOptions
+	}
+	options.NoDataIndex = opts.indexAdvisor != ""
+	for _, definition := range entry.Generated {
+		// Definisi sudah diperiksa readManifest
+		if column, err := ddl.ParseGenerated(definition); err == nil {
+			options.Generated = append(options.Generated, column)
+		}
+	}
+	if opts.columnstore {
+		// File .tbl dimuat per posisi kolom tanpa kolom id
+		options.Engine = "ColumnStore"
+	}
+	options.Dialect = warehouseDialect()
+	return options
+}
+
+// warehouseDialect mengembalikan dialect -dialect, atau dialect kosong untuk
+// MariaDB.
+func warehouseDialect() ddl.Dialect {
+	if opts.dialect == "mariadb" {
+		return ""
+	}
+	return ddl.Dialect(opts.dialect)
+}
+
+// delimitedFormat mengembalikan format file data teks berbatas, atau nil bila
+// data ditulis sebagai pernyataan INSERT.
+func delimitedFormat() *writer.DelimitedFormat {
+	switch {
+	case opts.columnstore:
+		return &writer.CPImportFormat
+	case warehouseDialect() != "":
+		return &writer.CSVFormat
+	}
+	return nil
+}
+
+// validateTableOptions memeriksa engine, row format dan opsi tabel tambahan,
+// yang disisipkan langsung ke CREATE TABLE. Titik koma ditolak karena file
+// SQLTable dipecah per pernyataan pada titik koma.
+func validateTableOptions(engine, rowFormat, extra string) error {
+	if engine != "" && !validIdentifier.MatchString(engine) {
+		return errors.New(tr("engine %q tidak valid", engine))
+	}
+	if rowFormat != "" && !validIdentifier.MatchString(rowFormat) {
+		return errors.New(tr("row format %q tidak valid", rowFormat))
+	}
+	if strings.Contains(extra, ";") {
+		return errors.New(tr("opsi tabel tidak boleh berisi titik koma"))
+	}
+	return nil
+}
+
+// passwordEntry adalah satu baris file -passwords.
+type passwordEntry struct {
+	// pattern adalah pola nama file (huruf kecil), key path absolut file (winpath.Key)
+	pattern  string
+	key      string
+	password string
+}
+
+var workbookPasswords []passwordEntry
+
+// readPasswords membaca file CSV -passwords. Baris pertama berisi nama kolom
+// file dan password; path relatif dihitung dari direktori file tersebut.
+func readPasswords(path string) ([]passwordEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	fileCol, passwordCol := -1, -1
+	for i, name := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "file":
+			fileCol = i
+		case "password":
+			passwordCol = i
+		}
+	}
+	if fileCol < 0 || passwordCol < 0 {
+		return nil, errors.New(tr("file password harus memiliki kolom file dan password"))
+	}
+	var entries []passwordEntry
+	for _, record := range records[1:] {
+		if fileCol >= len(record) || passwordCol >= len(record) {
+			continue
+		}
+		file := strings.TrimSpace(record[fileCol])
+		entry := passwordEntry{password: record[passwordCol]}
+		if strings.ContainsAny(file, `/\`) {
+			abs, err := winpath.Resolve(filepath.Dir(path), file)
+			if err != nil {
+				return nil, err
+			}
+			entry.key = winpath.Key(abs)
+		} else {
+			entry.pattern = strings.ToLower(file)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// passwordFor mengembalikan password workbook path: baris -passwords pertama
+// yang cocok, atau -xlsx-password.
+func passwordFor(path string) string {
+	name := strings.ToLower(filepath.Base(path))
+	for _, entry := range workbookPasswords {
+		if entry.key != "" && entry.key == winpath.Key(path) {
+			return entry.password
+		}
+		if matched, _ := filepath.Match(entry.pattern, name); entry.pattern != "" && matched {
+			return entry.password
+		}
+	}
+	return opts.xlsxPassword
+}
+
+// tenantEntry adalah satu baris file -tenants.
+type tenantEntry struct {
+	// pattern adalah pola nama file (huruf kecil), key path absolut file dan
+	// dir path absolut direktori (winpath.Key)
+	pattern string
+	key     string
+	dir     string
+	tenant  string
+}
+
+var tenantEntries []tenantEntry
+
+// readTenants membaca file CSV -tenants. Baris pertama berisi nama kolom file
+// dan tenant; path relatif dihitung dari direktori file tersebut.
+func readTenants(path string) ([]tenantEntry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	fileCol, tenantCol := -1, -1
+	for i, name := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "file":
+			fileCol = i
+		case "tenant":
+			tenantCol = i
+		}
+	}
+	if fileCol < 0 || tenantCol < 0 {
+		return nil, errors.New(tr("file tenant harus memiliki kolom file dan tenant"))
+	}
+	var entries []tenantEntry
+	for _, record := range records[1:] {
+		if fileCol >= len(record) || tenantCol >= len(record) {
+			continue
+		}
+		file := strings.TrimSpace(record[fileCol])
+		entry := tenantEntry{tenant: strings.TrimSpace(record[tenantCol])}
+		if err := validateTenant(entry.tenant, file); err != nil {
+			return nil, err
+		}
+		abs, err := winpath.Resolve(filepath.Dir(path), file)
+		if err != nil {
+			return nil, err
+		}
+		switch info, err := os.Stat(abs); {
+		case err == nil && info.IsDir():
+			entry.dir = winpath.Key(abs)
+		case strings.ContainsAny(file, `/\`):
+			entry.key = winpath.Key(abs)
+		default:
+			entry.pattern = strings.ToLower(file)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// validateTenant memeriksa nilai tenant untuk file sesuai ddl.TenantType.
+func validateTenant(tenant, file string) error {
+	if tenant == "" || utf8.RuneCountInString(tenant) > 64 {
+		return errors.New(tr("tenant %q untuk %s tidak valid: harus diisi dan paling banyak 64 karakter", tenant, file))
+	}
+	return nil
+}
+
+// tenantFor mengembalikan tenant file path: kolom tenant pada manifest, atau
+// baris -tenants pertama yang cocok dengan direktori, path atau nama file.
+// Kosong berarti tabel tanpa kolom tenant.
+func tenantFor(path string) string {
+	if entry, ok := manifestEntryFor(path); ok && entry.Tenant != "" {
+		return entry.Tenant
+	}
+	key := winpath.Key(path)
+	name := strings.ToLower(filepath.Base(path))
+	for _, entry := range tenantEntries {
+		if entry.key != "" && entry.key == key {
+			return entry.tenant
+		}
+		if entry.dir != "" && strings.HasPrefix(key, entry.dir+string(filepath.Separator)) {
+			return entry.tenant
+		}
+		if matched, _ := filepath.Match(entry.pattern, name); entry.pattern != "" && matched {
+			return entry.tenant
+		}
+	}
+	return ""
+}
+
+// readErrorMessage membentuk pesan kesalahan membaca file path, dengan
+// petunjuk bila workbook terenkripsi dan password salah atau tidak diisi.
+func readErrorMessage(path string, err error) string {
+	var headerErr *xlsx2sql.HeaderMismatchError
+	if errors.As(err, &headerErr) {
+		return tr("Header %s tidak sesuai kontrak headers pada manifest", path)
+	}
+	if errors.Is(err, xlsx2sql.ErrXLSEncrypted) {
+		return tr("Workbook xls %s dilindungi password; simpan ulang tanpa password atau sebagai xlsx", path)
+	}
+	if errors.Is(err, excelize.ErrWorkbookPassword) || passwordFor(path) == "" && !isXLSFile(path) && isEncryptedWorkbook(path) {
+		return tr("Password workbook %s salah atau tidak diisi (lihat -xlsx-password dan -passwords)", path)
+	}
+	return tr("Error membaca file %s", path)
+}
+
+// isEncryptedWorkbook mengembalikan true bila path berformat OLE compound
+// file, yaitu format xlsx terenkripsi, bukan arsip zip.
+func isEncryptedWorkbook(path string) bool {
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer file.Close()
+	signature := make([]byte, 8)
+	if _, err := io.ReadFull(file, signature); err != nil {
+		return false
+	}
+	return bytes.Equal(signature, []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1})
+}
+
+// sheetJob adalah satu sheet workbook beserta nama tabel tujuannya.
+type sheetJob struct {
+	sheet string
+	table string
+
+	// excelTable adalah Excel Table yang dibaca, nil berarti seluruh sheet
+	excelTable *xlsx2sql.ExcelTable
+}
+
+// sheetSelector adalah daftar pola -sheets selain active dan all: nama sheet
+// atau pola glob seperti Data*, dan regex di antara garis miring seperti
+// /^Q[1-4]$/. Seperti nama sheet Excel, huruf besar dan kecil tidak dibedakan.
+type sheetSelector struct {
+	globs   []string
+	regexps []*regexp.Regexp
+}
+
+// sheetSelection adalah pola -sheets, nil pada -sheets active dan all.
+var sheetSelection *sheetSelector
+
+func parseSheetSelector(list string) (*sheetSelector, error) {
+	s := &sheetSelector{}
+	for _, pattern := range strings.Split(list, ",") {
+		pattern = strings.TrimSpace(pattern)
+		switch {
+		case pattern == "":
+		case len(pattern) > 2 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/"):
+			re, err := regexp.Compile("(?i)" + pattern[1:len(pattern)-1])
+			if err != nil {
+				return nil, err
+			}
+			s.regexps = append(s.regexps, re)
+		default:
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("%q: %w", pattern, err)
+			}
+			s.globs = append(s.globs, strings.ToLower(pattern))
+		}
+	}
+	if len(s.globs) == 0 && len(s.regexps) == 0 {
+		return nil, errors.New(tr("tidak ada pola sheet"))
+	}
+	return s, nil
+}
+
+func (s *sheetSelector) match(sheet string) bool {
+	for _, glob := range s.globs {
+		if ok, _ := path.Match(glob, strings.ToLower(sheet)); ok {
+			return true
+		}
+	}
+	for _, re := range s.regexps {
+		if re.MatchString(sheet) {
+			return true
+		}
+	}
+	return false
+}
+
+// sheetJobs menentukan sheet yang dikonversi dari path. Secara default hanya
+// sheet aktif (atau sheet dari manifest); dengan -sheets all setiap sheet yang
+// tidak disembunyikan menjadi tabel <tabel file><nama sheet>, misalnya
+// penjualanJanuari, dan dengan pola -sheets hanya sheet yang cocok. Workbook
+// tanpa sheet yang cocok tidak menghasilkan tabel. Dengan -excel-tables,
+// setiap Excel Table pada sheet xlsx menjadi tabel bernama sesuai Table.
+func sheetJobs(path, readPath string) ([]sheetJob, error) {
+	jobs, err := worksheetJobs(path, readPath)
+	entry, _ := manifestEntryFor(path)
+	if err != nil || !opts.excelTables || opts.cellRange != "" || entry.Range != "" || !strings.EqualFold(filepath.Ext(path), ".xlsx") {
+		return jobs, err
+	}
+	return excelTableJobs(path, readPath, jobs)
+}
+
+// excelTableJobs mengganti setiap sheet pada jobs yang memiliki Excel Table
+// dengan satu job per Table, bernama sesuai nama Table. Sheet tanpa Table
+// tetap dibaca utuh.
+func excelTableJobs(path, readPath string, jobs []sheetJob) ([]sheetJob, error) {
+	xlsx, err := excelize.OpenFile(readPath, excelizeOptions(path))
+	if err != nil {
+		return nil, err
+	}
+	defer xlsx.Close()
+	var result []sheetJob
+	for _, job := range jobs {
+		sheet := job.sheet
+		if sheet == "" {
+			sheet = xlsx.GetSheetName(xlsx.GetActiveSheetIndex())
+		}
+		tables, err := xlsx2sql.ExcelTables(xlsx, sheet)
+		if err != nil {
+			return nil, err
+		}
+		if len(tables) == 0 {
+			result = append(result, job)
+			continue
+		}
+		for i := range tables {
+			result = append(result, sheetJob{sheet: sheet, table: tableNaming().Table(tables[i].Name), excelTable: &tables[i]})
+		}
+	}
+	if selectedTables != nil {
+		mu.Lock()
+		for _, job := range result {
+			if job.excelTable != nil {
+				selectedTables[job.table] = true
+			}
+		}
+		mu.Unlock()
+	}
+	return result, nil
+}
+
+// worksheetJobs menentukan sheet yang dikonversi seperti sheetJobs, tanpa
+// Excel Table.
+func worksheetJobs(path, readPath string) ([]sheetJob, error) {
+	job := sheetJob{table: tableNameFor(path)}
+	entry, _ := manifestEntryFor(path)
+	job.sheet = entry.Sheet
+	if opts.sheets == "active" || entry.Sheet != "" || strings.EqualFold(filepath.Ext(path), ".csv") || isJSONFile(path) || isParquetFile(path) || isXMLFile(path) {
+		return []sheetJob{job}, nil
+	}
+
+	sheets, err := visibleSheets(path, readPath)
+	if err != nil {
+		return nil, err
+	}
+	var jobs []sheetJob
+	for _, sheet := range sheets {
+		if sheetSelection != nil && !sheetSelection.match(sheet) {
+			continue
+		}
+		jobs = append(jobs, sheetJob{sheet: sheet, table: tableNaming().Table(ddl.Unquote(job.table) + sheet)})
+	}
+	if sheetSelection != nil {
+		switch len(jobs) {
+		case 0:
+			logRun(tr("Tidak ada sheet %s yang cocok dengan -sheets %q", path, opts.sheets))
+			return nil, nil
+		case 1:
+			// Satu sheet yang cocok memakai nama tabel dari nama file
+			job.sheet = jobs[0].sheet
+			return []sheetJob{job}, nil
+		}
+	}
+	// Workbook dengan satu sheet tetap memakai nama tabel dari nama file
+	if len(jobs) <= 1 {
+		return []sheetJob{job}, nil
+	}
+	if selectedTables != nil {
+		mu.Lock()
+		for _, job := range jobs {
+			selectedTables[job.table] = true
+		}
+		mu.Unlock()
+	}
+	return jobs, nil
+}
+
+// encryptedColumns mengembalikan kolom terenkripsi file path: kolom manifest
+// encrypt bila diisi, atau -encrypt-columns.
+func encryptedColumns(path string) map[string]bool {
+	names := strings.Split(opts.encryptColumns, ",")
+	if entry, _ := manifestEntryFor(path); entry.Encrypt != nil {
+		names = entry.Encrypt
+	}
+	var columns map[string]bool
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			if columns == nil {
+				columns = make(map[string]bool)
+			}
+			columns[name] = true
+		}
+	}
+	return columns
+}
+
+// encryptionConfigured mengembalikan true bila -encrypt-columns atau kolom
+// manifest encrypt diisi.
+func encryptionConfigured() bool {
+	if strings.TrimSpace(opts.encryptColumns) != "" {
+		return true
+	}
+	for _, entry := range manifestEntries {
+		if len(entry.Encrypt) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// headerNames mengembalikan nama kolom -column-names, nil bila tidak diisi.
+func headerNames() []string {
+	if opts.columnNames == "" {
+		return nil
+	}
+	var names []string
+	for _, name := range strings.Split(opts.columnNames, ",") {
+		names = append(names, strings.TrimSpace(name))
+	}
+	return names
+}
+
+// visibleSheets mengembalikan sheet workbook readPath yang tidak
+// disembunyikan.
+func visibleSheets(path, readPath string) ([]string, error) {
+	if isODSFile(path) {
+		return xlsx2sql.ODSSheets(readPath)
+	}
+	if isXLSFile(path) {
+		return xlsx2sql.XLSSheets(readPath)
+	}
+	xlsx, err := excelize.OpenFile(readPath, excelizeOptions(path))
+	if err != nil {
+		return nil, err
+	}
+	defer xlsx.Close()
+	var sheets []string
+	for _, sheet := range xlsx.GetSheetList() {
+		if visible, err := xlsx.GetSheetVisible(sheet); err == nil && !visible {
+			continue
+		}
+		sheets = append(sheets, sheet)
+	}
+	return sheets, nil
+}
+
+// sheetOutcome adalah hasil konversi satu sheet: status seperti pada
+// logProcessing dan file SQL yang ditulis.
+type sheetOutcome struct {
+	status  string
+	outputs []string
+}
+
+// convertSheet mengonversi satu sheet ke SQLTable dan SQLData (atau standard
+// output pada -stdout). Kegagalan dicatat di sini; status file ditentukan oleh
+// processFile dari hasil semua sheet.
+func convertSheet(ctx context.Context, path, readPath string, job sheetJob, sqlDir, sqlDataDir string) sheetOutcome {
+	convertOptions := xlsx2sql.Options{
+		Table:         job.table,
+		Sheet:         job.sheet,
+		Excelize:      excelizeOptions(path),
+		InferRows:     opts.inferRows,
+		InferRandom:   opts.inferSample == "random",
+		NumberFormats: opts.numberFormats,
+		FillMerged:    opts.fillMerged,
+		Inference:     inferenceEngine(),
+		DDL:           tableOptionsFor(path),
+		Delimited:     delimitedFormat(),
+		Tenant:        tenantFor(path),
+		HeaderRow:     opts.headerRow,
+		HeaderRows:    opts.headerRows,
+		SkipRows:      opts.skipRows,
+		NoHeader:      opts.noHeader,
+		Range:         opts.cellRange,
+		HeaderNames:   headerNames(),
+		Encrypt:       encryptedColumns(path),
+		OnEvent: func(event xlsx2sql.Event) {
+			if event.Kind == xlsx2sql.RowBatchWritten {
+				emitProgress("rows_converted", map[string]interface{}{"file": path, "table": event.Table, "rows": event.Rows})
+			}
+		},
+	}
+	if entry, ok := manifestEntryFor(path); ok {
+		if entry.HeaderRow != 0 {
+			convertOptions.HeaderRow = entry.HeaderRow
+		}
+		if entry.HeaderRows != 0 {
+			convertOptions.HeaderRows = entry.HeaderRows
+		}
+		if entry.SkipRows != nil {
+			convertOptions.SkipRows = *entry.SkipRows
+		}
+		if entry.Range != "" {
+			convertOptions.Range = entry.Range
+		}
+		convertOptions.ExpectHeader = entry.Headers
+		convertOptions.Collations = entry.Collations
+	}
+	if table := job.excelTable; table != nil {
+		// Header Excel Table selalu baris pertama rentangnya
+		convertOptions.Range = table.Range
+		convertOptions.HeaderRow = 1
+		convertOptions.HeaderRows = 1
+		convertOptions.SkipRows = 0
+		convertOptions.NoHeader = !table.Header
+		convertOptions.HeaderNames = nil
+		if !table.Header {
+			convertOptions.HeaderNames = table.Columns
+		}
+	}
+	if isXMLFile(path) {
+		convertOptions.XMLRows = xmlRowsFor(path)
+	}
+	if opts.approval && opts.reviewRows > convertOptions.SampleRows {
+		convertOptions.SampleRows = opts.reviewRows
+	}
+	if opts.preview != "" && opts.previewRows > convertOptions.SampleRows {
+		convertOptions.SampleRows = opts.previewRows
+	}
+
+	var result *xlsx2sql.Result
+	var dataBuffer, jsonBuffer *spillBuffer
+	var stats *tableStats
+	var rejects []xlsx2sql.Reject
+	defer func() {
+		if dataBuffer != nil {
+			dataBuffer.discard()
+		}
+		if jsonBuffer != nil {
+			jsonBuffer.discard()
+		}
+	}()
+	// convert dimulai dengan buffer dan statistik yang kosong, juga pada
+	// percobaan ulang
+	convert := func() (err error) {
+		if dataBuffer != nil {
+			dataBuffer.discard()
+		}
+		dataBuffer = &spillBuffer{threshold: opts.spillThreshold << 20}
+		convertOptions.Data = dataBuffer
+		var addStats func(*xlsx2sql.Result, []string)
+		if statsEnabled() {
+			stats = &tableStats{Source: filepath.Base(path), HeaderRow: convertOptions.HeaderRow, HeaderRows: convertOptions.HeaderRows, SkipRows: convertOptions.SkipRows, NoHeader: convertOptions.NoHeader}
+			addStats = stats.addRow
+		}
+		convertOptions.OnRow = addStats
+		rejects = nil
+		convertOptions.OnReject = func(reject xlsx2sql.Reject) {
+			rejects = append(rejects, reject)
+		}
+		if ndjsonOut != nil {
+			if jsonBuffer != nil {
+				jsonBuffer.discard()
+			}
+			jsonBuffer = &spillBuffer{threshold: opts.spillThreshold << 20}
+			var jw *writer.JSONWriter
+			convertOptions.OnRow = func(result *xlsx2sql.Result, row []string) {
+				if addStats != nil {
+					addStats(result, row)
+				}
+				if jw == nil {
+					jw = writer.NewJSON(jsonBuffer, columnNames(result), result.Types)
+				}
+				// Kesalahan tulis disimpan spillBuffer dan dilaporkan saat dikirim
+				jw.WriteRow(row)
+			}
+		}
+		result, err = xlsx2sql.ConvertFile(ctx, readPath, convertOptions)
+		return err
+	}
+	err := retryRead(ctx, path, func() error {
+		err := convert()
+		var mismatch *xlsx2sql.SampleMismatchError
+		if errors.As(err, &mismatch) {
+			// Tipe dari sampel terlalu sempit, tentukan ulang dari seluruh baris
+			logRun(tr("%s: %v, tipe kolom ditentukan ulang dari seluruh baris", path, err))
+			convertOptions.InferRows = 0
+			err = convert()
+		}
+		return err
+	})
+	if errors.Is(err, xlsx2sql.ErrNoData) {
+		return sheetOutcome{status: "empty"}
+	}
+	if err != nil {
+		// File yang terhenti di tengah jalan tidak ditulis sama sekali
+		if ctx.Err() != nil {
+			return sheetOutcome{status: interruptedStatus(ctx.Err())}
+		}
+		var sheetErr *xlsx2sql.SheetError
+		var dataErr *xlsx2sql.DataError
+		switch {
+		case errors.As(err, &sheetErr):
+			logError(sheetErr.Err, tr("Error mendapatkan baris pada sheet %s", sheetErr.Sheet))
+		case errors.As(err, &dataErr):
+			logError(dataErr.Err, tr("Error menulis data ke file SQL untuk %s", path))
+		default:
+			logError(err, readErrorMessage(path, err))
+		}
+		return sheetOutcome{status: "error"}
+	}
+
+	tableName := result.Table
+	firstRow := result.Header
+	columnTypes := result.Types
+
+	warnings := schemaWarnings(path, result, convertOptions.DDL)
+	if len(rejects) > 0 {
+		warnings = append(warnings, tr("%s: %d sel tidak sesuai tipe kolomnya (-min-confidence) dan ditulis NULL", tableName, len(rejects)))
+		if opts.quarantine != "" {
+			quarantineFile := filepath.Join(workPath(opts.quarantine), tableName+".csv")
+			if err := writeQuarantine(quarantineFile, rejects); err != nil {
+				logError(err, tr("Gagal menulis karantina sel untuk %s", path))
+			} else {
+				warnings = append(warnings, tr("Sel yang ditulis NULL pada %s dicatat di %s", tableName, quarantineFile))
+			}
+		}
+	}
+	for _, warning := range warnings {
+		logRun(warning)
+		fmt.Println(warning)
+	}
+
+	createTableStatement := result.CreateTable
+	if result.DecryptView != "" {
+		createTableStatement += "\n" + result.DecryptView
+	}
+	if entry, ok := manifestEntryFor(path); ok && entry.Mode == "replace" {
+		createTableStatement = fmt.Sprintf("DROP TABLE IF EXISTS %s;\n%s", tableName, createTableStatement)
+	}
+	if dialect := warehouseDialect(); dialect != "" {
+		location := copyLocation(dataFilePath(sqlDataDir, tableName))
+		createTableStatement += "\n" + dialect.Copy(tableName, result.Columns, location)
+	}
+
+	if opts.stdout {
+		if err := writeSQLToStdout(createTableStatement, dataBuffer); err != nil {
+			logError(err, tr("Error menulis SQL ke standard output untuk %s", path))
+			return sheetOutcome{status: "error"}
+		}
+		if err := ndjsonOut.emit(ctx, result, jsonBuffer); err != nil {
+			logError(err, tr("Gagal mengirim baris NDJSON untuk %s", path))
+			return sheetOutcome{status: "error"}
+		}
+		return sheetOutcome{status: "success"}
+	}
+
+	header := ""
+	if opts.provenance {
+		header = provenanceHeader(path, result)
+	}
+	sqlFile := filepath.Join(sqlDir, fmt.Sprintf("%s.sql", tableName))
+	if err := writeFileAtomic(sqlFile, header+createTableStatement); err != nil {
+		logError(err, tr("Error menulis ke file SQL untuk %s", path))
+		return sheetOutcome{status: "error"}
+	}
+
+	dataFile := dataFilePath(sqlDataDir, tableName)
+	dataHeader := header
+	if convertOptions.Delimited != nil {
+		// cpimport dan COPY tidak mengenal baris komentar
+		dataHeader = ""
+	}
+	if err := dataBuffer.commit(dataFile, dataHeader); err != nil {
+		logError(err, tr("Error menulis data ke file SQL untuk %s", path))
+		return sheetOutcome{status: "error"}
+	}
+	removeStaleDataFiles(dataFile)
+	if err := s3Upload.upload(ctx, sqlFile, dataFile); err != nil {
+		logError(err, tr("Gagal mengunggah file SQL untuk %s", path))
+		return sheetOutcome{status: "error"}
+	}
+
+	if err := ndjsonOut.emit(ctx, result, jsonBuffer); err != nil {
+		logError(err, tr("Gagal mengirim baris NDJSON untuk %s", path))
+		return sheetOutcome{status: "error"}
+	}
+
+	if opts.approval {
+		if err := writeReviewFile(tableName, path, createTableStatement, firstRow, columnTypes, result.Sample); err != nil {
+			logError(err, tr("Gagal menulis file review untuk %s", path))
+		}
+	}
+
+	if opts.preview != "" {
+		if err := writePreviewFile(tableName, firstRow, columnTypes, result.Sample); err != nil {
+			logError(err, tr("Gagal menulis file pratinjau untuk %s", path))
+		}
+	}
+
+	if stats != nil {
+		stats.Bytes = dataBuffer.Len()
+		if err := writeTableStats(statsFilePath(dataFile), *stats); err != nil {
+			logError(err, tr("Gagal menulis statistik tabel untuk %s", path))
+		}
+	}
+
+	runMetrics.addRowsConverted(result.Rows)
+	currentReport.addTable(tableName, path, result.Rows, firstRow, columnTypes)
+	currentReport.tableWarnings(tableName, warnings)
+	return sheetOutcome{status: "success", outputs: []string{sqlFile, dataFile}}
+}
+
+// inferenceEngine mengembalikan engine inferensi dengan -min-confidence, nil
+// (inference.DefaultEngine) bila semua sel harus cocok.
+func inferenceEngine() *inference.Engine {
+	if opts.minConfidence >= 1 {
+		return nil
+	}
+	return &inference.Engine{Detectors: inference.DefaultDetectors(), MinConfidence: opts.minConfidence}
+}
+
+// writeQuarantine menulis sel yang ditulis NULL ke file CSV path.
+func writeQuarantine(path string, rejects []xlsx2sql.Reject) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.Write([]string{"cell", "column", "type", "value", "suggestion"})
+	for _, reject := range rejects {
+		suggestion := ""
+		if reject.Suggestion != nil {
+			suggestion = reject.Suggestion.String()
+		}
+		w.Write([]string{reject.Cell, reject.Column, reject.Type, reject.Value, suggestion})
+	}
+	w.Flush()
+	return writeFileAtomic(path, b.String())
+}
+
+// schemaWarnings memeriksa skema hasil konversi path sebelum DDL dijalankan:
+// tabel yang terlalu lebar, baris yang melewati batas MariaDB, dan tabel
+// yang menurut ddl.EstimateRow jauh lebih besar dari file sumbernya karena
+// nilai TEXT dipindah ke halaman overflow. Setiap peringatan menyertakan
+// saran perbaikannya.
+func schemaWarnings(path string, result *xlsx2sql.Result, options ddl.TableOptions) []string {
+	var warnings []string
+	columns := len(result.Columns)
+	innodb := !opts.columnstore && warehouseDialect() == ""
+	switch {
+	case innodb && columns+1 > ddl.MaxInnoDBColumns:
+		warnings = append(warnings, tr("Peringatan: tabel %s memiliki %d kolom, melebihi batas InnoDB %d kolom sehingga CREATE TABLE akan gagal; pecah sheet menjadi beberapa tabel atau ubah kolom berulang (misalnya per bulan) menjadi baris", result.Table, columns, ddl.MaxInnoDBColumns))
+	case opts.warnColumns > 0 && columns > opts.warnColumns:
+		warnings = append(warnings, tr("Peringatan: tabel %s sangat lebar (%d kolom); pertimbangkan memecahnya menjadi beberapa tabel atau mengubah kolom berulang (misalnya per bulan) menjadi baris", result.Table, columns))
+	}
+	if !innodb {
+		return warnings
+	}
+
+	lengths := make([]int, len(result.Inferred))
+	for i, inferred := range result.Inferred {
+		lengths[i] = inferred.MaxLength
+	}
+	estimate := ddl.EstimateRow(result.Columns, lengths, options.ID.Definition())
+	if estimate.DeclaredBytes > ddl.MaxRowSize {
+		warnings = append(warnings, tr("Peringatan: ukuran baris tabel %s menurut definisi kolom %d byte melebihi batas MariaDB %d byte sehingga CREATE TABLE akan gagal (Row size too large); ubah kolom VARCHAR panjang menjadi TEXT atau kurangi jumlah kolom", result.Table, estimate.DeclaredBytes, ddl.MaxRowSize))
+	}
+	info, err := os.Stat(path)
+	if opts.warnGrowth <= 0 || err != nil || info.Size() == 0 || estimate.OffPage == 0 {
+		return warnings
+	}
+	tableBytes := float64(result.Rows) * float64(estimate.StoredBytes)
+	if growth := tableBytes / float64(info.Size()); growth > opts.warnGrowth {
+		warnings = append(warnings, tr("Peringatan: perkiraan ukuran tabel %s sampai %.1f MB, %.0f kali file sumber, karena nilai %d kolom teks panjang disimpan di halaman overflow %d KB masing-masing; pertimbangkan VARCHAR untuk kolom teks yang pendek, memindahkan kolom teks panjang ke tabel terpisah, atau -row-format COMPRESSED", result.Table, tableBytes/(1<<20), growth, estimate.OffPage, ddl.InnoDBPageSize/1024))
+	}
+	return warnings
+}
+
+// writeFileAtomic menulis content ke file sementara lalu mengganti namanya
+// menjadi path, sehingga path tidak pernah berisi SQL yang terpotong.
+func writeFileAtomic(path, content string) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	_, err = file.WriteString(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// progressOut menerima kejadian kemajuan dalam format JSON pada -progress json,
+// dan progressSubs menerima kejadian yang sama untuk StreamProgress gRPC.
+var (
+	progressOut  io.Writer
+	progressSubs = make(map[chan map[string]interface{}]bool)
+	progressMu   sync.Mutex
+)
+
+// emitProgress menulis satu baris JSON untuk satu kejadian, misalnya
+// {"event":"file_done","file":"...","status":"success",...}.
+func emitProgress(event string, fields map[string]interface{}) {
+	progressMu.Lock()
+	defer progressMu.Unlock()
+	if progressOut == nil && len(progressSubs) == 0 {
+		return
+	}
+	fields["event"] = event
+	fields["time"] = time.Now().Format(time.RFC3339Nano)
+	for ch := range progressSubs {
+		// Pelanggan yang lambat kehilangan kejadian, bukan menahan konversi
+		select {
+		case ch <- fields:
+		default:
+		}
+	}
+	if progressOut == nil {
+		return
+	}
+	line, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+	progressOut.Write(append(line, '\n'))
+}
+
+// subscribeProgress mendaftarkan penerima kejadian kemajuan. Fungsi yang
+// dikembalikan menghentikan langganan.
+func subscribeProgress() (<-chan map[string]interface{}, func()) {
+	ch := make(chan map[string]interface{}, 256)
+	progressMu.Lock()
+	progressSubs[ch] = true
+	progressMu.Unlock()
+	return ch, func() {
+		progressMu.Lock()
+		delete(progressSubs, ch)
+		progressMu.Unlock()
+	}
+}
+
+// sqlStdout adalah standard output asli pada mode -stdout. Selama mode tersebut
+// os.Stdout diarahkan ke stderr agar pesan program tidak tercampur dengan SQL.
+var (
+	sqlStdout   io.Writer
+	sqlStdoutMu sync.Mutex
+)
+
+// writeSQLToStdout menulis SQL satu tabel sekaligus agar keluaran dari file
+// yang diproses bersamaan tidak saling menyela.
+func writeSQLToStdout(createTableStatement string, data *spillBuffer) error {
+	sqlStdoutMu.Lock()
+	defer sqlStdoutMu.Unlock()
+
+	w := bufio.NewWriter(sqlStdout)
+	if opts.stdoutStage != "data" {
+		fmt.Fprintf(w, "%s\n", createTableStatement)
+	}
+	if opts.stdoutStage != "schema" {
+		if err := data.writeTo(w); err != nil {
+			return err
+		}
+		w.WriteString("\n")
+	}
+	return w.Flush()
+}
+
+// ndjsonTarget adalah tujuan -ndjson: direktori file <tabel>.ndjson, standard
+// output, atau topic Kafka.
+type ndjsonTarget struct {
+	dir    string
+	stdout bool
+	kafka  *kafka.Writer
+	topic  string
+}
+
+// ndjsonOut adalah tujuan -ndjson, nil bila baris tidak dikirim sebagai NDJSON.
+var ndjsonOut *ndjsonTarget
+
+// ndjsonBatchMessages adalah jumlah pesan per pengiriman ke Kafka.
+const ndjsonBatchMessages = 1000
+
+// openNDJSONTarget mengurai spec -ndjson. Pada kafka://, broker dipisahkan
+// koma dan bagian setelah garis miring adalah topic.
+func openNDJSONTarget(spec string) (*ndjsonTarget, error) {
+	switch {
+	case spec == "-":
+		return &ndjsonTarget{stdout: true}, nil
+	case strings.HasPrefix(spec, "kafka://"):
+		brokers, topic, _ := strings.Cut(strings.TrimPrefix(spec, "kafka://"), "/")
+		if brokers == "" {
+			return nil, errors.New(tr("broker Kafka tidak disebutkan"))
+		}
+		return &ndjsonTarget{
+			topic: topic,
+			kafka: &kafka.Writer{
+				Addr: kafka.TCP(strings.Split(brokers, ",")...),
+				// Pesan dengan key yang sama selalu masuk partisi yang sama
+				Balancer:     &kafka.Hash{},
+				RequiredAcks: kafka.RequireAll,
+				BatchSize:    ndjsonBatchMessages,
+			},
+		}, nil
+	}
+	if err := os.MkdirAll(spec, 0755); err != nil {
+		return nil, err
+	}
+	return &ndjsonTarget{dir: spec}, nil
+}
+
+// close menunggu pesan Kafka yang masih tertunda terkirim.
+func (t *ndjsonTarget) close() error {
+	if t.kafka != nil {
+		return t.kafka.Close()
+	}
+	return nil
+}
+
+// emit mengirim baris NDJSON satu tabel dari data. Tidak ada yang dikirim bila
+// t nil (tanpa -ndjson).
+func (t *ndjsonTarget) emit(ctx context.Context, result *xlsx2sql.Result, data *spillBuffer) error {
+	switch {
+	case t == nil:
+		return nil
+	case t.stdout:
+		sqlStdoutMu.Lock()
+		defer sqlStdoutMu.Unlock()
+		return data.writeTo(sqlStdout)
+	case t.kafka != nil:
+		return t.produce(ctx, result, data)
+	}
+	return data.commit(filepath.Join(t.dir, result.Table+".ndjson"), "")
+}
+
+// produce mengirim setiap baris NDJSON sebagai satu pesan Kafka dengan nilai
+// kolom -kafka-key sebagai key dan nama tabel pada header table.
+func (t *ndjsonTarget) produce(ctx context.Context, result *xlsx2sql.Result, data *spillBuffer) error {
+	keyColumn := ""
+	if opts.kafkaKey != "" {
+		for _, column := range result.Columns {
+			if strings.EqualFold(column.Name, opts.kafkaKey) || strings.EqualFold(column.Comment, opts.kafkaKey) {
+				keyColumn = column.Name
+			}
+		}
+		if keyColumn == "" {
+			return errors.New(tr("kolom key %s tidak ada pada tabel %s", opts.kafkaKey, result.Table))
+		}
+	}
+	topic := t.topic
+	if topic == "" {
+		topic = result.Table
+	}
+
+	reader, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(data.writeTo(pw))
+	}()
+	defer reader.Close()
+	lines := bufio.NewReader(reader)
+	var batch []kafka.Message
+	for {
+		line, err := lines.ReadBytes('\n')
+		if len(line) > 0 {
+			message := kafka.Message{
+				Topic:   topic,
+				Value:   bytes.TrimSuffix(line, []byte("\n")),
+				Headers: []kafka.Header{{Key: "table", Value: []byte(result.Table)}},
+			}
+			if keyColumn != "" {
+				message.Key = ndjsonKey(message.Value, keyColumn)
+			}
+			batch = append(batch, message)
+		}
+		if len(batch) == ndjsonBatchMessages || (err != nil && len(batch) > 0) {
+			if err := t.kafka.WriteMessages(ctx, batch...); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ndjsonKey mengambil nilai kolom dari satu objek NDJSON sebagai key pesan.
+// Nilai null menghasilkan key kosong.
+func ndjsonKey(line []byte, column string) []byte {
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(line, &fields) != nil {
+		return nil
+	}
+	var text string
+	if json.Unmarshal(fields[column], &text) == nil {
+		return []byte(text)
+	}
+	if string(fields[column]) == "null" {
+		return nil
+	}
+	return fields[column]
+}
+
+// columnNames mengembalikan nama kolom data hasil konversi.
+func columnNames(result *xlsx2sql.Result) []string {
+	names := make([]string, len(result.Columns))
+	for i, column := range result.Columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// spillBuffer menampung isi file data di memori dan memindahkannya ke file
+// sementara di -tmp-dir begitu ukurannya melewati threshold, agar sheet yang
+// sangat besar tetap dapat dikonversi pada mesin dengan RAM terbatas.
+type spillBuffer struct {
+	threshold int64
+	mem       strings.Builder
+	tmp       *os.File
+	w         *bufio.Writer
+	size      int64
+	err       error
+}
+
+// bufferedBytes adalah total isi semua spillBuffer yang masih di memori,
+// dibandingkan dengan -memory-budget.
+var bufferedBytes atomic.Int64
+
+func (b *spillBuffer) WriteString(s string) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	b.size += int64(len(s))
+	if b.w != nil {
+		_, b.err = b.w.WriteString(s)
+		return len(s), b.err
+	}
+	b.mem.WriteString(s)
+	total := bufferedBytes.Add(int64(len(s)))
+	if b.threshold > 0 && int64(b.mem.Len()) > b.threshold ||
+		opts.memoryBudget > 0 && total > opts.memoryBudget<<20 {
+		b.spill()
+	}
+	return len(s), b.err
+}
+
+// Write membuat spillBuffer memenuhi io.Writer untuk writer.InsertWriter.
+func (b *spillBuffer) Write(p []byte) (int, error) {
+	return b.WriteString(string(p))
+}
+
+func (b *spillBuffer) spill() {
+	b.tmp, b.err = os.CreateTemp(opts.tmpDir, "xlsx2mariadb-*.sql")
+	if b.err != nil {
+		return
+	}
+	b.w = bufio.NewWriterSize(b.tmp, 1<<20)
+	_, b.err = b.w.WriteString(b.mem.String())
+	b.release()
+}
+
+// release mengosongkan buffer memori dan mengurangi bufferedBytes.
+func (b *spillBuffer) release() {
+	bufferedBytes.Add(-int64(b.mem.Len()))
+	b.mem = strings.Builder{}
+}
+
+func (b *spillBuffer) Len() int64 {
+	return b.size
+}
+
+// commit menulis header lalu isi buffer ke path, dikompres sesuai ekstensi
+// path. Buffer yang sudah dipindah ke disk, tanpa header dan tidak perlu
+// dikompres di-rename, atau disalin bila -tmp-dir berada di filesystem yang berbeda.
+func (b *spillBuffer) commit(path, header string) error {
+	if b.err != nil {
+		return b.err
+	}
+	if b.tmp == nil {
+		defer b.release()
+		return writeStreamAtomic(path, strings.NewReader(header+b.mem.String()))
+	}
+
+	if err := b.w.Flush(); err != nil {
+		return err
+	}
+	if err := b.tmp.Close(); err != nil {
+		return err
+	}
+	if filepath.Ext(path) == ".sql" && header == "" {
+		if err := os.Rename(b.tmp.Name(), path); err == nil {
+			b.tmp = nil
+			return nil
+		}
+	}
+
+	src, err := os.Open(b.tmp.Name())
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	return writeStreamAtomic(path, io.MultiReader(strings.NewReader(header), src))
+}
+
+// provenanceHeader membentuk komentar asal file SQL untuk sheet result dari
+// file sumber path. Setiap baris diawali "-- " sehingga dapat dibuang dengan
+// stripProvenance sebelum isi file dibandingkan atau dipecah per pernyataan.
+func provenanceHeader(path string, result *xlsx2sql.Result) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "-- Generated by xlsx2mariadb %s\n", version)
+	fmt.Fprintf(&b, "-- Source: %s\n", provenanceValue(path))
+	if result.Sheet != "" {
+		fmt.Fprintf(&b, "-- Sheet: %s\n", provenanceValue(result.Sheet))
+	}
+	fmt.Fprintf(&b, "-- Rows: %d\n", result.Rows)
+	fmt.Fprintf(&b, "-- Config: %s\n", configHash())
+	fmt.Fprintf(&b, "-- Generated at: %s\n", time.Now().UTC().Format(time.RFC3339))
+	return b.String()
+}
+
+// provenanceValue membuang baris baru dan titik koma agar nilai tidak keluar
+// dari komentar atau memecah pernyataan pada executeSQLTableFile.
+func provenanceValue(value string) string {
+	return strings.NewReplacer("\n", " ", "\r", " ", ";", ",").Replace(value)
+}
+
+// configHash mengembalikan sidik opsi yang berlaku (baris perintah dan db.cfg),
+// sehingga dua file SQL dengan hash yang sama dibuat dengan konfigurasi yang sama.
+func configHash() string {
+	var b strings.Builder
+	flag.VisitAll(func(f *flag.Flag) {
+		fmt.Fprintf(&b, "%s=%s\n", f.Name, f.Value)
+	})
+	return fingerprintString(b.String())
+}
+
+// stripProvenance membuang baris komentar "-- " di awal content.
+func stripProvenance(content string) string {
+	for strings.HasPrefix(content, "-- ") {
+		end := strings.IndexByte(content, '\n')
+		if end < 0 {
+			return ""
+		}
+		content = content[end+1:]
+	}
+	return content
+}
+
+// writeTo menulis isi buffer ke w.
+func (b *spillBuffer) writeTo(w io.Writer) error {
+	if b.err != nil {
+		return b.err
+	}
+	if b.tmp == nil {
+		_, err := io.WriteString(w, b.mem.String())
+		return err
+	}
+	if err := b.w.Flush(); err != nil {
+		return err
+	}
+	if _, err := b.tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := io.Copy(w, b.tmp)
+	return err
+}
+
+// discard menghapus file sementara yang belum dipindahkan ke tujuan.
+func (b *spillBuffer) discard() {
+	b.release()
+	if b.tmp != nil {
+		b.tmp.Close()
+		os.Remove(b.tmp.Name())
+		b.tmp = nil
+	}
+}
+
+// tableStats adalah statistik satu tabel hasil konversi, disimpan di samping
+// file data sebagai JSON lalu dicatat ke tabel _import_stats saat pemuatan.
+type tableStats struct {
+	Table  string `json:"table"`
+	Source string `json:"source"`
+
+	// Sheet, FirstRow, FirstColumn, HeaderRow, HeaderRows, SkipRows dan
+	// NoHeader dipakai untuk menunjuk sel sumber pada kesalahan pemuatan
+	Sheet       string `json:"sheet,omitempty"`
+	FirstRow    int    `json:"first_row,omitempty"`
+	FirstColumn int    `json:"first_column,omitempty"`
+	HeaderRow   int    `json:"header_row,omitempty"`
+	HeaderRows  int    `json:"header_rows,omitempty"`
+	SkipRows    int    `json:"skip_rows,omitempty"`
+	NoHeader    bool   `json:"no_header,omitempty"`
+
+	Rows    int           `json:"rows"`
+	Bytes   int64         `json:"bytes"`
+	Columns []columnStats `json:"columns"`
+}
+
+type columnStats struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Nulls int    `json:"nulls"`
+	Min   string `json:"min,omitempty"`
+	Max   string `json:"max,omitempty"`
+}
+
+// buildTableStats menghitung jumlah NULL per kolom dengan aturan yang sama
+// seperti penulisan INSERT, serta nilai minimum dan maksimum kolom tanggal/waktu.
+// addRow menambahkan satu baris data ke statistik. Kolom diisi dari skema
+// result pada baris pertama.
+func (s *tableStats) addRow(result *xlsx2sql.Result, row []string) {
+	if s.Columns == nil {
+		s.Table = result.Table
+		s.Sheet = result.Sheet
+		s.FirstRow, s.FirstColumn = result.FirstRow, result.FirstColumn
+		s.Columns = make([]columnStats, len(result.Columns))
+		for i, column := range result.Columns {
+			s.Columns[i] = columnStats{Name: column.Name, Type: column.Type}
+		}
+	}
+	s.Rows++
+	for i := range s.Columns {
+		column := &s.Columns[i]
+		if i >= len(row) || row[i] == "" {
+			column.Nulls++
+			continue
+		}
+		if !inference.IsDateTimeType(column.Type) {
+			continue
+		}
+		value := row[i]
+		if !inference.IsValidDateTime(value, column.Type) {
+			column.Nulls++
+			continue
+		}
+		// Format ISO dapat dibandingkan secara leksikal
+		if column.Min == "" || value < column.Min {
+			column.Min = value
+		}
+		if value > column.Max {
+			column.Max = value
+		}
+	}
+}
+
+func statsFilePath(dataFile string) string {
+	return strings.TrimSuffix(dataFile, dataFileExt(dataFile)) + ".stats.json"
+}
+
+// Ekstensi file data yang dikenali: tanpa kompresi, gzip dan zstd
+var dataFileExts = []string{".sql", ".sql.gz", ".sql.zst", ".tbl", ".csv"}
+
+func dataFileExt(name string) string {
+	for _, ext := range dataFileExts[1:] {
+		if strings.HasSuffix(name, ext) {
+			return ext
+		}
+	}
+	return filepath.Ext(name)
+}
+
+func isDataFile(name string) bool {
+	ext := dataFileExt(name)
+	for _, known := range dataFileExts {
+		if ext == known {
+			return true
+		}
+	}
+	return false
+}
+
+// dataFileTable mengembalikan nama tabel dari nama file data, misalnya
+// data_penjualan.sql.zst menjadi penjualan.
+func dataFileTable(name string) string {
+	name = filepath.Base(name)
+	return strings.TrimSuffix(strings.TrimPrefix(name, "data_"), dataFileExt(name))
+}
+
+// dataFilePath mengembalikan path file data tabel sesuai opsi -compress.
+func dataFilePath(dir, tableName string) string {
+	ext := ".sql"
+	switch {
+	case opts.columnstore:
+		// cpimport membaca file teks berbatas tanpa kompresi
+		ext = ".tbl"
+	case warehouseDialect() != "":
+		// COPY membaca CSV tanpa kompresi
+		ext = ".csv"
+	case opts.compress == "gzip":
+		ext = ".sql.gz"
+	case opts.compress == "zstd":
+		ext = ".sql.zst"
+	}
+	return filepath.Join(dir, "data_"+tableName+ext)
+}
+
+// copyLocation mengembalikan lokasi file data dataFile pada perintah COPY:
+// awalan -copy-location, atau direktori -sql-data-dir bila kosong, diikuti
+// path file relatif terhadap direktori tersebut.
+func copyLocation(dataFile string) string {
+	prefix := opts.copyLocation
+	if prefix == "" {
+		prefix = strings.TrimSuffix(filepath.ToSlash(opts.sqlDataDir), "/") + "/"
+	}
+	rel, err := filepath.Rel(workPath(opts.sqlDataDir), dataFile)
+	if err != nil {
+		rel = filepath.Base(dataFile)
+	}
+	return prefix + filepath.ToSlash(rel)
+}
+
+// findDataFile mencari file data tabel dengan ekstensi apa pun.
+func findDataFile(dir, tableName string) (string, error) {
+	for _, ext := range dataFileExts {
+		if path, err := outputFile(dir, "data_"+tableName+ext); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New(tr("file data tabel %s tidak ditemukan", tableName))
+}
+
+// removeStaleDataFiles menghapus file data tabel yang sama dengan kompresi
+// lain dari konversi sebelumnya, agar tabel tidak dimuat dua kali.
+func removeStaleDataFiles(dataFile string) {
+	base := strings.TrimSuffix(dataFile, dataFileExt(dataFile))
+	for _, ext := range dataFileExts {
+		if base+ext != dataFile {
+			os.Remove(base + ext)
+		}
+	}
+}
+
+// readDataFile membaca file data dan mendekompresinya secara streaming bila
+// berekstensi .gz atau .zst.
+func readDataFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	switch dataFileExt(path) {
+	case ".sql.gz":
+		zr, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		return io.ReadAll(zr)
+	case ".sql.zst":
+		zr, err := zstd.NewReader(file)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		return io.ReadAll(zr)
+	}
+	return io.ReadAll(file)
+}
+
+// writeStreamAtomic seperti writeFileAtomic, tetapi membaca isi dari r dan
+// mengompresnya bila path berekstensi .gz atau .zst.
+func writeStreamAtomic(path string, r io.Reader) error {
+	tmp := path + ".tmp"
+	file, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	var w io.WriteCloser
+	switch dataFileExt(path) {
+	case ".sql.gz":
+		w = gzip.NewWriter(file)
+	case ".sql.zst":
+		w, err = zstd.NewWriter(file)
+	}
+	if err == nil && w != nil {
+		_, err = io.Copy(w, r)
+		if closeErr := w.Close(); err == nil {
+			err = closeErr
+		}
+	} else if err == nil {
+		_, err = io.Copy(file, r)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func writeTableStats(path string, stats tableStats) error {
+	content, err := json.MarshalIndent(stats, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, string(content))
+}
+
+func readTableStats(path string) (tableStats, error) {
+	var stats tableStats
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return stats, err
+	}
+	err = json.Unmarshal(content, &stats)
+	return stats, err
+}
+
+const importStatsTable = `CREATE TABLE IF NOT EXISTS _import_stats (
+_import_stats_id BIGINT NOT NULL AUTO_INCREMENT,
+run_id VARCHAR(32) NOT NULL,
+table_name VARCHAR(64) NOT NULL,
+source_file VARCHAR(255) NOT NULL,
+imported_at DATETIME NOT NULL,
+row_count BIGINT NOT NULL,
+byte_count BIGINT NOT NULL,
+column_stats JSON,
+PRIMARY KEY (_import_stats_id),
+INDEX idx_table_name (table_name, imported_at)
+) ENGINE = INNODB`
+
+// recordImportStats mencatat statistik tabel yang baru dimuat ke _import_stats.
+func recordImportStats(ctx context.Context, t *dbTarget, stats tableStats) error {
+	t.mu.Lock()
+	if !t.statsReady {
+		if err := execWithReconnect(ctx, t.db, importStatsTable); err != nil {
+			t.mu.Unlock()
+			return err
+		}
+		t.statsReady = true
+	}
+	t.mu.Unlock()
+
+	columns, err := json.Marshal(stats.Columns)
+	if err != nil {
+		return err
+	}
+	_, err = t.db.ExecContext(ctx,
+		"INSERT INTO _import_stats (run_id, table_name, source_file, imported_at, row_count, byte_count, column_stats) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		runID, stats.Table, stats.Source, time.Now().Format("2006-01-02 15:04:05"), stats.Rows, stats.Bytes, string(columns))
+	return err
+}
+
+func statsEnabled() bool {
+	return opts.stats || opts.anomalyThreshold > 0 || opts.drift != "off"
+}
+
+// columnDrift adalah perbedaan kolom file saat ini terhadap impor sebelumnya.
+type columnDrift struct {
+	previous string // sidik skema impor sebelumnya
+	current  string // sidik skema file saat ini
+	added    []columnStats
+	removed  []columnStats
+	renamed  [][2]columnStats // pasangan kolom lama dan baru pada posisi yang sama
+}
+
+func (d *columnDrift) empty() bool {
+	return len(d.added) == 0 && len(d.removed) == 0 && len(d.renamed) == 0
+}
+
+// schemaFingerprint menghasilkan sidik pendek dari urutan nama kolom.
+func schemaFingerprint(columns []columnStats) string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	sum := sha256.Sum256([]byte(strings.Join(names, ",")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// detectColumnDrift membandingkan kolom file dengan impor terakhir dari file
+// sumber yang sama di _import_stats. Kolom yang hilang dan kolom baru pada
+// posisi yang sama dianggap sebagai kolom yang diganti namanya.
+func detectColumnDrift(ctx context.Context, db *sql.DB, stats tableStats) (*columnDrift, error) {
+	var columnsJSON sql.NullString
+	err := db.QueryRowContext(ctx,
+		"SELECT column_stats FROM _import_stats WHERE source_file = ? ORDER BY imported_at DESC LIMIT 1",
+		stats.Source).Scan(&columnsJSON)
+	var mysqlErr *mysql.MySQLError
+	if errors.Is(err, sql.ErrNoRows) || (errors.As(err, &mysqlErr) && mysqlErr.Number == 1146) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var previous []columnStats
+	if columnsJSON.Valid {
+		if err := json.Unmarshal([]byte(columnsJSON.String), &previous); err != nil {
+			return nil, err
+		}
+	}
+
+	drift := &columnDrift{previous: schemaFingerprint(previous), current: schemaFingerprint(stats.Columns)}
+	if drift.previous == drift.current {
+		return drift, nil
+	}
+
+	oldNames := make(map[string]bool)
+	for _, column := range previous {
+		oldNames[column.Name] = true
+	}
+	newNames := make(map[string]bool)
+	for _, column := range stats.Columns {
+		newNames[column.Name] = true
+	}
+	for i, column := range stats.Columns {
+		if oldNames[column.Name] {
+			continue
+		}
+		if i < len(previous) && !newNames[previous[i].Name] {
+			drift.renamed = append(drift.renamed, [2]columnStats{previous[i], column})
+			continue
+		}
+		drift.added = append(drift.added, column)
+	}
+	for i, column := range previous {
+		if newNames[column.Name] {
+			continue
+		}
+		if i < len(stats.Columns) && !oldNames[stats.Columns[i].Name] {
+			continue // sudah dicatat sebagai rename
+		}
+		drift.removed = append(drift.removed, column)
+	}
+	return drift, nil
+}
+
+// applyDriftAlter menyesuaikan tabel yang sudah ada dengan kolom file saat ini:
+// kolom baru ditambahkan dan kolom yang diganti namanya di-rename. Kolom yang
+// hilang dibiarkan dan akan berisi NULL untuk data baru.
+func applyDriftAlter(ctx context.Context, db *sql.DB, tableName string, drift *columnDrift) error {
+	for _, pair := range drift.renamed {
+		query := fmt.Sprintf("ALTER TABLE %s RENAME COLUMN %s TO %s", tableName, pair[0].Name, pair[1].Name)
+		if err := execWithReconnect(ctx, db, query); err != nil {
+			return err
+		}
+	}
+	for _, column := range drift.added {
+		query := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s DEFAULT NULL", tableName, column.Name, column.Type)
+		if err := execWithReconnect(ctx, db, query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkColumnDrift menerapkan kebijakan -drift dan mengembalikan false bila
+// file tidak boleh dimuat.
+func checkColumnDrift(ctx context.Context, t *dbTarget, tableName string, stats tableStats) bool {
+	drift, err := detectColumnDrift(ctx, t.db, stats)
+	if err != nil {
+		logError(err, tr("Gagal memeriksa perubahan kolom untuk %s", tableName))
+		return true
+	}
+	if drift == nil || drift.empty() {
+		return true
+	}
+
+	var changes []string
+	for _, column := range drift.added {
+		changes = append(changes, "+"+column.Name)
+	}
+	for _, column := range drift.removed {
+		changes = append(changes, "-"+column.Name)
+	}
+	for _, pair := range drift.renamed {
+		changes = append(changes, pair[0].Name+"->"+pair[1].Name)
+	}
+	msg := tr("Kolom %s berubah dari impor sebelumnya (skema %s -> %s): %s", stats.Source, drift.previous, drift.current, strings.Join(changes, ", "))
+	logError(errors.New(msg), tr("Perubahan kolom terdeteksi pada target %s", t.name))
+
+	switch opts.drift {
+	case "block":
+		return false
+	case "alter":
+		if err := applyDriftAlter(ctx, t.db, tableName, drift); err != nil {
+			logError(err, tr("Gagal menyesuaikan kolom tabel %s", tableName))
+			return false
+		}
+		logRun(tr("Kolom tabel %s pada %s disesuaikan otomatis", tableName, t.name))
+		return true
+	default:
+		return askApproval(tr("Setujui perubahan kolom %s dan lanjutkan pemuatan ke %s? (Ya/Tidak, default Tidak): ", stats.Source, t.name))
+	}
+}
+
+// detectAnomalies membandingkan statistik file saat ini dengan rata-rata
+// impor sebelumnya dari file sumber yang sama di _import_stats. Jumlah baris
+// dibandingkan secara relatif, rasio NULL per kolom secara absolut, keduanya
+// terhadap -anomaly-threshold.
+func detectAnomalies(ctx context.Context, db *sql.DB, stats tableStats) ([]string, error) {
+	rows, err := db.QueryContext(ctx,
+		"SELECT row_count, column_stats FROM _import_stats WHERE source_file = ? ORDER BY imported_at DESC LIMIT ?",
+		stats.Source, opts.anomalyHistory)
+	if err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1146 {
+			// Tabel _import_stats belum ada, belum ada riwayat
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := 0
+	totalRows := 0.0
+	nullRates := make(map[string]float64)
+	for rows.Next() {
+		var rowCount int64
+		var columnsJSON sql.NullString
+		if err := rows.Scan(&rowCount, &columnsJSON); err != nil {
+			return nil, err
+		}
+		var columns []columnStats
+		if columnsJSON.Valid {
+			json.Unmarshal([]byte(columnsJSON.String), &columns)
+		}
+		history++
+		totalRows += float64(rowCount)
+		for _, column := range columns {
+			if rowCount > 0 {
+				nullRates[column.Name] += float64(column.Nulls) / float64(rowCount)
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if history == 0 {
+		return nil, nil
+	}
+
+	var anomalies []string
+	avgRows := totalRows / float64(history)
+	if avgRows > 0 {
+		deviation := math.Abs(float64(stats.Rows)-avgRows) / avgRows
+		if deviation > opts.anomalyThreshold {
+			anomalies = append(anomalies, tr("jumlah baris %d menyimpang %.0f%% dari rata-rata %.0f", stats.Rows, deviation*100, avgRows))
+		}
+	}
+	for _, column := range stats.Columns {
+		avgRate, ok := nullRates[column.Name]
+		if !ok || stats.Rows == 0 {
+			continue
+		}
+		avgRate /= float64(history)
+		rate := float64(column.Nulls) / float64(stats.Rows)
+		if math.Abs(rate-avgRate) > opts.anomalyThreshold {
+			anomalies = append(anomalies, tr("rasio NULL kolom %s %.1f%% berbeda dari rata-rata %.1f%%", column.Name, rate*100, avgRate*100))
+		}
+	}
+	return anomalies, nil
+}
+
+// checkpoint mencatat pekerjaan yang sudah selesai agar run berikutnya dengan
+// opsi -resume dapat melewatinya setelah program terhenti di tengah jalan.
+type checkpoint struct {
+	mu   sync.Mutex
+	path string
+
+	// Converted memetakan path file Excel ke ukuran dan waktu modifikasinya saat
+	// dikonversi, sehingga file yang berubah setelah itu tetap dikonversi ulang.
+	Converted map[string]string `json:"converted"`
+	// Executed memetakan nama target ke file SQL yang sudah selesai dieksekusi.
+	Executed map[string]map[string]bool `json:"executed"`
+	// LoadedRows memetakan nama target ke jumlah tuple yang sudah dimuat dari
+	// file data yang belum selesai.
+	LoadedRows map[string]map[string]int `json:"loaded_rows"`
+	// LoadedChunks sama dengan LoadedRows untuk file yang dimuat per potongan
+	// (-load-chunks): jumlah tuple yang sudah dimuat dari setiap potongan.
+	LoadedChunks map[string]map[string][]int `json:"loaded_chunks,omitempty"`
+}
+
+var runCheckpoint *checkpoint
+
+// openCheckpoint membuat checkpoint baru, atau membaca checkpoint lama bila resume.
+func openCheckpoint(path string, resume bool) (*checkpoint, error) {
+	cp := &checkpoint{
+		path:         path,
+		Converted:    make(map[string]string),
+		Executed:     make(map[string]map[string]bool),
+		LoadedRows:   make(map[string]map[string]int),
+		LoadedChunks: make(map[string]map[string][]int),
+	}
+	if resume {
+		content, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		if err == nil {
+			if err := json.Unmarshal(content, cp); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return cp, cp.save()
+}
+
+// checkpointKey menyeragamkan path relatif dan absolut menjadi satu key. Di
+// Windows huruf besar/kecil pada path diabaikan.
+func checkpointKey(path string) string {
+	if abs, err := winpath.Resolve("", path); err == nil {
+		return winpath.Key(abs)
+	}
+	return winpath.Key(path)
+}
+
+func fileFingerprint(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano())
+}
+
+func (cp *checkpoint) save() error {
+	content, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(cp.path, string(content))
+}
+
+func (cp *checkpoint) saveLocked() {
+	if err := cp.save(); err != nil {
+		logError(err, tr("Gagal menyimpan checkpoint %s", cp.path))
+	}
+}
+
+func (cp *checkpoint) isConverted(path string) bool {
+	if cp == nil {
+		return false
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	fingerprint, ok := cp.Converted[path]
+	return ok && fingerprint == fileFingerprint(path)
+}
+
+// markConverted mencatat file Excel yang selesai dikonversi. File SQL hasil
+// konversi ulang harus dieksekusi lagi, sehingga statusnya di setiap target dihapus.
+func (cp *checkpoint) markConverted(path string, outputs ...string) {
+	if cp == nil {
+		return
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.Converted[path] = fileFingerprint(path)
+	cp.forgetLocked(outputs)
+	cp.saveLocked()
+}
+
+// forget menghapus status eksekusi file SQL yang isinya diubah setelah konversi.
+func (cp *checkpoint) forget(outputs ...string) {
+	if cp == nil {
+		return
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.forgetLocked(outputs)
+	cp.saveLocked()
+}
+
+func (cp *checkpoint) forgetLocked(outputs []string) {
+	for _, output := range outputs {
+		for target := range cp.Executed {
+			delete(cp.Executed[target], checkpointKey(output))
+		}
+		for target := range cp.LoadedRows {
+			delete(cp.LoadedRows[target], checkpointKey(output))
+		}
+		for target := range cp.LoadedChunks {
+			delete(cp.LoadedChunks[target], checkpointKey(output))
+		}
+	}
+}
+
+func (cp *checkpoint) isExecuted(target, file string) bool {
+	if cp == nil {
+		return false
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.Executed[target][checkpointKey(file)]
+}
+
+func (cp *checkpoint) markExecuted(target, file string) {
+	if cp == nil {
+		return
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if cp.Executed[target] == nil {
+		cp.Executed[target] = make(map[string]bool)
+	}
+	cp.Executed[target][checkpointKey(file)] = true
+	delete(cp.LoadedRows[target], checkpointKey(file))
+	delete(cp.LoadedChunks[target], checkpointKey(file))
+	cp.saveLocked()
+}
+
+func (cp *checkpoint) loadedRows(target, file string) int {
+	if cp == nil {
+		return 0
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return cp.LoadedRows[target][checkpointKey(file)]
+}
+
+func (cp *checkpoint) addLoadedRows(target, file string, rows int) {
+	if cp == nil {
+		return
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if cp.LoadedRows[target] == nil {
+		cp.LoadedRows[target] = make(map[string]int)
+	}
+	cp.LoadedRows[target][checkpointKey(file)] += rows
+	cp.saveLocked()
+}
+
+// loadedChunks mengembalikan jumlah tuple yang sudah dimuat dari setiap
+// potongan file, atau nil bila file belum pernah dimuat per potongan.
+func (cp *checkpoint) loadedChunks(target, file string) []int {
+	if cp == nil {
+		return nil
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	return slices.Clone(cp.LoadedChunks[target][checkpointKey(file)])
+}
+
+func (cp *checkpoint) addChunkRows(target, file string, chunk, chunks, rows int) {
+	if cp == nil {
+		return
+	}
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if cp.LoadedChunks == nil {
+		cp.LoadedChunks = make(map[string]map[string][]int)
+	}
+	if cp.LoadedChunks[target] == nil {
+		cp.LoadedChunks[target] = make(map[string][]int)
+	}
+	key := checkpointKey(file)
+	loaded := cp.LoadedChunks[target][key]
+	if len(loaded) != chunks {
+		loaded = make([]int, chunks)
+	}
+	loaded[chunk] += rows
+	cp.LoadedChunks[target][key] = loaded
+	cp.saveLocked()
+}
+
+// stateStore menyimpan hash SHA-256 setiap file Excel pada konversi terakhir
+// agar file yang isinya tidak berubah tidak dikonversi ulang.
+type stateStore struct {
+	mu    sync.Mutex
+	path  string
+	Files map[string]fileState `json:"files"`
+}
+
+type fileState struct {
+	SHA256      string    `json:"sha256"`
+	ConvertedAt time.Time `json:"converted_at"`
+}
+
+var fileStates *stateStore
+
+func openStateStore(path string) (*stateStore, error) {
+	store := &stateStore{path: path, Files: make(map[string]fileState)}
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, store); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func (st *stateStore) unchanged(path, hash string) bool {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	state, ok := st.Files[checkpointKey(path)]
+	return ok && state.SHA256 == hash
+}
+
+func (st *stateStore) record(path, hash string) {
+	if st == nil {
+		return
+	}
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.Files[checkpointKey(path)] = fileState{SHA256: hash, ConvertedAt: time.Now()}
+
+	content, err := json.MarshalIndent(st, "", "  ")
+	if err == nil {
+		err = writeFileAtomic(st.path, string(content))
+	}
+	if err != nil {
+		logError(err, tr("Gagal menyimpan file state %s", st.path))
+	}
+}
+
+const reviewDir = "review"
+
+// writeReviewFile menulis skema dan beberapa baris pertama tabel ke
+// review/<tabel>.md untuk diperiksa sebelum pemuatan disetujui.
+func writeReviewFile(tableName, path, createTableStatement string, header, columnTypes []string, dataRows [][]string) error {
+	if err := os.MkdirAll(reviewDir, 0755); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", tableName)
+	fmt.Fprintf(&b, "Sumber: %s\n\n", filepath.Base(path))
+	fmt.Fprintf(&b, "Sidik skema: %s\n\n", fingerprintString(createTableStatement))
+	fmt.Fprintf(&b, "```sql\n%s\n```\n\n", createTableStatement)
+	writeMarkdownTable(&b, header, columnTypes, dataRows, opts.reviewRows)
+
+	return writeFileAtomic(filepath.Join(reviewDir, tableName+".md"), b.String())
+}
+
+const previewDir = "preview"
+
+// writePreviewFile menulis beberapa baris pertama tabel beserta tipe kolom hasil
+// inferensi ke preview/<tabel>.md atau preview/<tabel>.html.
+func writePreviewFile(tableName string, header, columnTypes []string, dataRows [][]string) error {
+	if err := os.MkdirAll(previewDir, 0755); err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	ext := ".md"
+	if opts.preview == "html" {
+		ext = ".html"
+		writeHTMLTable(&b, tableName, header, columnTypes, dataRows, opts.previewRows)
+	} else {
+		fmt.Fprintf(&b, "# %s\n\n", tableName)
+		writeMarkdownTable(&b, header, columnTypes, dataRows, opts.previewRows)
+	}
+	return writeFileAtomic(filepath.Join(previewDir, tableName+ext), b.String())
+}
+
+// writeMarkdownTable menulis header kolom, baris tipe kolom dan paling banyak
+// limit baris data sebagai tabel Markdown.
+func writeMarkdownTable(b *strings.Builder, header, columnTypes []string, dataRows [][]string, limit int) {
+	escape := func(s string) string {
+		s = strings.ReplaceAll(s, "|", "\\|")
+		return strings.ReplaceAll(s, "\n", " ")
+	}
+
+	b.WriteString("|")
+	for _, colCell := range header {
+		fmt.Fprintf(b, " %s |", escape(tableNaming().Column(colCell)))
+	}
+	b.WriteString("\n|")
+	for range header {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n|")
+	for _, columnType := range columnTypes {
+		fmt.Fprintf(b, " *%s* |", columnType)
+	}
+	b.WriteString("\n")
+	for i, row := range dataRows {
+		if i >= limit {
+			break
+		}
+		b.WriteString("|")
+		for j := range header {
+			cell := ""
+			if j < len(row) {
+				cell = escape(row[j])
+			}
+			fmt.Fprintf(b, " %s |", cell)
+		}
+		b.WriteString("\n")
+	}
+}
+
+func writeHTMLTable(b *strings.Builder, tableName string, header, columnTypes []string, dataRows [][]string, limit int) {
+	fmt.Fprintf(b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", html.EscapeString(tableName))
+	b.WriteString("<style>table{border-collapse:collapse}th,td{border:1px solid #ccc;padding:2px 6px}.type{color:#666;font-style:italic}</style>\n")
+	fmt.Fprintf(b, "</head>\n<body>\n<h1>%s</h1>\n<table>\n<tr>", html.EscapeString(tableName))
+	for _, colCell := range header {
+		fmt.Fprintf(b, "<th>%s</th>", html.EscapeString(tableNaming().Column(colCell)))
+	}
+	b.WriteString("</tr>\n<tr class=\"type\">")
+	for _, columnType := range columnTypes {
+		fmt.Fprintf(b, "<th>%s</th>", html.EscapeString(columnType))
+	}
+	b.WriteString("</tr>\n")
+	for i, row := range dataRows {
+		if i >= limit {
+			break
+		}
+		b.WriteString("<tr>")
+		for j := range header {
+			cell := ""
+			if j < len(row) {
+				cell = row[j]
+			}
+			fmt.Fprintf(b, "<td>%s</td>", html.EscapeString(cell))
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>\n</body>\n</html>\n")
+}
+
+func fingerprintString(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:8])
+}
+
+const approvalsPath = "approvals.json"
+
+// approval mencatat persetujuan pemuatan satu tabel untuk skema tertentu.
+type approval struct {
+	Fingerprint string    `json:"fingerprint"`
+	ApprovedBy  string    `json:"approved_by"`
+	ApprovedAt  time.Time `json:"approved_at"`
+}
+
+var approvalsMu sync.Mutex
+
+func readApprovals() (map[string]approval, error) {
+	approvals := make(map[string]approval)
+	content, err := os.ReadFile(approvalsPath)
+	if os.IsNotExist(err) {
+		return approvals, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(content, &approvals)
+	return approvals, err
+}
+
+func tableSchemaFingerprint(tableName string) (string, error) {
+	path, err := outputFile(workPath(opts.sqlTableDir), tableName+".sql")
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	// Waktu pembuatan pada header berubah setiap konversi, skemanya tidak
+	return fingerprintString(stripProvenance(string(content))), nil
+}
+
+func isAuthorizedApprover(user string) bool {
+	if opts.approvers == "" {
+		return true
+	}
+	for _, approver := range strings.Split(opts.approvers, ",") {
+		if strings.TrimSpace(approver) == user {
+			return true
+		}
+	}
+	return false
+}
+
+// approveTable menyetujui pemuatan tabel dengan skema yang ada saat ini. Bila
+// tabel dikonversi ulang dengan skema berbeda, persetujuan harus diberikan lagi.
+func approveTable(tableName, user string) error {
+	if user == "" || !isAuthorizedApprover(user) {
+		return errors.New(tr("pengguna %q tidak berwenang menyetujui pemuatan", user))
+	}
+	fingerprint, err := tableSchemaFingerprint(tableName)
+	if err != nil {
+		return err
+	}
+
+	approvalsMu.Lock()
+	defer approvalsMu.Unlock()
+	approvals, err := readApprovals()
+	if err != nil {
+		return err
+	}
+	approvals[tableName] = approval{Fingerprint: fingerprint, ApprovedBy: user, ApprovedAt: time.Now()}
+	content, err := json.MarshalIndent(approvals, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(approvalsPath, string(content))
+}
+
+// isApproved mengembalikan true bila tabel sudah disetujui untuk skema saat ini.
+func isApproved(tableName string) bool {
+	fingerprint, err := tableSchemaFingerprint(tableName)
+	if err != nil {
+		return false
+	}
+	approvalsMu.Lock()
+	defer approvalsMu.Unlock()
+	approvals, err := readApprovals()
+	if err != nil {
+		logError(err, tr("Gagal membaca file persetujuan %s", approvalsPath))
+		return false
+	}
+	a, ok := approvals[tableName]
+	return ok && a.Fingerprint == fingerprint
+}
+
+// runApprove menjalankan subperintah "approve <tabel>...".
+func runApprove(tables []string) int {
+	if len(tables) == 0 {
+		fmt.Println(tr("Penggunaan: xlsx2mariadb [opsi] approve <tabel>..."))
+		return exitConfig
+	}
+	user, err := currentUser()
+	if err != nil {
+		logError(err, tr("Gagal menentukan akun sistem penyetuju"))
+		return exitConfig
+	}
+	code := exitOK
+	for _, tableName := range tables {
+		if err := approveTable(tableName, user); err != nil {
+			logError(err, tr("Gagal menyetujui tabel %s", tableName))
+			code = exitPartial
+			continue
+		}
+		msg := tr("Tabel %s disetujui oleh %s", tableName, user)
+		logRun(msg)
+		fmt.Println(msg)
+	}
+	return code
+}
+
+// currentUser mengembalikan nama akun sistem operasi yang menjalankan
+// program, bukan variabel lingkungan seperti $USER yang dapat diisi bebas.
+func currentUser() (string, error) {
+	account, err := user.Current()
+	if err != nil {
+		return "", err
+	}
+	return account.Username, nil
+}
+
+// readApproverTokens membaca file -approver-tokens berkolom user dan token
+// menjadi peta token ke nama penyetuju.
+func readApproverTokens(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	userCol, tokenCol := -1, -1
+	if len(records) > 0 {
+		for i, name := range records[0] {
+			switch strings.ToLower(strings.TrimSpace(name)) {
+			case "user":
+				userCol = i
+			case "token":
+				tokenCol = i
+			}
+		}
+	}
+	if userCol < 0 || tokenCol < 0 {
+		return nil, errors.New(tr("file token penyetuju harus memiliki kolom user dan token"))
+	}
+	tokens := make(map[string]string)
+	for _, record := range records[1:] {
+		if userCol >= len(record) || tokenCol >= len(record) {
+			continue
+		}
+		name, token := strings.TrimSpace(record[userCol]), strings.TrimSpace(record[tokenCol])
+		if name == "" || len(token) < minTokenLength {
+			return nil, errors.New(tr("token penyetuju %q harus paling sedikit %d karakter", name, minTokenLength))
+		}
+		tokens[token] = name
+	}
+	return tokens, nil
+}
+
+// minTokenLength adalah panjang terpendek token penyetuju dan -api-token.
+const minTokenLength = 16
+
+// approverFor mengembalikan nama penyetuju pemilik token, kosong bila token
+// tidak dikenal. Setiap token dibandingkan dalam waktu konstan.
+func approverFor(tokens map[string]string, token string) string {
+	approver := ""
+	for known, name := range tokens {
+		if subtle.ConstantTimeCompare([]byte(known), []byte(token)) == 1 {
+			approver = name
+		}
+	}
+	return approver
+}
+
+// excelizeOptions membentuk opsi pembacaan excelize untuk file path dari
+// password workbook, -xlsx-unzip-limit, -xlsx-xml-limit dan -low-memory.
+// Lokasi file sementara mengikuti TMPDIR.
+func excelizeOptions(path string) excelize.Options {
+	var options excelize.Options
+	options.Password = passwordFor(path)
+	if opts.xlsxUnzipLimit > 0 {
+		options.UnzipSizeLimit = opts.xlsxUnzipLimit << 20
+	}
+	if opts.xlsxXMLLimit > 0 {
+		options.UnzipXMLSizeLimit = opts.xlsxXMLLimit << 20
+	} else if opts.lowMemory {
+		options.UnzipXMLSizeLimit = 1 << 20
+	}
+	// excelize menolak UnzipXMLSizeLimit (default 16 MB) yang lebih besar dari UnzipSizeLimit
+	if options.UnzipSizeLimit > 0 && (options.UnzipXMLSizeLimit > options.UnzipSizeLimit ||
+		options.UnzipXMLSizeLimit == 0 && options.UnzipSizeLimit < 16<<20) {
+		options.UnzipXMLSizeLimit = options.UnzipSizeLimit
+	}
+	return options
+}
+
+var waitingInput atomic.Bool
+
+// promptMu mencegah dua pertanyaan ke pengguna tampil bersamaan saat beberapa
+// file dimuat paralel.
+var promptMu sync.Mutex
+
+// handleSignals menangani SIGINT/SIGTERM: sinyal pertama membatalkan context
+// program sehingga tidak ada file baru yang diambil dan file serta pernyataan
+// SQL yang sedang berjalan dihentikan, sinyal kedua menghentikan program seketika.
+func handleSignals(cancel context.CancelFunc) {
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		sig := <-sigCh
+		msg := tr("Sinyal %v diterima, menghentikan proses yang sedang berjalan (tekan sekali lagi untuk berhenti paksa)...", sig)
+		logRun(msg)
+		fmt.Println(msg)
+		cancel()
+
+		// Tidak ada pekerjaan yang berjalan ketika program menunggu jawaban pengguna
+		if waitingInput.Load() {
+			logShutdownSummary()
+			os.Exit(exitInterrupted)
+		}
+
+		<-sigCh
+		logRun(tr("Sinyal kedua diterima, program dihentikan paksa."))
+		os.Exit(exitInterrupted)
+	}()
+}
+
+// interruptedStatus mengembalikan status file yang dihentikan oleh context.
+func interruptedStatus(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "timeout"
+	}
+	return "incomplete"
+}
+
+func logShutdownSummary() {
+	mu.Lock()
+	done := processedFiles
+	mu.Unlock()
+
+	msg := tr("Program dihentikan oleh sinyal: %d dari %d file selesai diproses.", done, totalFiles)
+	logRun(msg)
+	fmt.Println(msg)
+}
+
+// askContinue menampilkan pertanyaan dan mengembalikan false bila pengguna menjawab tidak.
+func askContinue(question string) bool {
+	promptMu.Lock()
+	defer promptMu.Unlock()
+	fmt.Print(question)
+
+	var answer string
+	waitingInput.Store(true)
+	fmt.Scanln(&answer)
+	waitingInput.Store(false)
+
+	return !isNoAnswer(answer)
+}
+
+// askApproval seperti askContinue, tetapi hanya jawaban ya yang dianggap setuju.
+func askApproval(question string) bool {
+	promptMu.Lock()
+	defer promptMu.Unlock()
+	fmt.Print(question)
+
+	var answer string
+	waitingInput.Store(true)
+	fmt.Scanln(&answer)
+	waitingInput.Store(false)
+
+	switch strings.TrimSpace(strings.ToLower(answer)) {
+	case "ya", "y", "yes":
+		return true
+	}
+	return false
+}
+
+func logProcessing(filePath, status string, duration time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	processedFiles++
+	fileStatus[filePath] = status
+	if isFailedStatus(status) {
+		notifyFileFailure(filePath, status)
+	}
+	emitProgress("file_done", map[string]interface{}{"file": filePath, "status": status, "duration_ms": duration.Milliseconds()})
+	currentReport.addFile(filePath, status, duration)
+	runMetrics.observeFile(status, duration)
+	percentage := float64(processedFiles) / float64(totalFiles) * 100
+	if prescanTotalCells > 0 {
+		processedCells += prescanDims[filePath].cells()
+		percentage = float64(processedCells) / float64(prescanTotalCells) * 100
+	}
+	logEntry := fmt.Sprintf("%s: %s\n", time.Now().Format(time.RFC3339), tr("%s - %v - %s - %.2f%% selesai", filePath, duration, status, percentage))
+	if err := writeLog("read.log", logEntry); err != nil {
+		fmt.Println(tr("Error menulis ke file read.log: %v", err))
+	}
+
+	fmt.Println(tr("File: %s, Status: %s, Durasi: %v, %.2f%% selesai", filePath, status, duration, percentage))
+}
+
+func logRun(status string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	logEntry := fmt.Sprintf("%s: %s\n", time.Now().Format(time.RFC3339), status)
+	if err := writeLog("run.log", logEntry); err != nil {
+		fmt.Println(tr("Error menulis ke file run.log: %v", err))
+	}
+}
+
+func createDefaultDBConfig() error {
+	file, err := os.Create(dbConfigPath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString("username\npassword\ndatabase\nhostname\nport\n")
+	return err
+}
+
+func readDBConfig(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	config := make(map[string]string)
+	keys := []string{"username", "password", "database", "hostname", "port", "datadir"}
+	i := 0
+
+	// Lima baris pertama selalu posisional (password boleh memuat "="),
+	// baris berikutnya berformat key=value, diawali # untuk komentar.
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i >= len(keys)-1 {
+			trimmed := strings.TrimSpace(line)
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+			if key, value, ok := strings.Cut(trimmed, "="); ok {
+				config[strings.TrimSpace(key)] = strings.TrimSpace(value)
+				i = len(keys)
+				continue
+			}
+			if i >= len(keys) {
+				return nil, errors.New(tr("baris konfigurasi tidak valid: %q", line))
+			}
+		}
+		config[keys[i]] = line
+		i++
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// applyConfigOptions mengisi opsi baris perintah dari key=value pada file
+// konfigurasi. Nama key sama dengan nama flag ("_" boleh dipakai sebagai
+// pengganti "-"), dan flag yang diberikan di baris perintah tetap diutamakan.
+func applyConfigOptions(config map[string]string) error {
+	explicit := make(map[string]bool)
+	flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	for key, value := range config {
+		name := strings.ReplaceAll(key, "_", "-")
+		f := flag.Lookup(name)
+		if f == nil || explicit[name] {
+			continue
+		}
+		if err := f.Value.Set(value); err != nil {
+			return errors.New(tr("nilai %q untuk %s tidak valid: %v", value, key, err))
+		}
+	}
+	return nil
+}
+
+func createDBConnection(config map[string]string) (*sql.DB, error) {
+	cfg := mysql.Config{
+		User:                 config["username"],
+		Passwd:               config["password"],
+		Net:                  "tcp", // default to tcp, can be changed based on OS
+		Addr:                 config["hostname"] + ":" + config["port"],
+		DBName:               config["database"],
+		AllowNativePasswords: true,
+	}
+	if opts.encryptionKey != "" {
+		// Dijalankan driver sebagai SET pada setiap koneksi baru
+		cfg.Params = map[string]string{ddl.KeyVariable: fmt.Sprintf("'%s'", writer.EscapeString(opts.encryptionKey))}
+	}
+
+	// Check for Unix socket
+	if runtime.GOOS == "linux" {
+		if _, err := os.Stat("/var/run/mysqld/mysqld.sock"); err == nil {
+			cfg.Net = "unix"
+			cfg.Addr = "/var/run/mysqld/mysqld.sock"
+		}
+	}
+
+	dsn := cfg.FormatDSN()
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	// Koneksi diganti sebelum diputus server (wait_timeout) agar proses
+	// pemuatan yang panjang tidak mendapatkan "server has gone away".
+	db.SetConnMaxLifetime(opts.dbConnMaxLifetime)
+	db.SetConnMaxIdleTime(opts.dbConnMaxIdle)
+
+	return db, nil
+}
+
+// isConnectionLost mengenali koneksi yang terputus sebelum query dikirim
+// (driver.ErrBadConn), sehingga query aman diulang pada koneksi baru.
+// Kegagalan di tengah jalan seperti mysql.ErrInvalidConn, "broken pipe" atau
+// "connection reset" tidak termasuk: server mungkin sudah menjalankan dan
+// meng-commit batch tersebut, dan INSERT yang diulang menggandakan barisnya
+// pada tabel tanpa kunci unik.
+func isConnectionLost(err error) bool {
+	return errors.Is(err, driver.ErrBadConn)
+}
+
+// isDeadlock mengenali pernyataan yang dibatalkan InnoDB karena deadlock atau
+// lock wait timeout, misalnya saat beberapa potongan -load-chunks menyisipkan
+// ke indeks unik yang sama. Pernyataan tersebut di-rollback seluruhnya
+// sehingga aman diulang.
+func isDeadlock(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && (mysqlErr.Number == 1213 || mysqlErr.Number == 1205)
+}
+
+// execWithReconnect mengeksekusi query dan, bila koneksi terputus sebelum
+// query terkirim, melakukan ping untuk membuka koneksi baru lalu mencoba ulang
+// query yang sama. Query yang dibatalkan karena deadlock juga dicoba ulang.
+func execWithReconnect(ctx context.Context, db *sql.DB, query string) error {
+	for attempt := 0; ; attempt++ {
+		err := execWithTimeout(ctx, db, query)
+		if err == nil || !isConnectionLost(err) && !isDeadlock(err) || attempt >= opts.dbRetries || ctx.Err() != nil {
+			return err
+		}
+		if isDeadlock(err) {
+			logRun(tr("Deadlock pada database (%v), mencoba ulang (%d/%d)", err, attempt+1, opts.dbRetries))
+			time.Sleep(time.Duration(attempt+1) * 100 * time.Millisecond)
+			continue
+		}
+
+		logRun(tr("Koneksi database terputus (%v), mencoba ulang (%d/%d)", err, attempt+1, opts.dbRetries))
+		time.Sleep(time.Duration(attempt+1) * time.Second)
+		if err := db.PingContext(ctx); err != nil {
+			logError(err, tr("Gagal membuka ulang koneksi ke database"))
+		}
+	}
+}
+
+// execWithTimeout mengeksekusi satu pernyataan dengan batas waktu -stmt-timeout.
+func execWithTimeout(ctx context.Context, db *sql.DB, query string) error {
+	if opts.stmtTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.stmtTimeout)
+		defer cancel()
+	}
+	_, err := db.ExecContext(ctx, query)
+	return err
+}
+
+// splitStatements memecah isi file SQL pada titik koma di luar string dan
+// nama berkutip, sehingga titik koma pada COMMENT kolom atau nama kolom
+// -naming original tidak memotong pernyataan.
+func splitStatements(content string) []string {
+	var statements []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == ';':
+			statements = append(statements, content[start:i])
+			start = i + 1
+		}
+	}
+	return append(statements, content[start:])
+}
+
+func executeSQLTableFile(ctx context.Context, db *sql.DB, path string) error {
+	msg1 := tr("Mulai memproses file %s", path)
+	logRun(msg1)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	statements := splitStatements(stripProvenance(string(content)))
+	for _, stmt := range statements {
+		trimmedStmt := strings.TrimSpace(stmt)
+		if trimmedStmt != "" {
+			err := execWithReconnect(ctx, db, trimmedStmt)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	msg2 := tr("Selesai memproses file %s", path)
+	logRun(msg2)
+	return nil
+}
+
+// selectedTables membatasi tabel yang dibuat dan dimuat, nil berarti semua tabel.
+var selectedTables map[string]bool
+
+func tableSelected(tableName string) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return selectedTables == nil || selectedTables[tableName]
+}
+
+func processSQLTableFiles(ctx context.Context, t *dbTarget, dir string) {
+	files, err := outputEntries(dir)
+	if err != nil {
+		logError(err, tr("Gagal membaca file SQL pembuatan tabel pada direktori"))
+		return
+	}
+	sortByLoadOrder(files, sqlTableName)
+	t.failedTables = nil
+
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return
+		}
+		if filepath.Ext(file.Name()) == ".sql" {
+			sqlFilePath := filepath.Join(dir, file.Name())
+			if !tableSelected(sqlTableName(file.Name())) {
+				continue
+			}
+			if opts.approval && !isApproved(sqlTableName(file.Name())) {
+				logRun(tr("Tabel %s belum disetujui, pembuatan tabel dilewati", sqlTableName(file.Name())))
+				continue
+			}
+			if runCheckpoint.isExecuted(t.name, sqlFilePath) {
+				logRun(tr("File %s sudah dieksekusi pada run sebelumnya, dilewati", file.Name()))
+				t.tablesOK++
+				continue
+			}
+
+			start := time.Now()
+			err := executeSQLTableFile(ctx, t.db, sqlFilePath)
+			duration := time.Since(start)
+
+			if err != nil {
+				errMsg := tr("Gagal mengeksekusi %s: %v", file.Name(), err)
+				logError(err, errMsg)
+				t.tablesFailed++
+				t.markFailed(sqlTableName(file.Name()))
+			} else {
+				t.tablesOK++
+				runCheckpoint.markExecuted(t.name, sqlFilePath)
+				currentReport.tableCreated(sqlTableName(file.Name()), t.name)
+			}
+
+			fmt.Println(tr("Selesai mengeksekusi %s dalam %s", file.Name(), duration))
+		}
+	}
+}
+
+// dbTarget adalah satu database tujuan beserta status pemuatannya sendiri.
+type dbTarget struct {
+	name     string
+	database string
+	db       *sql.DB
+
+	// lockConn memegang advisory lock GET_LOCK selama pemuatan, lihat -lock
+	lockConn *sql.Conn
+
+	// mu melindungi field di bawah ketika beberapa file dimuat bersamaan (-db-workers)
+	mu sync.Mutex
+
+	// batchRows adalah jumlah tuple per INSERT yang terakhir diterima server
+	// (0 berarti satu INSERT utuh seperti pada file). Nilainya diperkecil otomatis
+	// ketika server menolak batch karena melebihi max_allowed_packet.
+	batchRows int
+
+	statsReady bool
+
+	// governor membatasi batch yang dieksekusi bersamaan menurut beban server
+	// (-adaptive-workers), nil bila tidak dipakai
+	governor *loadGovernor
+
+	// failedTables berisi tabel yang gagal dibuat atau dimuat pada target ini,
+	// sehingga tabel yang bergantung padanya tidak dimuat
+	failedTables map[string]bool
+
+	tablesOK     int
+	tablesFailed int
+	filesOK      int
+	filesFailed  int
+}
+
+// openTargets membuka koneksi ke database utama dari db.cfg dan ke setiap
+// database tambahan yang file konfigurasinya (berformat sama dengan db.cfg)
+// disebutkan pada opsi -targets.
+func openTargets(dbConfig map[string]string) ([]*dbTarget, error) {
+	configs := []map[string]string{dbConfig}
+	for _, path := range strings.Split(opts.targets, ",") {
+		path = strings.TrimSpace(path)
+		if path == "" {
+			continue
+		}
+		config, err := readDBConfig(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		configs = append(configs, config)
+	}
+
+	var targets []*dbTarget
+	for _, config := range configs {
+		db, err := createDBConnection(config)
+		if err != nil {
+			closeTargets(targets)
+			return nil, err
+		}
+		name := fmt.Sprintf("%s@%s", config["database"], config["hostname"])
+		targets = append(targets, &dbTarget{name: name, database: config["database"], db: db})
+	}
+	return targets, nil
+}
+
+func (t *dbTarget) markFailed(tableName string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.failedTables == nil {
+		t.failedTables = make(map[string]bool)
+	}
+	t.failedTables[tableName] = true
+	notifyLoadFailure(t.name, tableName)
+}
+
+// dependencyFailed mengembalikan tabel dependensi tableName yang gagal pada target ini.
+func (t *dbTarget) dependencyFailed(tableName string) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return dependencyFailed(tableName, t.failedTables)
+}
+
+// countFile mencatat satu file data yang berhasil atau gagal dimuat.
+func (t *dbTarget) countFile(ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if ok {
+		t.filesOK++
+	} else {
+		t.filesFailed++
+	}
+}
+
+func closeTargets(targets []*dbTarget) {
+	for _, t := range targets {
+		if t.lockConn != nil {
+			// Lock juga dilepas server ketika koneksinya ditutup
+			t.lockConn.ExecContext(context.Background(), "DO RELEASE_LOCK(?)", t.lockName())
+			t.lockConn.Close()
+			t.lockConn = nil
+		}
+		if err := t.db.Close(); err != nil {
+			logError(err, tr("Gagal menutup koneksi ke database."))
+		}
+	}
+}
+
+func logTargetSummary(targets []*dbTarget) {
+	for _, t := range targets {
+		msg := tr("Target %s: tabel %d sukses, %d gagal; file data %d sukses, %d gagal", t.name, t.tablesOK, t.tablesFailed, t.filesOK, t.filesFailed)
+		logRun(msg)
+		fmt.Println(msg)
+	}
+}
+
+func isPacketTooLarge(err error) bool {
+	if errors.Is(err, mysql.ErrPktTooLarge) {
+		return true
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1153 {
+		return true
+	}
+	return strings.Contains(err.Error(), "max_allowed_packet")
+}
+
+// dataOrigin adalah asal tuple file data: sheet, baris header dan tipe kolom
+// dari statistik tabel (bila known) serta jumlah tuple sebelum tuple pertama
+// pernyataan yang sedang dieksekusi.
+type dataOrigin struct {
+	known      bool
+	sheet      string
+	firstRow   int
+	firstCol   int
+	headerRow  int
+	headerRows int
+	skipRows   int
+	noHeader   bool
+	types      map[string]string
+	offset     int
+}
+
+// rowRejection adalah kesalahan pemuatan satu sel: posisi sel sumber, kolom,
+// nilai dan saran perbaikannya untuk laporan run.
+type rowRejection struct {
+	position   string
+	column     string
+	value      string
+	suggestion *inference.Suggestion
+	err        error
+}
+
+func (e *rowRejection) Error() string {
+	msg := tr("%s kolom %s nilai %s", e.position, e.column, xlsx2sql.Excerpt(e.value))
+	if e.suggestion != nil {
+		msg += " (" + tr("saran: %s", e.suggestion) + ")"
+	}
+	return msg + ": " + e.err.Error()
+}
+
+func (e *rowRejection) Unwrap() error {
+	return e.err
+}
+
+// valueErrorPattern mengenali tipe kolom pada kesalahan MariaDB seperti
+// "Incorrect date value", dipakai bila tipe kolom tidak ada di statistik.
+var valueErrorPattern = regexp.MustCompile(`Incorrect (\w+) value`)
+
+// valueErrorTypes memetakan tipe pada valueErrorPattern ke tipe kolom.
+var valueErrorTypes = map[string]string{
+	"integer":  "BIGINT",
+	"decimal":  "DECIMAL",
+	"double":   "DOUBLE",
+	"date":     "DATE",
+	"datetime": "DATETIME",
+	"time":     "TIME",
+}
+
+// rowErrorPattern mengenali kolom dan nomor tuple pada kesalahan MariaDB,
+// misalnya "Data too long for column 'nama' at row 3" atau "Incorrect integer
+// value: 'x' for column `db`.`t`.`jumlah` at row 1".
+var rowErrorPattern = regexp.MustCompile(`for column (\S+) at row (\d+)`)
+
+// cellError menambahkan posisi sel sumber, nama kolom, kutipan nilai dan
+// saran perbaikan (inference.Suggest) ke kesalahan err dari batch
+// stmt.Rows[start:] sebagai *rowRejection. Tanpa statistik tabel, posisi
+// ditulis sebagai nomor baris data karena sheet dan baris header tidak
+// diketahui, dan tipe kolom ditebak dari pesan kesalahan.
+func cellError(err error, stmt loader.Statement, start int, origin dataOrigin) error {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return err
+	}
+	m := rowErrorPattern.FindStringSubmatch(mysqlErr.Message)
+	if m == nil {
+		return err
+	}
+	column := m[1]
+	if i := strings.LastIndexByte(column, '.'); i >= 0 {
+		column = column[i+1:]
+	}
+	column = strings.Trim(column, "`'\"")
+	n, _ := strconv.Atoi(m[2])
+	if n < 1 || start+n > len(stmt.Rows) {
+		return err
+	}
+	index := -1
+	for i, name := range stmt.Columns {
+		if strings.EqualFold(strings.Trim(name, "`"), column) {
+			index = i
+		}
+	}
+	if index < 0 {
+		return err
+	}
+	value := ""
+	if literals := loader.TupleLiterals(stmt.Rows[start+n-1]); index < len(literals) {
+		value = strings.TrimSuffix(strings.TrimPrefix(literals[index], "'"), "'")
+	}
+	row := origin.offset + start + n
+	position := tr("baris data %d", row)
+	// Kolom tenant tidak ada pada input sehingga kolom sumber bergeser satu
+	source := index + 1
+	if stmt.Columns[0] == tableNaming().TenantColumn() {
+		source = index
+	}
+	if origin.known && source > 0 {
+		header := max(origin.headerRows, 1)
+		if origin.noHeader {
+			header = 0
+		}
+		position = xlsx2sql.CellRef(origin.sheet, max(origin.firstCol, 1)-1+source, max(origin.firstRow, 1)-1+max(origin.headerRow, 1)+header-1+origin.skipRows+row)
+	}
+	rejection := &rowRejection{position: position, column: column, value: value, err: err}
+	columnType, ok := origin.types[column]
+	if !ok {
+		if m := valueErrorPattern.FindStringSubmatch(mysqlErr.Message); m != nil {
+			columnType = valueErrorTypes[strings.ToLower(m[1])]
+		}
+	}
+	if suggestion, ok := inference.Suggest(value, columnType); ok {
+		rejection.suggestion = &suggestion
+	}
+	return rejection
+}
+
+// executeInsertStatement mengeksekusi tuple-tuple stmt per batch. Bila batch
+// ditolak karena paket terlalu besar, ukuran batch dibagi dua dan batch yang
+// sama dicoba ulang.
+func executeInsertStatement(ctx context.Context, t *dbTarget, stmt loader.Statement, origin dataOrigin, progress func(rows int)) error {
+	for start := 0; start < len(stmt.Rows); {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		size := len(stmt.Rows) - start
+		t.mu.Lock()
+		if t.batchRows > 0 && t.batchRows < size {
+			size = t.batchRows
+		}
+		t.mu.Unlock()
+
+		query := stmt.Prefix + "\n" + strings.Join(stmt.Rows[start:start+size], ",\n")
+		if err := t.governor.acquire(ctx); err != nil {
+			return err
+		}
+		err := execWithReconnect(ctx, t.db, query)
+		t.governor.release()
+		if err != nil {
+			if isPacketTooLarge(err) && size > 1 {
+				t.mu.Lock()
+				t.batchRows = size / 2
+				t.mu.Unlock()
+				logRun(tr("Paket terlalu besar untuk %d baris, ukuran batch diturunkan menjadi %d baris", size, size/2))
+				continue
+			}
+			return cellError(err, stmt, start, origin)
+		}
+		progress(size)
+		start += size
+	}
+	return nil
+}
+
+// loadChunked membagi tuple statements menjadi -load-chunks potongan berurutan
+// yang tidak beririsan dan memuat setiap potongan bersamaan lewat koneksinya
+// sendiri dari pool t.db. Setiap tuple dimuat tepat sekali sehingga kunci
+// unik tidak dilanggar oleh pembagian ini, tetapi urutan nilai kolom id
+// AUTO_INCREMENT tidak lagi mengikuti urutan baris file. Kegagalan satu
+// potongan menghentikan potongan lain. resumed berisi tuple yang sudah dimuat
+// per potongan pada run sebelumnya; jumlah potongannya dipakai lagi agar
+// pembagiannya sama.
+func loadChunked(ctx context.Context, t *dbTarget, filePath string, statements []loader.Statement, origin dataOrigin, resumed []int, loaded func(rows int)) error {
+	chunks := opts.loadChunks
+	if resumed != nil {
+		chunks = len(resumed)
+		logRun(tr("Melanjutkan %s dalam %d potongan", filePath, chunks))
+	} else {
+		resumed = make([]int, chunks)
+	}
+	total := 0
+	for _, stmt := range statements {
+		total += len(stmt.Rows)
+	}
+	size := (total + chunks - 1) / chunks
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	var errMu sync.Mutex
+	var firstErr error
+	for i := 0; i < chunks; i++ {
+		start, end := i*size+resumed[i], min((i+1)*size, total)
+		if start >= end {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			progress := func(rows int) {
+				runCheckpoint.addChunkRows(t.name, filePath, i, chunks, rows)
+				loaded(rows)
+			}
+			if err := loadTupleRange(ctx, t, statements, origin, start, end, progress); err != nil {
+				errMu.Lock()
+				if firstErr == nil || errors.Is(firstErr, context.Canceled) {
+					firstErr = err
+				}
+				errMu.Unlock()
+				cancel()
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// loadTupleRange memuat tuple ke-start sampai sebelum ke-end dari seluruh
+// statements.
+func loadTupleRange(ctx context.Context, t *dbTarget, statements []loader.Statement, origin dataOrigin, start, end int, progress func(rows int)) error {
+	offset := 0
+	for _, stmt := range statements {
+		from, to := max(start-offset, 0), min(end-offset, len(stmt.Rows))
+		if from < to {
+			part := stmt
+			part.Rows = stmt.Rows[from:to]
+			partOrigin := origin
+			partOrigin.offset = origin.offset + offset + from
+			if err := executeInsertStatement(ctx, t, part, partOrigin, progress); err != nil {
+				return err
+			}
+		}
+		offset += len(stmt.Rows)
+		if offset >= end {
+			break
+		}
+	}
+	return nil
+}
+
+// sampleCondition membuat kondisi WHERE untuk mencari kembali satu tuple.
+// Literal pecahan dilewati karena nilai FLOAT/DOUBLE tidak dapat dibandingkan persis.
+func sampleCondition(columns []string, row string) string {
+	var conditions []string
+	for i, literal := range loader.TupleLiterals(row) {
+		if i >= len(columns) {
+			break
+		}
+		switch {
+		case strings.EqualFold(literal, "NULL"):
+			conditions = append(conditions, columns[i]+" IS NULL")
+		case literal[0] != '\'' && strings.ContainsAny(literal, ".eE"):
+		default:
+			conditions = append(conditions, columns[i]+" = "+literal)
+		}
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// verifyTable membandingkan jumlah baris tabel dengan jumlah tuple pada file
+// data, lalu memeriksa apakah beberapa tuple sampel benar-benar ada di tabel.
+func verifyTable(ctx context.Context, db *sql.DB, tableName string, statements []loader.Statement) (expected, actual, matched, sampled int, err error) {
+	var rows []string
+	var columns []string
+	for _, stmt := range statements {
+		rows = append(rows, stmt.Rows...)
+		columns = stmt.Columns
+	}
+	expected = len(rows)
+
+	if err = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+tableName).Scan(&actual); err != nil {
+		return
+	}
+
+	samples := opts.verifySamples
+	if samples > len(rows) {
+		samples = len(rows)
+	}
+	for i := 0; i < samples; i++ {
+		index := 0
+		if samples > 1 {
+			index = i * (len(rows) - 1) / (samples - 1)
+		}
+		condition := sampleCondition(columns, rows[index])
+		if condition == "" {
+			continue
+		}
+		var count int
+		if err = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+tableName+" WHERE "+condition).Scan(&count); err != nil {
+			return
+		}
+		sampled++
+		if count > 0 {
+			matched++
+		}
+	}
+	return
+}
+
+// verifyLoad menjalankan verifikasi untuk setiap file data pada direktori SQLData.
+// Jumlah baris dianggap cocok hanya bila tabel berisi tepat sebanyak tuple pada
+// file, sehingga tabel yang sudah berisi data sebelumnya akan dilaporkan berbeda.
+func verifyLoad(ctx context.Context, db *sql.DB, label string) {
+	files, err := outputEntries(opts.sqlDataDir)
+	if err != nil {
+		logError(err, tr("Gagal membaca direktori SQLData: %v", err))
+		return
+	}
+
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return
+		}
+		if file.IsDir() || !isDataFile(file.Name()) {
+			continue
+		}
+		content, err := readDataFile(filepath.Join(opts.sqlDataDir, file.Name()))
+		if err != nil {
+			logError(err, tr("Gagal membaca file %s: %v", file.Name(), err))
+			continue
+		}
+		tableName := dataFileTable(file.Name())
+		statements, err := loader.Parse(string(content), tableName)
+		if err != nil {
+			logError(err, tr("Verifikasi %s pada %s dilewati, file data tidak dapat diurai", tableName, label))
+			continue
+		}
+
+		expected, actual, matched, sampled, err := verifyTable(ctx, db, tableName, statements)
+		if err != nil {
+			logError(err, tr("Verifikasi %s pada %s gagal", tableName, label))
+			continue
+		}
+		msg := tr("Verifikasi %s pada %s: %d baris diharapkan, %d baris ditemukan, %d dari %d sampel cocok", tableName, label, expected, actual, matched, sampled)
+		if expected != actual || matched != sampled {
+			logError(errors.New(msg), tr("Verifikasi tidak cocok"))
+			continue
+		}
+		logRun(msg)
+		fmt.Println(msg)
+	}
+}
+
+// waitForReplica menunggu replika menerapkan semua transaksi yang sudah ada di
+// primary, berdasarkan posisi GTID MariaDB.
+func waitForReplica(ctx context.Context, primary, replica *sql.DB) error {
+	var pos string
+	if err := primary.QueryRowContext(ctx, "SELECT @@gtid_binlog_pos").Scan(&pos); err != nil {
+		return err
+	}
+
+	var result sql.NullInt64
+	if err := replica.QueryRowContext(ctx, "SELECT MASTER_GTID_WAIT(?, ?)", pos, opts.replicaWait.Seconds()).Scan(&result); err != nil {
+		return err
+	}
+	if !result.Valid || result.Int64 != 0 {
+		return errors.New(tr("replika belum menyusul posisi GTID %s dalam %v", pos, opts.replicaWait))
+	}
+	return nil
+}
+
+// Batas -index-advisor: kolom dengan selektivitas (nilai berbeda per baris)
+// minimal minIndexSelectivity disarankan diindeks, paling banyak
+// maxAdvisedIndexes per tabel, sedangkan indeks bawaan pada kolom dengan
+// selektivitas di bawah maxDropSelectivity disarankan dihapus.
+const (
+	minIndexSelectivity = 0.05
+	maxDropSelectivity  = 0.01
+	maxAdvisedIndexes   = 3
+)
+
+// indexAdvice adalah satu saran indeks: pernyataan ALTER TABLE beserta
+// alasannya sebagai komentar.
+type indexAdvice struct {
+	reason    string
+	statement string
+}
+
+// adviseIndexes menganalisis kardinalitas kolom setiap tabel file SQLData
+// pada target pertama, menulis sarannya ke -index-advisor dan, dengan
+// -index-apply, menjalankannya pada setiap target.
+func adviseIndexes(ctx context.Context, targets []*dbTarget) {
+	files, err := outputEntries(opts.sqlDataDir)
+	if err != nil {
+		logError(err, tr("Gagal membaca direktori SQLData: %v", err))
+		return
+	}
+	t := targets[0]
+	var advice []indexAdvice
+	var b strings.Builder
+	fmt.Fprintf(&b, "-- Index advice by xlsx2mariadb %s\n", version)
+	fmt.Fprintf(&b, "-- Database: %s\n", provenanceValue(t.name))
+	fmt.Fprintf(&b, "-- Generated at: %s\n", time.Now().UTC().Format(time.RFC3339))
+	seen := make(map[string]bool)
+	for _, file := range files {
+		if ctx.Err() != nil {
+			return
+		}
+		tableName := dataFileTable(file.Name())
+		if file.IsDir() || !isDataFile(file.Name()) || seen[tableName] || !tableSelected(tableName) || t.failedTables[tableName] {
+			continue
+		}
+		seen[tableName] = true
+		tableAdvice, err := adviseTableIndexes(ctx, t.db, ddl.Unquote(tableName))
+		if err != nil {
+			logError(err, tr("Gagal menganalisis kardinalitas kolom tabel %s", tableName))
+			continue
+		}
+		for _, a := range tableAdvice {
+			fmt.Fprintf(&b, "\n-- %s\n%s\n", a.reason, a.statement)
+		}
+		advice = append(advice, tableAdvice...)
+	}
+
+	path := workPath(opts.indexAdvisor)
+	if err := writeFileAtomic(path, b.String()); err != nil {
+		logError(err, tr("Gagal menulis saran indeks ke %s", path))
+		return
+	}
+	msg := tr("%d saran indeks ditulis ke %s", len(advice), path)
+	logRun(msg)
+	fmt.Println(msg)
+	if !opts.indexApply {
+		return
+	}
+	for _, t := range targets {
+		for _, a := range advice {
+			if err := execWithReconnect(ctx, t.db, a.statement); err != nil {
+				logError(err, tr("Gagal menjalankan saran indeks pada %s: %s", t.name, a.statement))
+				continue
+			}
+			logRun(tr("Saran indeks dijalankan pada %s: %s", t.name, a.statement))
+		}
+	}
+}
+
+// adviseTableIndexes menghitung nilai berbeda setiap kolom tabel yang dapat
+// diindeks pada -index-sample baris pertamanya, lalu menyarankan indeks untuk
+// kolom paling selektif yang belum menjadi kolom pertama suatu indeks dan
+// penghapusan indeks bawaan (nama dari -naming) pada kolom yang hampir tidak
+// selektif.
+func adviseTableIndexes(ctx context.Context, db *sql.DB, table string) ([]indexAdvice, error) {
+	rows, err := db.QueryContext(ctx, "SELECT COLUMN_NAME, DATA_TYPE, COLUMN_KEY FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? ORDER BY ORDINAL_POSITION", table)
+	if err != nil {
+		return nil, err
+	}
+	var columns []string
+	for rows.Next() {
+		var name, dataType, key string
+		if err := rows.Scan(&name, &dataType, &key); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		switch strings.ToLower(dataType) {
+		case "tinytext", "text", "mediumtext", "longtext", "tinyblob", "blob", "mediumblob", "longblob", "json", "geometry":
+			continue
+		}
+		if key != "PRI" {
+			columns = append(columns, name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	// leading memetakan kolom pertama setiap indeks ke nama indeksnya
+	leading := make(map[string][]string)
+	rows, err = db.QueryContext(ctx, "SELECT INDEX_NAME, COLUMN_NAME FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND SEQ_IN_INDEX = 1", table)
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var index, column string
+		if err := rows.Scan(&index, &column); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		leading[column] = append(leading[column], index)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	quoted := make([]string, len(columns))
+	counts := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = sqlName(column)
+		counts[i] = "COUNT(DISTINCT " + quoted[i] + ")"
+	}
+	source := sqlName(table)
+	if opts.indexSample > 0 {
+		source = fmt.Sprintf("(SELECT %s FROM %s LIMIT %d) AS sample", strings.Join(quoted, ", "), sqlName(table), opts.indexSample)
+	}
+	total := 0
+	distinct := make([]int, len(columns))
+	dest := []interface{}{&total}
+	for i := range distinct {
+		dest = append(dest, &distinct[i])
+	}
+	query := fmt.Sprintf("SELECT COUNT(*), %s FROM %s", strings.Join(counts, ", "), source)
+	if err := db.QueryRowContext(ctx, query).Scan(dest...); err != nil {
+		return nil, err
+	}
+	if total == 0 {
+		return nil, nil
+	}
+
+	type candidate struct {
+		column      string
+		distinct    int
+		selectivity float64
+	}
+	var candidates []candidate
+	var advice []indexAdvice
+	for i, column := range columns {
+		c := candidate{column: column, distinct: distinct[i], selectivity: float64(distinct[i]) / float64(total)}
+		reason := fmt.Sprintf("%s.%s: %d distinct values in %d sampled rows (selectivity %.2f)", table, column, c.distinct, total, c.selectivity)
+		if indexes, ok := leading[column]; ok {
+			own := ddl.Unquote(tableNaming().Index(column))
+			if c.selectivity < maxDropSelectivity && slices.Contains(indexes, own) {
+				advice = append(advice, indexAdvice{
+					reason:    reason + ", index does not help lookups",
+					statement: fmt.Sprintf("ALTER TABLE %s DROP INDEX %s;", sqlName(table), sqlName(own)),
+				})
+			}
+			continue
+		}
+		if c.distinct >= 2 && c.selectivity >= minIndexSelectivity {
+			candidates = append(candidates, c)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].selectivity > candidates[j].selectivity })
+	for _, c := range candidates[:min(len(candidates), maxAdvisedIndexes)] {
+		reason := fmt.Sprintf("%s.%s: %d distinct values in %d sampled rows (selectivity %.2f)", table, c.column, c.distinct, total, c.selectivity)
+		if c.distinct == total {
+			reason += ", unique in sample (UNIQUE candidate)"
+		}
+		advice = append(advice, indexAdvice{
+			reason:    reason,
+			statement: fmt.Sprintf("ALTER TABLE %s ADD INDEX %s (%s);", sqlName(table), sqlName(ddl.Unquote(tableNaming().Index(c.column))), sqlName(c.column)),
+		})
+	}
+	return advice, nil
+}
+
+// sqlName mengutip name dengan backtick bila bukan identifier biasa.
+func sqlName(name string) string {
+	if validIdentifier.MatchString(name) {
+		return name
+	}
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// verifyTargets menjalankan verifikasi setelah pemuatan, terhadap replika bila
+// opsi -replica diisi, atau terhadap setiap database tujuan.
+func verifyTargets(ctx context.Context, targets []*dbTarget) {
+	if opts.replica == "" {
+		for _, t := range targets {
+			verifyLoad(ctx, t.db, t.name)
+		}
+		return
+	}
+
+	config, err := readDBConfig(opts.replica)
+	if err != nil {
+		logError(err, tr("Gagal membaca file konfigurasi replika %s", opts.replica))
+		return
+	}
+	replica, err := createDBConnection(config)
+	if err != nil {
+		logError(err, tr("Gagal membuat koneksi ke replika"))
+		return
+	}
+	defer replica.Close()
+
+	logRun(tr("Menunggu replika menyusul primary"))
+	if err := waitForReplica(ctx, targets[0].db, replica); err != nil {
+		logError(err, tr("Replika tidak menyusul, verifikasi tetap dijalankan"))
+	}
+	verifyLoad(ctx, replica, fmt.Sprintf("%s@%s (replika)", config["database"], config["hostname"]))
+}
+
+func processSQLDataFiles(ctx context.Context, t *dbTarget) {
+	// Membaca semua file di direktori SQLData
+	files, err := outputEntries(opts.sqlDataDir)
+	if err != nil {
+		errMsg := tr("Gagal membaca direktori SQLData: %v", err)
+		logError(err, errMsg)
+		log.Fatal(errMsg)
+	}
+	sortByLoadOrder(files, dataFileTable)
+
+	// File dimuat per level dependensi: paling banyak -db-workers file satu
+	// level dimuat bersamaan, dan level berikutnya menunggu level sebelumnya
+	// selesai agar tabel dependensi sudah terisi.
+	levels := make(map[string]int)
+	workerCount := max(opts.dbWorkers, 1)
+	if opts.adaptiveWorkers > 0 {
+		// Dengan -adaptive-workers jumlah file yang dimuat bersamaan mengikuti
+		// batas atasnya, sedangkan batch yang dieksekusi dibatasi governor
+		t.governor = newLoadGovernor(min(workerCount, opts.adaptiveWorkers), opts.adaptiveWorkers)
+		logRun(tr("Konkurensi pemuatan %s diatur otomatis antara 1 dan %d, mulai dari %d", t.name, t.governor.max, t.governor.limit))
+		monitorCtx, stop := context.WithCancel(ctx)
+		defer stop()
+		go t.governor.monitor(monitorCtx, t)
+		workerCount = max(workerCount, opts.adaptiveWorkers)
+	}
+	workers := make(chan struct{}, workerCount)
+	var loads sync.WaitGroup
+	level := -1
+	for _, file := range files {
+		if ctx.Err() != nil {
+			break
+		}
+		if file.IsDir() || !isDataFile(file.Name()) {
+			continue
+		}
+		if l := tableLevel(dataFileTable(file.Name()), levels); l != level {
+			loads.Wait()
+			level = l
+		}
+		workers <- struct{}{}
+		loads.Add(1)
+		go func() {
+			defer loads.Done()
+			defer func() { <-workers }()
+			loadDataFile(ctx, t, file)
+		}()
+	}
+	loads.Wait()
+}
+
+// loadGovernor membatasi jumlah batch yang dieksekusi bersamaan pada satu
+// target. Batasnya diturunkan setengah bila salah satu indikator beban server
+// melewati batasnya dan dinaikkan satu per pemeriksaan bila semua indikator di
+// bawah adaptiveHeadroom dari batasnya, seperti pengendalian kongesti TCP.
+type loadGovernor struct {
+	mu     sync.Mutex
+	limit  int
+	max    int
+	active int
+	// wake ditutup dan diganti setiap kali slot mungkin tersedia
+	wake chan struct{}
+	// warned berisi indikator yang gagal dibaca dan sudah dicatat
+	warned map[string]bool
+}
+
+// adaptiveHeadroom adalah bagian batas indikator yang harus tersisa sebelum
+// loadGovernor menaikkan batasnya, agar batas tidak naik turun terus.
+const adaptiveHeadroom = 0.75
+
+func newLoadGovernor(limit, upper int) *loadGovernor {
+	return &loadGovernor{limit: max(limit, 1), max: upper, wake: make(chan struct{}), warned: make(map[string]bool)}
+}
+
+// acquire menunggu slot eksekusi. Governor nil tidak membatasi apa pun.
+func (g *loadGovernor) acquire(ctx context.Context) error {
+	if g == nil {
+		return nil
+	}
+	for {
+		g.mu.Lock()
+		if g.active < g.limit {
+			g.active++
+			g.mu.Unlock()
+			return nil
+		}
+		wake := g.wake
+		g.mu.Unlock()
+		select {
+		case <-wake:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (g *loadGovernor) release() {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	g.active--
+	g.wakeLocked()
+	g.mu.Unlock()
+}
+
+func (g *loadGovernor) wakeLocked() {
+	close(g.wake)
+	g.wake = make(chan struct{})
+}
+
+// setLimit mengubah batas dan mengembalikan batas sebelumnya.
+func (g *loadGovernor) setLimit(limit int) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	prev := g.limit
+	g.limit = min(max(limit, 1), g.max)
+	g.wakeLocked()
+	return prev
+}
+
+// monitor memeriksa beban server target t setiap -load-check-interval dan
+// mengubah batas g sampai ctx selesai. Lag replika dibaca dari -replica bila
+// diisi.
+func (g *loadGovernor) monitor(ctx context.Context, t *dbTarget) {
+	var replica *sql.DB
+	if opts.replica != "" {
+		config, err := readDBConfig(opts.replica)
+		if err == nil {
+			replica, err = createDBConnection(config)
+		}
+		if err != nil {
+			logError(err, tr("Indikator beban %s tidak dapat dibaca dari %s, diabaikan", "Seconds_Behind_Master", opts.replica))
+		} else {
+			defer replica.Close()
+		}
+	}
+
+	ticker := time.NewTicker(opts.loadCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		load := g.readLoad(ctx, t, replica)
+		g.mu.Lock()
+		limit := g.limit
+		g.mu.Unlock()
+		if reason := load.exceeds(1); reason != "" {
+			if limit > 1 {
+				g.setLimit(limit / 2)
+				logRun(tr("Konkurensi pemuatan %s diturunkan dari %d menjadi %d: %s", t.name, limit, max(limit/2, 1), reason))
+			}
+		} else if load.exceeds(adaptiveHeadroom) == "" && limit < g.max {
+			g.setLimit(limit + 1)
+			logRun(tr("Konkurensi pemuatan %s dinaikkan dari %d menjadi %d", t.name, limit, limit+1))
+		}
+	}
+}
+
+// serverLoad berisi indikator beban server; nilai negatif berarti indikator
+// tidak tersedia.
+type serverLoad struct {
+	threadsRunning int64
+	replicaLag     time.Duration
+	historyLength  int64
+}
+
+// exceeds mengembalikan indikator pertama yang melewati scale kali batasnya,
+// atau string kosong.
+func (l serverLoad) exceeds(scale float64) string {
+	switch {
+	case l.threadsRunning >= 0 && float64(l.threadsRunning) > scale*float64(opts.maxThreadsRunning):
+		return fmt.Sprintf("Threads_running %d > %d", l.threadsRunning, opts.maxThreadsRunning)
+	case l.replicaLag >= 0 && float64(l.replicaLag) > scale*float64(opts.maxReplicaLag):
+		return fmt.Sprintf("Seconds_Behind_Master %v > %v", l.replicaLag, opts.maxReplicaLag)
+	case l.historyLength >= 0 && float64(l.historyLength) > scale*float64(opts.maxHistoryLength):
+		return fmt.Sprintf("trx_rseg_history_len %d > %d", l.historyLength, opts.maxHistoryLength)
+	}
+	return ""
+}
+
+// readLoad membaca indikator beban dari target t dan replica (boleh nil).
+// Indikator yang gagal dibaca dicatat sekali lalu diabaikan.
+func (g *loadGovernor) readLoad(ctx context.Context, t *dbTarget, replica *sql.DB) serverLoad {
+	load := serverLoad{threadsRunning: -1, replicaLag: -1, historyLength: -1}
+	var name string
+	if err := t.db.QueryRowContext(ctx, "SHOW GLOBAL STATUS LIKE 'Threads_running'").Scan(&name, &load.threadsRunning); err != nil {
+		g.warn(err, "Threads_running", t.name)
+	}
+	if err := t.db.QueryRowContext(ctx, "SELECT `COUNT` FROM information_schema.INNODB_METRICS WHERE NAME = 'trx_rseg_history_len'").Scan(&load.historyLength); err != nil {
+		load.historyLength = -1
+		g.warn(err, "trx_rseg_history_len", t.name)
+	}
+	if replica != nil {
+		lag, err := replicaLag(ctx, replica)
+		if err != nil {
+			g.warn(err, "Seconds_Behind_Master", opts.replica)
+		}
+		load.replicaLag = lag
+	}
+	return load
+}
+
+func (g *loadGovernor) warn(err error, indicator, source string) {
+	if err == nil || g.warned[indicator] || errors.Is(err, context.Canceled) {
+		return
+	}
+	g.warned[indicator] = true
+	logError(err, tr("Indikator beban %s tidak dapat dibaca dari %s, diabaikan", indicator, source))
+}
+
+// replicaLag membaca Seconds_Behind_Master dari SHOW SLAVE STATUS. Replikasi
+// yang tidak berjalan (nilai NULL) dianggap tidak tersedia, bernilai -1.
+func replicaLag(ctx context.Context, replica *sql.DB) (time.Duration, error) {
+	rows, err := replica.QueryContext(ctx, "SHOW SLAVE STATUS")
+	if err != nil {
+		return -1, err
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return -1, err
+	}
+	if !rows.Next() {
+		return -1, rows.Err()
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return -1, err
+	}
+	for i, column := range columns {
+		if column != "Seconds_Behind_Master" || !values[i].Valid {
+			continue
+		}
+		seconds, err := strconv.ParseInt(values[i].String, 10, 64)
+		if err != nil {
+			return -1, err
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return -1, nil
+}
+
+// loadDataFile memuat satu file SQLData ke target t.
+func loadDataFile(ctx context.Context, t *dbTarget, file os.DirEntry) {
+	// Membaca konten file SQL
+	filePath := filepath.Join(opts.sqlDataDir, file.Name())
+	if runCheckpoint.isExecuted(t.name, filePath) {
+		logRun(tr("File %s sudah dieksekusi pada run sebelumnya, dilewati", file.Name()))
+		t.countFile(true)
+		return
+	}
+	// File teks berbatas (-columnstore) dimuat langsung oleh server
+	delimited := dataFileExt(file.Name()) == ".tbl"
+	var sqlContent []byte
+	var err error
+	if !delimited {
+		sqlContent, err = readDataFile(filePath)
+	}
+	if err != nil {
+		errMsg := tr("Gagal membaca file %s: %v", file.Name(), err)
+		logError(err, errMsg)
+		log.Print(errMsg)
+		t.countFile(false)
+		t.markFailed(dataFileTable(file.Name()))
+		return
+	}
+
+	tableName := dataFileTable(file.Name())
+	if !tableSelected(tableName) {
+		return
+	}
+	if dep, failed := t.dependencyFailed(tableName); failed {
+		logRun(tr("Pemuatan %s ke %s ditahan karena tabel dependensi %s gagal dimuat", file.Name(), t.name, dep))
+		t.countFile(false)
+		t.markFailed(tableName)
+		return
+	}
+	if opts.approval && !isApproved(tableName) {
+		logRun(tr("Tabel %s belum disetujui, pemuatan data dilewati", tableName))
+		return
+	}
+	var statements []loader.Statement
+	var parseErr error
+	if !delimited {
+		statements, parseErr = loader.Parse(string(sqlContent), tableName)
+	}
+	if opts.audit && parseErr != nil {
+		errMsg := tr("Audit file %s gagal, file tidak dieksekusi: %v", file.Name(), parseErr)
+		logError(parseErr, errMsg)
+		log.Print(errMsg)
+		t.countFile(false)
+		t.markFailed(tableName)
+		return
+	}
+
+	var stats tableStats
+	haveStats := false
+	if statsEnabled() {
+		if stats, err = readTableStats(statsFilePath(filePath)); err != nil {
+			logError(err, tr("Gagal membaca statistik tabel %s", tableName))
+		} else {
+			haveStats = true
+		}
+	}
+	if opts.anomalyThreshold > 0 && haveStats {
+		anomalies, err := detectAnomalies(ctx, t.db, stats)
+		if err != nil {
+			logError(err, tr("Gagal memeriksa anomali untuk %s", tableName))
+		}
+		if len(anomalies) > 0 {
+			for _, anomaly := range anomalies {
+				logError(errors.New(anomaly), tr("Anomali terdeteksi pada %s (target %s)", stats.Source, t.name))
+			}
+			if opts.anomalyHold && !askContinue(tr("Data %s menyimpang dari impor sebelumnya. Tetap muat ke %s? (Ya/Tidak, default Ya): ", stats.Source, t.name)) {
+				logRun(tr("Pemuatan %s ke %s ditahan karena anomali", file.Name(), t.name))
+				t.countFile(false)
+				t.markFailed(tableName)
+				return
+			}
+		}
+	}
+	if opts.drift != "off" && haveStats && !checkColumnDrift(ctx, t, tableName, stats) {
+		logRun(tr("Pemuatan %s ke %s ditahan karena perubahan kolom", file.Name(), t.name))
+		t.countFile(false)
+		t.markFailed(tableName)
+		return
+	}
+
+	// Memastikan koneksi masih hidup setelah jeda di antara file
+	if err := t.db.PingContext(ctx); err != nil {
+		logError(err, tr("Ping ke database gagal, koneksi akan dibuka ulang saat eksekusi"))
+	}
+
+	// Mengeksekusi konten file SQL
+	start := time.Now()
+	if delimited {
+		var rows int64
+		if err = t.governor.acquire(ctx); err == nil {
+			rows, err = loadDelimitedFile(ctx, t.db, filePath, tableName)
+			t.governor.release()
+		}
+		if err == nil {
+			runMetrics.addRowsInserted(int(rows))
+			currentReport.rowsLoaded(tableName, t.name, int(rows))
+		}
+	} else if parseErr != nil {
+		// File tidak dapat dipecah per tuple, eksekusi apa adanya
+		if err = t.governor.acquire(ctx); err == nil {
+			err = execWithReconnect(ctx, t.db, string(sqlContent))
+			t.governor.release()
+		}
+	} else {
+		// Tuple yang sudah dimuat sebelum program terhenti tidak dimuat ulang
+		skip := runCheckpoint.loadedRows(t.name, filePath)
+		if skip > 0 {
+			logRun(tr("Melanjutkan %s dari tuple ke-%d", file.Name(), skip+1))
+		}
+		loaded := func(rows int) {
+			runMetrics.addRowsInserted(rows)
+			emitProgress("rows_inserted", map[string]interface{}{"target": t.name, "file": filePath, "rows": rows})
+			currentReport.rowsLoaded(tableName, t.name, rows)
+		}
+		progress := func(rows int) {
+			runCheckpoint.addLoadedRows(t.name, filePath, rows)
+			loaded(rows)
+		}
+		origin := dataOrigin{known: haveStats, sheet: stats.Sheet, firstRow: stats.FirstRow, firstCol: stats.FirstColumn, headerRow: stats.HeaderRow, headerRows: stats.HeaderRows, skipRows: stats.SkipRows, noHeader: stats.NoHeader, types: make(map[string]string)}
+		for _, column := range stats.Columns {
+			origin.types[column.Name] = column.Type
+		}
+		// File yang sebagian sudah dimuat tanpa potongan dilanjutkan tanpa
+		// potongan, dan sebaliknya
+		chunks := runCheckpoint.loadedChunks(t.name, filePath)
+		if skip == 0 && (opts.loadChunks > 1 || chunks != nil) {
+			err = loadChunked(ctx, t, filePath, statements, origin, chunks, loaded)
+		} else {
+			for _, stmt := range statements {
+				if skip >= len(stmt.Rows) {
+					skip -= len(stmt.Rows)
+					origin.offset += len(stmt.Rows)
+					continue
+				}
+				stmt.Rows = stmt.Rows[skip:]
+				origin.offset += skip
+				skip = 0
+				if err = executeInsertStatement(ctx, t, stmt, origin, progress); err != nil {
+					break
+				}
+				origin.offset += len(stmt.Rows)
+			}
+		}
+	}
+	if err != nil {
+		var rejection *rowRejection
+		if errors.As(err, &rejection) {
+			currentReport.rowRejected(tableName, t.name, rejection)
+		}
+		errMsg := tr("Gagal mengeksekusi file %s: %v", file.Name(), err)
+		logError(err, errMsg)
+		log.Print(errMsg)
+		t.countFile(false)
+		t.markFailed(tableName)
+		return
+	}
+	t.countFile(true)
+	runCheckpoint.markExecuted(t.name, filePath)
+	if haveStats {
+		if err := recordImportStats(ctx, t, stats); err != nil {
+			logError(err, tr("Gagal mencatat statistik impor untuk %s", tableName))
+		}
+	}
+	duration := time.Since(start)
+	rMsg := tr("Sukses mengeksekusi file %s dalam waktu %s", file.Name(), duration)
+	logRun(rMsg)
+	log.Print(rMsg)
+}
+
+// loadDelimitedFile memuat file teks berbatas dari DelimitedWriter ke tableName
+// dengan LOAD DATA LOCAL INFILE, yang pada tabel ColumnStore diteruskan server
+// ke cpimport. Pernyataan tidak diulang saat koneksi terputus agar baris tidak
+// dimuat dua kali.
+func loadDelimitedFile(ctx context.Context, db *sql.DB, path, tableName string) (int64, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return 0, err
+	}
+	mysql.RegisterLocalFile(abs)
+	defer mysql.DeregisterLocalFile(abs)
+
+	if opts.stmtTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.stmtTimeout)
+		defer cancel()
+	}
+	query := fmt.Sprintf(`LOAD DATA LOCAL INFILE '%s' INTO TABLE %s FIELDS TERMINATED BY '|' OPTIONALLY ENCLOSED BY '"' ESCAPED BY '\\' LINES TERMINATED BY '\n'`,
+		writer.EscapeString(abs), tableName)
+	result, err := db.ExecContext(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// sheetDimension adalah jumlah baris dan kolom sheet aktif menurut metadata
+// dimensi file xlsx, tanpa membaca isi sel.
+type sheetDimension struct {
+	rows, cols int
+}
+
+func (d sheetDimension) cells() int64 {
+	return int64(d.rows) * int64(d.cols)
+}
+
+// Hasil pre-scan run yang sedang berjalan. Bila prescanTotalCells lebih dari nol,
+// persentase kemajuan dihitung dari jumlah sel, bukan jumlah file.
+var (
+	prescanDims       map[string]sheetDimension
+	prescanTotalCells int64
+	processedCells    int64
+)
+
+func scanSheetDimension(path string) (sheetDimension, error) {
+	if isODSFile(path) || isXLSFile(path) || isJSONFile(path) || isParquetFile(path) || isXMLFile(path) {
+		return scanRowDimension(path)
+	}
+	xlsx, err := excelize.OpenFile(path, excelizeOptions(path))
+	if err != nil {
+		return sheetDimension{}, err
+	}
+	defer xlsx.Close()
+
+	ref, err := xlsx.GetSheetDimension(xlsx.GetSheetName(xlsx.GetActiveSheetIndex()))
+	if err != nil {
+		return sheetDimension{}, err
+	}
+	// Contoh ref: "A1:D100". Sheet kosong atau tanpa metadata dimensi hanya "A1".
+	cells := strings.Split(ref, ":")
+	if len(cells) != 2 {
+		return sheetDimension{}, errors.New(tr("metadata dimensi sheet tidak tersedia (%q)", ref))
+	}
+	startCol, startRow, err := excelize.CellNameToCoordinates(cells[0])
+	if err != nil {
+		return sheetDimension{}, err
+	}
+	endCol, endRow, err := excelize.CellNameToCoordinates(cells[1])
+	if err != nil {
+		return sheetDimension{}, err
+	}
+	return sheetDimension{rows: endRow - startRow + 1, cols: endCol - startCol + 1}, nil
+}
+
+// scanRowDimension menghitung baris dan kolom sheet aktif file ODS atau xls
+// dengan membaca seluruh sheet, karena metadata dimensinya tidak dibaca.
+func scanRowDimension(path string) (sheetDimension, error) {
+	r, err := xlsx2sql.OpenFile(path, xlsx2sql.Options{XMLRows: xmlRowsFor(path)})
+	if err != nil {
+		return sheetDimension{}, err
+	}
+	defer r.Close()
+	var dim sheetDimension
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return dim, nil
+		}
+		if err != nil {
+			return sheetDimension{}, err
+		}
+		dim.rows++
+		dim.cols = max(dim.cols, len(row))
+	}
+}
+
+// prescanFiles melaporkan jumlah baris dan kolom setiap file Excel sebelum
+// diproses, lalu mengurutkan file dari yang terbesar agar file besar mulai
+// dikerjakan lebih dulu dan tidak menjadi satu-satunya pekerjaan di akhir run.
+func prescanFiles(paths []string) []string {
+	mu.Lock()
+	prescanDims = make(map[string]sheetDimension)
+	prescanTotalCells, processedCells = 0, 0
+	mu.Unlock()
+
+	var totalRows, totalCells int64
+	known := true
+	for _, path := range paths {
+		dim, err := scanSheetDimension(path)
+		if err != nil {
+			logError(err, tr("Pre-scan %s gagal", filepath.Base(path)))
+			known = false
+			continue
+		}
+		prescanDims[path] = dim
+		totalRows += int64(dim.rows)
+		totalCells += dim.cells()
+		fmt.Println(tr("Pre-scan %s: %d baris, %d kolom", filepath.Base(path), dim.rows, dim.cols))
+	}
+
+	msg := tr("Pre-scan selesai: %d file, %d baris, %d sel", len(prescanDims), totalRows, totalCells)
+	logRun(msg)
+	fmt.Println(msg)
+
+	// Persentase per sel hanya akurat bila ukuran semua file diketahui
+	if known {
+		mu.Lock()
+		prescanTotalCells = totalCells
+		mu.Unlock()
+	}
+
+	sorted := append([]string(nil), paths...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return prescanDims[sorted[i]].cells() > prescanDims[sorted[j]].cells()
+	})
+	return sorted
+}
+
+// metrics menyimpan counter dan histogram durasi per file untuk endpoint
+// /metrics, terutama berguna pada mode -watch dan -schedule.
+type metrics struct {
+	mu            sync.Mutex
+	files         map[string]int64
+	rowsConverted int64
+	rowsInserted  int64
+	errors        int64
+
+	durationCounts []int64
+	durationSum    float64
+	durationCount  int64
+}
+
+// Batas atas bucket histogram durasi per file dalam detik
+var durationBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900}
+
+var runMetrics = &metrics{
+	files:          make(map[string]int64),
+	durationCounts: make([]int64, len(durationBuckets)),
+}
+
+func (m *metrics) observeFile(status string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[status]++
+	// File yang dilewati tidak diproses sehingga durasinya tidak dicatat
+	if status == "skipped" || status == "unchanged" {
+		return
+	}
+	seconds := duration.Seconds()
+	for i, bound := range durationBuckets {
+		if seconds <= bound {
+			m.durationCounts[i]++
+		}
+	}
+	m.durationSum += seconds
+	m.durationCount++
+}
+
+func (m *metrics) addRowsConverted(n int) {
+	m.mu.Lock()
+	m.rowsConverted += int64(n)
+	m.mu.Unlock()
+}
+
+func (m *metrics) addRowsInserted(n int) {
+	m.mu.Lock()
+	m.rowsInserted += int64(n)
+	m.mu.Unlock()
+}
+
+func (m *metrics) addError() {
+	m.mu.Lock()
+	m.errors++
+	m.mu.Unlock()
+}
+
+// writeTo menulis metrik dalam format teks Prometheus.
+func (m *metrics) writeTo(w io.Writer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	fmt.Fprintln(w, "# HELP xlsx2mariadb_files_processed_total Files processed by status.")
+	fmt.Fprintln(w, "# TYPE xlsx2mariadb_files_processed_total counter")
+	statuses := make([]string, 0, len(m.files))
+	for status := range m.files {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		fmt.Fprintf(w, "xlsx2mariadb_files_processed_total{status=%q} %d\n", status, m.files[status])
+	}
+
+	fmt.Fprintln(w, "# HELP xlsx2mariadb_rows_converted_total Rows converted from Excel files.")
+	fmt.Fprintln(w, "# TYPE xlsx2mariadb_rows_converted_total counter")
+	fmt.Fprintf(w, "xlsx2mariadb_rows_converted_total %d\n", m.rowsConverted)
+	fmt.Fprintln(w, "# HELP xlsx2mariadb_rows_inserted_total Rows inserted into the database.")
+	fmt.Fprintln(w, "# TYPE xlsx2mariadb_rows_inserted_total counter")
+	fmt.Fprintf(w, "xlsx2mariadb_rows_inserted_total %d\n", m.rowsInserted)
+	fmt.Fprintln(w, "# HELP xlsx2mariadb_errors_total Errors written to the error log.")
+	fmt.Fprintln(w, "# TYPE xlsx2mariadb_errors_total counter")
+	fmt.Fprintf(w, "xlsx2mariadb_errors_total %d\n", m.errors)
+
+	fmt.Fprintln(w, "# HELP xlsx2mariadb_file_duration_seconds Time spent converting one Excel file.")
+	fmt.Fprintln(w, "# TYPE xlsx2mariadb_file_duration_seconds histogram")
+	for i, bound := range durationBuckets {
+		fmt.Fprintf(w, "xlsx2mariadb_file_duration_seconds_bucket{le=\"%g\"} %d\n", bound, m.durationCounts[i])
+	}
+	fmt.Fprintf(w, "xlsx2mariadb_file_duration_seconds_bucket{le=\"+Inf\"} %d\n", m.durationCount)
+	fmt.Fprintf(w, "xlsx2mariadb_file_duration_seconds_sum %g\n", m.durationSum)
+	fmt.Fprintf(w, "xlsx2mariadb_file_duration_seconds_count %d\n", m.durationCount)
+}
+
+func startMetricsServer(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		runMetrics.writeTo(w)
+	})
+	mux.HandleFunc("/healthz", handleHealth)
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			logError(err, tr("Gagal menjalankan endpoint metrik pada %s", addr))
+		}
+	}()
+	logRun(tr("Endpoint metrik tersedia pada %s/metrics", addr))
+}
+
+// startPprofServer menjalankan endpoint net/http/pprof pada addr.
+func startPprofServer(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			logError(err, tr("Gagal menjalankan endpoint pprof pada %s", addr))
+		}
+	}()
+	logRun(tr("Endpoint pprof tersedia pada %s/debug/pprof/", addr))
+}
+
+// startTrace mulai menulis execution trace ke path. Fungsi yang dikembalikan
+// menghentikan trace dan menutup file.
+func startTrace(path string) (func(), error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := trace.Start(file); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return func() {
+		trace.Stop()
+		file.Close()
+	}, nil
+}
+
+var startedAt = time.Now()
+
+// isLoopbackAddr mengembalikan true bila alamat listen addr hanya dapat
+// dijangkau dari mesin yang sama, misalnya localhost:8080 atau 127.0.0.1:8080.
+// Host kosong seperti :8080 berarti semua antarmuka jaringan.
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// sameOrigin mengembalikan true bila permintaan r tidak berasal dari halaman
+// web origin lain. Browser modern mengirim Sec-Fetch-Site; browser lama hanya
+// Origin, yang dibandingkan dengan Host. Klien selain browser seperti curl
+// tidak mengirim keduanya dan selalu diizinkan.
+func sameOrigin(r *http.Request) bool {
+	if site := r.Header.Get("Sec-Fetch-Site"); site != "" {
+		return site == "same-origin" || site == "none"
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && strings.EqualFold(u.Host, r.Host)
+}
+
+// isLoopbackHost mengembalikan true bila header Host (dengan atau tanpa
+// port) menunjuk ke mesin yang sama.
+func isLoopbackHost(host string) bool {
+	if _, _, err := net.SplitHostPort(host); err != nil {
+		host = net.JoinHostPort(strings.Trim(host, "[]"), "0")
+	}
+	return isLoopbackAddr(host)
+}
+
+// validAPIToken mengembalikan true bila -api-token kosong (hanya mungkin pada
+// alamat loopback) atau authorization berisi "Bearer <token>" yang cocok.
+func validAPIToken(authorization string) bool {
+	if opts.apiToken == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(authorization, "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(opts.apiToken)) == 1
+}
+
+// requireAPIToken menolak permintaan HTTP tanpa -api-token, kecuali /healthz
+// untuk pemeriksaan kesehatan. Token dapat dikirim sebagai Bearer atau
+// sebagai password basic auth, sehingga halaman web dapat dibuka di browser.
+//
+// Karena browser mengirim ulang basic auth dan server loopback tanpa token
+// dapat dijangkau halaman web mana pun yang dibuka di mesin yang sama,
+// permintaan selain GET dan HEAD dari origin lain selalu ditolak. Tanpa
+// -api-token header Host juga harus alamat loopback agar situs lain tidak
+// dapat menjangkau server melalui DNS rebinding.
+func requireAPIToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if opts.apiToken == "" && !isLoopbackHost(r.Host) {
+			writeJSONError(w, http.StatusForbidden, tr("host %s tidak diizinkan tanpa -api-token", r.Host))
+			return
+		}
+		if r.Method != http.MethodGet && r.Method != http.MethodHead && !sameOrigin(r) {
+			writeJSONError(w, http.StatusForbidden, tr("permintaan dari origin lain ditolak"))
+			return
+		}
+		authorization := r.Header.Get("Authorization")
+		if _, password, ok := r.BasicAuth(); ok {
+			authorization = "Bearer " + password
+		}
+		if r.URL.Path != "/healthz" && !validAPIToken(authorization) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="xlsx2mariadb"`)
+			writeJSONError(w, http.StatusUnauthorized, tr("token API tidak valid"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// grpcAuthorized memeriksa metadata authorization panggilan gRPC terhadap
+// -api-token.
+func grpcAuthorized(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var authorization string
+	if values := md.Get("authorization"); len(values) > 0 {
+		authorization = values[0]
+	}
+	if !validAPIToken(authorization) {
+		return status.Error(codes.Unauthenticated, tr("token API tidak valid"))
+	}
+	return nil
+}
+
+func grpcAuthUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := grpcAuthorized(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func grpcAuthStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := grpcAuthorized(stream.Context()); err != nil {
+		return err
+	}
+	return handler(srv, stream)
+}
+
+// handleHealth melayani /healthz untuk pemeriksaan kesehatan layanan.
+func handleHealth(w http.ResponseWriter, r *http.Request) {
+	mu.Lock()
+	done, total := processedFiles, totalFiles
+	mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status":          "ok",
+		"pid":             os.Getpid(),
+		"uptime":          time.Since(startedAt).Round(time.Second).String(),
+		"processed_files": done,
+		"total_files":     total,
+	})
+}
+
+// cronSchedule adalah jadwal format cron lima kolom: menit, jam, tanggal,
+// bulan dan hari dalam minggu. Setiap kolom disimpan sebagai bitmask.
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+func parseCronSchedule(spec string) (*cronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.New(tr("jadwal harus terdiri dari 5 kolom, ditemukan %d", len(fields)))
+	}
+
+	var sched cronSchedule
+	var err error
+	if sched.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	}
+	if sched.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	}
+	if sched.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	}
+	if sched.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	}
+	if sched.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+	// Minggu boleh ditulis 0 atau 7
+	if sched.dow&(1<<7) != 0 {
+		sched.dow |= 1
+	}
+	sched.domAny = strings.HasPrefix(fields[2], "*")
+	sched.dowAny = strings.HasPrefix(fields[4], "*")
+	return &sched, nil
+}
+
+// parseCronField mengurai satu kolom cron: *, angka, rentang a-b, daftar
+// dipisahkan koma dan langkah /n.
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, errors.New(tr("langkah tidak valid pada %q", part))
+			}
+			rangePart, step = part[:i], n
+		}
+
+		lo, hi := min, max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if lo, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, errors.New(tr("nilai tidak valid pada %q", part))
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, errors.New(tr("nilai tidak valid pada %q", part))
+				}
+			} else if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, errors.New(tr("nilai di luar rentang %d-%d pada %q", min, max, part))
+		}
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := c.dom&(1<<uint(t.Day())) != 0
+	dowMatch := c.dow&(1<<uint(t.Weekday())) != 0
+	// Seperti cron, bila tanggal dan hari sama-sama dibatasi cukup salah satu yang cocok
+	if c.domAny || c.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// next mengembalikan waktu terdekat setelah t yang cocok dengan jadwal.
+func (c *cronSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !c.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// runSchedule menjalankan runPipeline setiap kali jadwal tercapai sampai program
+// dihentikan. Setiap run mendapat runID sendiri sehingga tercatat terpisah di log.
+func runSchedule(ctx context.Context, schedule *cronSchedule, excelDir, sqlDir, sqlDataDir string) int {
+	for {
+		next := schedule.next(time.Now())
+		if next.IsZero() {
+			fmt.Println(tr("Jadwal -schedule tidak pernah tercapai."))
+			return exitConfig
+		}
+		msg := tr("Run berikutnya dijadwalkan pada %s.", next.Format("2006-01-02 15:04"))
+		logRun(msg)
+		fmt.Println(msg)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return exitOK
+		}
+
+		mu.Lock()
+		runID = time.Now().Format("20060102-150405")
+		totalFiles, processedFiles = 0, 0
+		mu.Unlock()
+
+		logRun(tr("Run terjadwal %s dimulai.", runID))
+		code := runPipeline(ctx, excelDir, sqlDir, sqlDataDir, false)
+		notifyRun(code)
+		switch code {
+		case exitOK:
+			logRun(tr("Run terjadwal %s selesai.", runID))
+		case exitInterrupted:
+			logRun(tr("Run terjadwal %s berhenti sebelum selesai.", runID))
+		default:
+			logRun(tr("Run terjadwal %s selesai dengan kode keluar %d.", runID, code))
+		}
+		if ctx.Err() != nil {
+			return exitOK
+		}
+	}
+}
+
+// runWatch memantau direktori xlsx dan mengonversi file Excel yang baru masuk
+// atau berubah sampai program dihentikan. File baru diproses setelah tidak
+// berubah selama -watch-delay agar file yang masih disalin tidak terbaca setengah.
+func runWatch(ctx context.Context, excelDir, sqlDir, sqlDataDir string) int {
+	var targets []*dbTarget
+	if opts.watchLoad && warehouseDialect() != "" {
+		fmt.Println(tr("Opsi -watch-load hanya dapat dipakai dengan -dialect mariadb."))
+		return exitConfig
+	}
+	if opts.watchLoad {
+		// Tanpa checkpoint setiap pemuatan akan mengeksekusi ulang semua file SQL
+		if runCheckpoint == nil {
+			fmt.Println(tr("Opsi -watch-load memerlukan -checkpoint."))
+			return exitConfig
+		}
+		dbConfig, err := readDBConfig(dbConfigPath)
+		if err != nil {
+			logError(err, tr("Gagal membaca file konfigurasi database."))
+			return exitConfig
+		}
+		targets, err = openTargets(dbConfig)
+		if err != nil {
+			logError(err, tr("Gagal membuat koneksi ke database."))
+			return exitDB
+		}
+		defer closeTargets(targets)
+		if err := lockTargets(ctx, targets); err != nil {
+			logError(err, tr("Gagal mengambil lock database"))
+			return lockExitCode(err)
+		}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		logError(err, tr("Gagal memantau direktori %s", excelDir))
+		return exitConfig
+	}
+	defer watcher.Close()
+	if err := watcher.Add(excelDir); err != nil {
+		logError(err, tr("Gagal memantau direktori %s", excelDir))
+		return exitConfig
+	}
+
+	ready := make(chan string, 64)
+	var timersMu sync.Mutex
+	timers := make(map[string]*time.Timer)
+	schedule := func(path string) {
+		timersMu.Lock()
+		defer timersMu.Unlock()
+		if timer, ok := timers[path]; ok {
+			timer.Reset(opts.watchDelay)
+			return
+		}
+		timers[path] = time.AfterFunc(opts.watchDelay, func() {
+			timersMu.Lock()
+			delete(timers, path)
+			timersMu.Unlock()
+			select {
+			case ready <- path:
+			case <-ctx.Done():
+			}
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case path := <-ready:
+				watchProcess(ctx, path, sqlDir, sqlDataDir, targets)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// File yang sudah ada sebelum pemantauan dimulai ikut diperiksa
+	if files, err := os.ReadDir(excelDir); err == nil {
+		for _, file := range files {
+			if isWatchedFile(file.Name()) {
+				schedule(filepath.Join(excelDir, file.Name()))
+			}
+		}
+	}
+
+	msg := tr("Memantau direktori %s, tekan Ctrl+C untuk berhenti.", excelDir)
+	logRun(msg)
+	fmt.Println(msg)
+
+watch:
+	for {
+		select {
+		case event := <-watcher.Events:
+			if (event.Op.Has(fsnotify.Create) || event.Op.Has(fsnotify.Write)) && isWatchedFile(filepath.Base(event.Name)) {
+				schedule(event.Name)
+			}
+		case err := <-watcher.Errors:
+			logError(err, tr("Gagal memantau direktori %s", excelDir))
+		case <-ctx.Done():
+			break watch
+		}
+	}
+
+	<-done
+	logShutdownSummary()
+	if len(targets) > 0 {
+		logTargetSummary(targets)
+	}
+	return exitOK
+}
+
+// isWatchedFile mengabaikan file kunci "~$..." yang dibuat Excel saat file dibuka.
+func isWatchedFile(name string) bool {
+	return isWorkbookFile(name) && !strings.HasPrefix(name, "~$")
+}
+
+// isWorkbookFile mengenali workbook yang dibaca dari direktori xlsx: xlsx,
+// xls format lama (Excel 97-2003) dan OpenDocument Spreadsheet (.ods) dari
+// LibreOffice.
+func isWorkbookFile(name string) bool {
+	switch filepath.Ext(name) {
+	case ".xlsx", ".xls", ".ods":
+		return true
+	}
+	return false
+}
+
+// isODSFile mengenali file OpenDocument Spreadsheet dari ekstensinya.
+func isODSFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".ods")
+}
+
+// isXLSFile mengenali file xls format lama dari ekstensinya.
+func isXLSFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".xls")
+}
+
+// isJSONFile mengenali file JSON berisi array objek dan file JSON Lines
+// (.jsonl atau .ndjson) dari ekstensinya.
+func isJSONFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".jsonl", ".ndjson":
+		return true
+	}
+	return false
+}
+
+// isParquetFile mengenali file Parquet dari ekstensinya.
+func isParquetFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".parquet")
+}
+
+// isXMLFile mengenali file XML dari ekstensinya.
+func isXMLFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".xml")
+}
+
+// xmlRowsFor mengembalikan XPath baris XML untuk path: kolom xml_rows pada
+// manifest, atau -xml-rows.
+func xmlRowsFor(path string) string {
+	if entry, ok := manifestEntryFor(path); ok && entry.XMLRows != "" {
+		return entry.XMLRows
+	}
+	return opts.xmlRows
+}
+
+func watchProcess(ctx context.Context, path, sqlDir, sqlDataDir string, targets []*dbTarget) {
+	if runCheckpoint.isConverted(path) {
+		return
+	}
+	mu.Lock()
+	totalFiles++
+	mu.Unlock()
+
+	sem := make(chan struct{}, 1)
+	sem <- struct{}{}
+	wg.Add(1)
+	processFile(ctx, path, sem, sqlDir, sqlDataDir)
+
+	for _, t := range targets {
+		if ctx.Err() != nil {
+			return
+		}
+		processSQLTableFiles(ctx, t, sqlDir)
+		processSQLDataFiles(ctx, t)
+	}
+}
+
+// serverJob adalah pekerjaan konversi atau pemuatan yang dipicu lewat API.
+type serverJob struct {
+	ID       string            `json:"id"`
+	Kind     string            `json:"kind"`
+	Table    string            `json:"table,omitempty"`
+	Status   string            `json:"status"`
+	Files    map[string]string `json:"files,omitempty"`
+	Created  time.Time         `json:"created"`
+	Finished *time.Time        `json:"finished,omitempty"`
+}
+
+// apiServer melayani subperintah serve. Pekerjaan dijalankan satu per satu
+// oleh satu goroutine agar file yang sama tidak dikonversi bersamaan.
+type apiServer struct {
+	ctx                       context.Context
+	excelDir, sqlDir, dataDir string
+	mu                        sync.Mutex
+	jobs                      map[string]*serverJob
+	nextID                    int
+	queue                     chan *serverJob
+
+	// approvers memetakan token -approver-tokens ke nama penyetuju
+	approvers map[string]string
+}
+
+func runServer(ctx context.Context, excelDir, sqlDir, sqlDataDir string) int {
+	if opts.apiToken != "" && len(opts.apiToken) < minTokenLength {
+		fmt.Println(tr("Nilai -api-token harus paling sedikit %d karakter.", minTokenLength))
+		return exitConfig
+	}
+	for _, addr := range []string{opts.listen, opts.grpcListen} {
+		if addr != "" && opts.apiToken == "" && !isLoopbackAddr(addr) {
+			fmt.Println(tr("Server pada %s dapat dijangkau dari jaringan; isi -api-token atau api_token pada db.cfg, atau pakai alamat loopback seperti localhost:8080.", addr))
+			return exitConfig
+		}
+	}
+	srv := &apiServer{
+		ctx:      ctx,
+		excelDir: excelDir,
+		sqlDir:   sqlDir,
+		dataDir:  sqlDataDir,
+		jobs:     make(map[string]*serverJob),
+		queue:    make(chan *serverJob, 100),
+	}
+	if opts.approverTokens != "" {
+		tokens, err := readApproverTokens(opts.approverTokens)
+		if err != nil {
+			logError(err, tr("Gagal membaca file token penyetuju %s", opts.approverTokens))
+			return exitConfig
+		}
+		srv.approvers = tokens
+	}
+	go srv.worker()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/upload", srv.handleUpload)
+	mux.HandleFunc("/jobs", srv.handleJobs)
+	mux.HandleFunc("/jobs/", srv.handleJobStatus)
+	mux.HandleFunc("/sql/", srv.handleDownload)
+	mux.HandleFunc("/approve/", srv.handleApprove)
+	mux.HandleFunc("/schema/", srv.handleSchema)
+	mux.HandleFunc("/healthz", handleHealth)
+	mux.HandleFunc("/", srv.handleUI)
+	if opts.metricsAddr == "" {
+		mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+			runMetrics.writeTo(w)
+		})
+	}
+
+	if opts.grpcListen != "" {
+		listener, err := net.Listen("tcp", opts.grpcListen)
+		if err != nil {
+			logError(err, tr("Gagal menjalankan server gRPC pada %s", opts.grpcListen))
+			return exitConfig
+		}
+		grpcSrv := grpc.NewServer(grpc.UnaryInterceptor(grpcAuthUnary), grpc.StreamInterceptor(grpcAuthStream))
+		converterpb.RegisterConverterServer(grpcSrv, &grpcServer{api: srv})
+		go func() {
+			<-ctx.Done()
+			grpcSrv.GracefulStop()
+		}()
+		go func() {
+			if err := grpcSrv.Serve(listener); err != nil {
+				logError(err, tr("Gagal menjalankan server gRPC pada %s", opts.grpcListen))
+			}
+		}()
+		msg := tr("Server gRPC berjalan pada %s", opts.grpcListen)
+		logRun(msg)
+		fmt.Println(msg)
+	}
+
+	httpServer := &http.Server{Addr: opts.listen, Handler: requireAPIToken(mux)}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	msg := tr("Server API berjalan pada %s", opts.listen)
+	logRun(msg)
+	fmt.Println(msg)
+	if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logError(err, tr("Gagal menjalankan server API pada %s", opts.listen))
+		return exitConfig
+	}
+	return exitOK
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+// handleUpload menyimpan file xlsx dari form multipart (field "file") ke direktori xlsx.
+func (s *apiServer) handleUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	defer file.Close()
+
+	name := filepath.Base(header.Filename)
+	if !isWatchedFile(name) {
+		writeJSONError(w, http.StatusBadRequest, tr("hanya file .xlsx, .xls dan .ods yang diterima"))
+		return
+	}
+	// Ditulis ke file sementara dulu agar file setengah jadi tidak ikut dikonversi
+	tmp, err := os.CreateTemp(s.excelDir, ".upload-*")
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	_, err = io.Copy(tmp, file)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filepath.Join(s.excelDir, name))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		logError(err, tr("Gagal menyimpan file unggahan %s", name))
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	logRun(tr("File %s diunggah lewat API", name))
+	table := tableNaming().Table(strings.TrimSuffix(name, filepath.Ext(name)))
+	writeJSON(w, http.StatusCreated, map[string]string{"file": name, "table": table})
+}
+
+// handleJobs membuat pekerjaan baru (POST) atau menampilkan semua pekerjaan (GET).
+// Parameter kind=convert (default) atau load, dan file=nama.xlsx untuk
+// mengonversi satu file saja.
+func (s *apiServer) handleJobs(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.mu.Lock()
+		jobs := make([]serverJob, 0, len(s.jobs))
+		for _, j := range s.jobs {
+			jobs = append(jobs, *j)
+		}
+		s.mu.Unlock()
+		sort.Slice(jobs, func(a, b int) bool { return jobs[a].Created.Before(jobs[b].Created) })
+		writeJSON(w, http.StatusOK, jobs)
+	case http.MethodPost:
+		kind := r.FormValue("kind")
+		if kind == "" {
+			kind = "convert"
+		}
+		if kind != "convert" && kind != "load" {
+			writeJSONError(w, http.StatusBadRequest, tr("jenis pekerjaan %q tidak dikenal", kind))
+			return
+		}
+		job, err := s.enqueue(kind, r.FormValue("file"), r.FormValue("table"))
+		switch {
+		case errors.Is(err, errQueueFull):
+			writeJSONError(w, http.StatusServiceUnavailable, tr(err.Error()))
+		case err != nil:
+			writeJSONError(w, http.StatusNotFound, err.Error())
+		default:
+			writeJSON(w, http.StatusAccepted, job)
+		}
+	default:
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// errQueueFull dikembalikan enqueue bila antrean pekerjaan penuh.
+var errQueueFull = errors.New("antrean pekerjaan penuh")
+
+// enqueue membuat pekerjaan kind (convert atau load) dan memasukkannya ke
+// antrean. file membatasi konversi pada satu file di direktori xlsx dan table
+// membatasi pemuatan pada satu tabel.
+func (s *apiServer) enqueue(kind, file, table string) (*serverJob, error) {
+	job := &serverJob{Kind: kind, Status: "queued", Files: make(map[string]string), Created: time.Now()}
+	if file != "" {
+		name := filepath.Base(file)
+		if _, err := os.Stat(filepath.Join(s.excelDir, name)); err != nil {
+			return nil, err
+		}
+		job.Files[name] = "queued"
+	}
+	job.Table = table
+
+	s.mu.Lock()
+	s.nextID++
+	job.ID = strconv.Itoa(s.nextID)
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+
+	select {
+	case s.queue <- job:
+		snapshot, _ := s.snapshot(job.ID)
+		return &snapshot, nil
+	default:
+		s.setJobStatus(job, "failed")
+		return nil, errQueueFull
+	}
+}
+
+// snapshot mengembalikan salinan pekerjaan id yang aman dibaca di luar s.mu.
+func (s *apiServer) snapshot(id string) (serverJob, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[id]
+	if !ok {
+		return serverJob{}, false
+	}
+	snapshot := *job
+	snapshot.Files = make(map[string]string, len(job.Files))
+	for name, status := range job.Files {
+		snapshot.Files[name] = status
+	}
+	return snapshot, true
+}
+
+func (s *apiServer) handleJobStatus(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/jobs/")
+	snapshot, ok := s.snapshot(id)
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, tr("pekerjaan %s tidak ditemukan", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+// handleDownload mengirim file SQL hasil konversi: /sql/<tabel>.sql untuk
+// pembuatan tabel dan /sql/data_<tabel>.sql untuk data.
+func (s *apiServer) handleDownload(w http.ResponseWriter, r *http.Request) {
+	name := filepath.Base(strings.TrimPrefix(r.URL.Path, "/sql/"))
+	dir := s.sqlDir
+	if strings.HasPrefix(name, "data_") {
+		dir = s.dataDir
+	}
+	path, err := outputFile(dir, name)
+	if err != nil {
+		writeJSONError(w, http.StatusNotFound, tr("file %s tidak ditemukan", name))
+		return
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	http.ServeFile(w, r, path)
+}
+
+// handleApprove menyetujui pemuatan tabel (lihat -approval). Penyetuju
+// ditentukan dari token pribadinya pada header X-Approver-Token
+// (-approver-tokens); permintaan tanpa token yang dikenal ditolak.
+func (s *apiServer) handleApprove(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	tableName := filepath.Base(strings.TrimPrefix(r.URL.Path, "/approve/"))
+	user := approverFor(s.approvers, r.Header.Get("X-Approver-Token"))
+	if user == "" {
+		writeJSONError(w, http.StatusUnauthorized, tr("persetujuan membutuhkan token penyetuju yang valid pada header X-Approver-Token"))
+		return
+	}
+	if err := approveTable(tableName, user); err != nil {
+		writeJSONError(w, http.StatusForbidden, err.Error())
+		return
+	}
+	logRun(tr("Tabel %s disetujui oleh %s", tableName, user))
+	writeJSON(w, http.StatusOK, map[string]string{"table": tableName, "approved_by": user})
+}
+
+// handleSchema menampilkan (GET) atau mengubah (POST) nama, tipe dan collation
+// kolom tabel hasil konversi sebelum dimuat.
+func (s *apiServer) handleSchema(w http.ResponseWriter, r *http.Request) {
+	tableName := filepath.Base(strings.TrimPrefix(r.URL.Path, "/schema/"))
+	switch r.Method {
+	case http.MethodGet:
+		columns, err := readTableSchema(s.sqlDir, tableName)
+		if err != nil {
+			writeJSONError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, columns)
+	case http.MethodPost:
+		var columns []schemaColumn
+		if err := json.NewDecoder(r.Body).Decode(&columns); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if err := updateTableSchema(s.sqlDir, s.dataDir, tableName, columns); err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		logRun(tr("Skema tabel %s diubah lewat API", tableName))
+		columns, _ = readTableSchema(s.sqlDir, tableName)
+		writeJSON(w, http.StatusOK, columns)
+	default:
+		writeJSONError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (s *apiServer) handleUI(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	io.WriteString(w, webUIPage)
+}
+
+// grpcServer melayani API gRPC converterpb.Converter di atas antrean
+// pekerjaan apiServer yang sama dengan API REST.
+type grpcServer struct {
+	converterpb.UnimplementedConverterServer
+	api *apiServer
+}
+
+func (g *grpcServer) Convert(ctx context.Context, req *converterpb.ConvertRequest) (*converterpb.Job, error) {
+	kind := "convert"
+	switch req.GetKind() {
+	case converterpb.JobKind_JOB_KIND_UNSPECIFIED, converterpb.JobKind_JOB_KIND_CONVERT:
+	case converterpb.JobKind_JOB_KIND_LOAD:
+		kind = "load"
+	default:
+		return nil, status.Error(codes.InvalidArgument, tr("jenis pekerjaan %q tidak dikenal", req.GetKind().String()))
+	}
+	job, err := g.api.enqueue(kind, req.GetFile(), req.GetTable())
+	switch {
+	case errors.Is(err, errQueueFull):
+		return nil, status.Error(codes.Unavailable, tr(err.Error()))
+	case err != nil:
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return jobProto(*job), nil
+}
+
+func (g *grpcServer) GetStatus(ctx context.Context, req *converterpb.GetStatusRequest) (*converterpb.Job, error) {
+	job, ok := g.api.snapshot(req.GetId())
+	if !ok {
+		return nil, status.Error(codes.NotFound, tr("pekerjaan %s tidak ditemukan", req.GetId()))
+	}
+	return jobProto(job), nil
+}
+
+// StreamProgress meneruskan kejadian kemajuan sampai pekerjaan job_id selesai.
+// Kejadian tidak ditandai dengan pekerjaan, sehingga klien menerima semua
+// kejadian selama pekerjaan itu berjalan.
+func (g *grpcServer) StreamProgress(req *converterpb.StreamProgressRequest, stream converterpb.Converter_StreamProgressServer) error {
+	id := req.GetJobId()
+	if id != "" {
+		if _, ok := g.api.snapshot(id); !ok {
+			return status.Error(codes.NotFound, tr("pekerjaan %s tidak ditemukan", id))
+		}
+	}
+	events, unsubscribe := subscribeProgress()
+	defer unsubscribe()
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case fields := <-events:
+			if err := stream.Send(progressProto(fields)); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if id == "" {
+				continue
+			}
+			if job, _ := g.api.snapshot(id); job.Status != "done" && job.Status != "failed" {
+				continue
+			}
+			// Kirim kejadian yang masih tertahan sebelum stream ditutup
+			for {
+				select {
+				case fields := <-events:
+					if err := stream.Send(progressProto(fields)); err != nil {
+						return err
+					}
+				default:
+					return nil
+				}
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-g.api.ctx.Done():
+			return status.Error(codes.Unavailable, g.api.ctx.Err().Error())
+		}
+	}
+}
+
+// FetchArtifacts mengirim SQLTable/<tabel>.sql dan file data tabel dalam
+// potongan 64 KB.
+func (g *grpcServer) FetchArtifacts(req *converterpb.FetchArtifactsRequest, stream converterpb.Converter_FetchArtifactsServer) error {
+	table := filepath.Base(req.GetTable())
+	var paths []string
+	if tablePath, err := outputFile(g.api.sqlDir, table+".sql"); err == nil {
+		paths = append(paths, tablePath)
+	}
+	if path, err := findDataFile(g.api.dataDir, table); err == nil {
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return status.Error(codes.NotFound, tr("file %s tidak ditemukan", table+".sql"))
+	}
+	buf := make([]byte, 64*1024)
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		name := filepath.Base(path)
+		for {
+			n, err := f.Read(buf)
+			if n > 0 {
+				if sendErr := stream.Send(&converterpb.Artifact{Name: name, Data: buf[:n]}); sendErr != nil {
+					f.Close()
+					return sendErr
+				}
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				f.Close()
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+		f.Close()
+	}
+	return nil
+}
+
+func jobProto(job serverJob) *converterpb.Job {
+	kind := converterpb.JobKind_JOB_KIND_CONVERT
+	if job.Kind == "load" {
+		kind = converterpb.JobKind_JOB_KIND_LOAD
+	}
+	pb := &converterpb.Job{
+		Id:      job.ID,
+		Kind:    kind,
+		Table:   job.Table,
+		Status:  job.Status,
+		Files:   job.Files,
+		Created: timestamppb.New(job.Created),
+	}
+	if job.Finished != nil {
+		pb.Finished = timestamppb.New(*job.Finished)
+	}
+	return pb
+}
+
+// progressProto mengubah kejadian emitProgress menjadi ProgressEvent; nilai
+// selain event dan time ditulis sebagai teks.
+func progressProto(fields map[string]interface{}) *converterpb.ProgressEvent {
+	event := &converterpb.ProgressEvent{Fields: make(map[string]string, len(fields))}
+	for key, value := range fields {
+		switch key {
+		case "event":
+			event.Event = fmt.Sprint(value)
+		case "time":
+			if t, err := time.Parse(time.RFC3339Nano, fmt.Sprint(value)); err == nil {
+				event.Time = timestamppb.New(t)
+			}
+		default:
+			event.Fields[key] = fmt.Sprint(value)
+		}
+	}
+	return event
+}
+
+func (s *apiServer) setJobStatus(job *serverJob, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job.Status = status
+	if status == "done" || status == "failed" {
+		now := time.Now()
+		job.Finished = &now
+	}
+}
+
+func (s *apiServer) worker() {
+	for {
+		select {
+		case job := <-s.queue:
+			s.setJobStatus(job, "running")
+			var ok bool
+			if job.Kind == "load" {
+				ok = s.runLoadJob(job.Table)
+			} else {
+				ok = s.runConvertJob(job)
+			}
+			if ok {
+				s.setJobStatus(job, "done")
+			} else {
+				s.setJobStatus(job, "failed")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *apiServer) runConvertJob(job *serverJob) bool {
+	s.mu.Lock()
+	if len(job.Files) == 0 {
+		files, err := os.ReadDir(s.excelDir)
+		if err != nil {
+			s.mu.Unlock()
+			logError(err, tr("Error membaca direktori xlsx"))
+			return false
+		}
+		for _, file := range files {
+			if isWatchedFile(file.Name()) {
+				job.Files[file.Name()] = "queued"
+			}
+		}
+	}
+	names := make([]string, 0, len(job.Files))
+	for name := range job.Files {
+		names = append(names, name)
+	}
+	s.mu.Unlock()
+
+	mu.Lock()
+	totalFiles, processedFiles = len(names), 0
+	mu.Unlock()
+
+	sem := make(chan struct{}, max(opts.workers, 1))
+	for _, name := range names {
+		sem <- struct{}{}
+		wg.Add(1)
+		go processFile(s.ctx, filepath.Join(s.excelDir, name), sem, s.sqlDir, s.dataDir)
+	}
+	wg.Wait()
+
+	ok := true
+	mu.Lock()
+	statuses := make(map[string]string, len(names))
+	for _, name := range names {
+		status := fileStatus[filepath.Join(s.excelDir, name)]
+		statuses[name] = status
+		if isFailedStatus(status) {
+			ok = false
+		}
+	}
+	mu.Unlock()
+
+	s.mu.Lock()
+	job.Files = statuses
+	s.mu.Unlock()
+	return ok
+}
+
+func (s *apiServer) runLoadJob(table string) bool {
+	if table != "" {
+		selectedTables = map[string]bool{table: true}
+		defer func() { selectedTables = nil }()
+	}
+
+	dbConfig, err := readDBConfig(dbConfigPath)
+	if err != nil {
+		logError(err, tr("Gagal membaca file konfigurasi database."))
+		return false
+	}
+	targets, err := openTargets(dbConfig)
+	if err != nil {
+		logError(err, tr("Gagal membuat koneksi ke database."))
+		return false
+	}
+	defer closeTargets(targets)
+	if err := lockTargets(s.ctx, targets); err != nil {
+		logError(err, tr("Gagal mengambil lock database"))
+		return false
+	}
+
+	ok := true
+	for _, t := range targets {
+		processSQLTableFiles(s.ctx, t, s.sqlDir)
+		processSQLDataFiles(s.ctx, t)
+		if t.tablesFailed > 0 || t.filesFailed > 0 {
+			ok = false
+		}
+	}
+	logTargetSummary(targets)
+	return ok && s.ctx.Err() == nil
+}
+
+// schemaColumn adalah satu kolom pada file SQL pembuatan tabel. Original diisi
+// klien dengan nama lama saat kolom diganti namanya. Collation kosong berarti
+// collation tabel.
+type schemaColumn struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Collation string `json:"collation,omitempty"`
+	Comment   string `json:"comment"`
+	Original  string `json:"original,omitempty"`
+}
+
+var (
+	ddlColumnLine    = regexp.MustCompile("^(`(?:[^`]|``)+`|\\S+) (.+?)(?: COLLATE (\\w+))? DEFAULT NULL COMMENT '(.*)'(,?)$")
+	ddlIndexLine     = regexp.MustCompile("^INDEX (`(?:[^`]|``)+`|\\S+) \\((.+)\\)(,?)$")
+	validIdentifier  = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	quotedIdentifier = regexp.MustCompile("^`(?:[^`]|``)+`$")
+	validColumnType  = regexp.MustCompile(`(?i)^[a-z]+(\(\d+(,\s*\d+)?\))?( unsigned)?$`)
+)
+
+func readTableSchema(sqlDir, tableName string) ([]schemaColumn, error) {
+	path, err := outputFile(sqlDir, tableName+".sql")
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var columns []schemaColumn
+	for _, line := range strings.Split(string(content), "\n") {
+		if m := ddlColumnLine.FindStringSubmatch(line); m != nil {
+			columns = append(columns, schemaColumn{Name: m[1], Type: m[2], Collation: m[3], Comment: m[4]})
+		}
+	}
+	return columns, nil
+}
+
+// updateTableSchema mengganti nama dan tipe kolom pada file SQL pembuatan
+// tabel. Nama kolom yang berubah juga diganti pada file data dan statistiknya.
+// Urutan dan jumlah kolom tidak boleh berubah.
+func updateTableSchema(sqlDir, dataDir, tableName string, columns []schemaColumn) error {
+	sqlFile, err := outputFile(sqlDir, tableName+".sql")
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(sqlFile)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	renames := make(map[string]string)
+	types := make(map[string]string)
+	i := 0
+	for n, line := range lines {
+		m := ddlColumnLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if i >= len(columns) {
+			return errors.New(tr("jumlah kolom tidak sama dengan skema tabel %s", tableName))
+		}
+		column := columns[i]
+		i++
+		if column.Original != "" && column.Original != m[1] {
+			return errors.New(tr("urutan kolom tabel %s tidak boleh diubah", tableName))
+		}
+		// Nama berkutip dari -naming original juga dapat dipakai
+		if !validIdentifier.MatchString(column.Name) && !quotedIdentifier.MatchString(column.Name) {
+			return errors.New(tr("nama kolom %q tidak valid", column.Name))
+		}
+		if !validColumnType.MatchString(column.Type) {
+			return errors.New(tr("tipe kolom %q tidak valid", column.Type))
+		}
+		definition := column.Type
+		if column.Collation != "" {
+			if !validIdentifier.MatchString(column.Collation) {
+				return errors.New(tr("collation %q tidak valid", column.Collation))
+			}
+			if !ddl.Collatable(column.Type) {
+				return errors.New(tr("collation hanya dapat diatur pada kolom teks, bukan %s %s", column.Name, column.Type))
+			}
+			definition += " COLLATE " + column.Collation
+		}
+		if column.Name != m[1] {
+			renames[m[1]] = column.Name
+		}
+		types[column.Name] = column.Type
+		lines[n] = fmt.Sprintf("%s %s DEFAULT NULL COMMENT '%s'%s", column.Name, definition, m[4], m[5])
+	}
+	if i != len(columns) {
+		return errors.New(tr("jumlah kolom tidak sama dengan skema tabel %s", tableName))
+	}
+	for n, line := range lines {
+		m := ddlIndexLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		names := strings.Split(m[2], ", ")
+		renamed := false
+		for j, name := range names {
+			if newName, ok := renames[name]; ok {
+				names[j] = newName
+				renamed = true
+			}
+		}
+		if !renamed {
+			continue
+		}
+		// Indeks gabungan tenant tetap memakai namanya
+		index := m[1]
+		if len(names) == 1 {
+			index = tableNaming().Index(names[0])
+		}
+		lines[n] = fmt.Sprintf("INDEX %s (%s)%s", index, strings.Join(names, ", "), m[3])
+	}
+
+	dataFile, err := findDataFile(dataDir, tableName)
+	if err != nil {
+		return err
+	}
+	// File .tbl tidak memuat nama kolom sehingga tidak perlu diubah
+	if len(renames) > 0 && dataFileExt(dataFile) != ".tbl" {
+		data, err := readDataFile(dataFile)
+		if err != nil {
+			return err
+		}
+		statements, err := loader.Parse(string(data), tableName)
+		if err != nil {
+			return err
+		}
+		var b strings.Builder
+		// Header asal file dipertahankan
+		b.WriteString(strings.TrimSuffix(string(data), stripProvenance(string(data))))
+		for n, stmt := range statements {
+			if n > 0 {
+				b.WriteString(";\n")
+			}
+			for j, column := range stmt.Columns {
+				if newName, ok := renames[column]; ok {
+					stmt.Columns[j] = newName
+				}
+			}
+			fmt.Fprintf(&b, "INSERT INTO %s (%s) VALUES\n", tableName, strings.Join(stmt.Columns, ", "))
+			b.WriteString(strings.Join(stmt.Rows, ",\n"))
+		}
+		b.WriteString(";")
+		if err := writeStreamAtomic(dataFile, strings.NewReader(b.String())); err != nil {
+			return err
+		}
+	}
+
+	if stats, err := readTableStats(statsFilePath(dataFile)); err == nil {
+		for j, column := range stats.Columns {
+			if newName, ok := renames[column.Name]; ok {
+				column.Name = newName
+			}
+			if columnType, ok := types[column.Name]; ok {
+				column.Type = columnType
+			}
+			stats.Columns[j] = column
+		}
+		if err := writeTableStats(statsFilePath(dataFile), stats); err != nil {
+			return err
+		}
+	}
+
+	if err := writeFileAtomic(sqlFile, strings.Join(lines, "\n")); err != nil {
+		return err
+	}
+	runCheckpoint.forget(sqlFile, dataFile)
+	return nil
+}
+
+// webUIPage adalah halaman web mode serve: unggah file xlsx dengan drag and
+// drop, periksa dan ubah skema hasil inferensi, lalu muat ke database.
+const webUIPage = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>xlsx2mariadb</title>
+<style>
+body{font-family:sans-serif;max-width:900px;margin:2em auto;color:#222}
+#drop{border:2px dashed #888;border-radius:8px;padding:3em;text-align:center;color:#666}
+#drop.over{background:#eef}
+table{border-collapse:collapse;margin:1em 0}
+th,td{border:1px solid #ccc;padding:4px 8px}
+input{font-family:monospace}
+button{margin-right:.5em}
+#status{margin:1em 0;font-weight:bold}
+</style>
+</head>
+<body>
+<h1>xlsx2mariadb</h1>
+<div id="drop">Tarik file .xlsx, .xls atau .ods ke sini atau <input type="file" id="file" accept=".xlsx,.xls,.ods"></div>
+<div id="status"></div>
+<div id="schema" hidden>
+<h2 id="table"></h2>
+<table><thead><tr><th>Kolom Excel</th><th>Nama kolom</th><th>Tipe</th><th>Collation</th></tr></thead><tbody id="columns"></tbody></table>
+<button id="save">Simpan skema</button><button id="load">Muat ke database</button>
+</div>
+<script>
+var table = "";
+var statusEl = document.getElementById("status");
+function setStatus(msg) { statusEl.textContent = msg; }
+
+function waitJob(job) {
+  return new Promise(function (resolve, reject) {
+    (function poll() {
+      fetch("/jobs/" + job.id).then(function (r) { return r.json(); }).then(function (j) {
+        if (j.status === "done") { resolve(j); }
+        else if (j.status === "failed") { reject(new Error("pekerjaan " + j.id + " gagal")); }
+        else { setTimeout(poll, 1000); }
+      }).catch(reject);
+    })();
+  });
+}
+
+function startJob(params) {
+  return fetch("/jobs?" + new URLSearchParams(params), {method: "POST"}).then(function (r) { return r.json(); }).then(waitJob);
+}
+
+function showSchema() {
+  return fetch("/schema/" + table).then(function (r) { return r.json(); }).then(function (columns) {
+    var body = document.getElementById("columns");
+    body.innerHTML = "";
+    columns.forEach(function (c) {
+      var tr = document.createElement("tr");
+      tr.dataset.original = c.name;
+      var comment = document.createElement("td");
+      comment.textContent = c.comment;
+      tr.appendChild(comment);
+      [c.name, c.type, c.collation || ""].forEach(function (value) {
+        var td = document.createElement("td");
+        var input = document.createElement("input");
+        input.value = value;
+        td.appendChild(input);
+        tr.appendChild(td);
+      });
+      body.appendChild(tr);
+    });
+    document.getElementById("table").textContent = table;
+    document.getElementById("schema").hidden = false;
+  });
+}
+
+function upload(file) {
+  var form = new FormData();
+  form.append("file", file);
+  setStatus("Mengunggah " + file.name + "...");
+  fetch("/upload", {method: "POST", body: form}).then(function (r) { return r.json(); }).then(function (res) {
+    if (res.error) { throw new Error(res.error); }
+    table = res.table;
+    setStatus("Mengonversi " + res.file + "...");
+    return startJob({kind: "convert", file: res.file});
+  }).then(showSchema).then(function () {
+    setStatus("Periksa skema, ubah bila perlu, lalu muat ke database.");
+  }).catch(function (err) { setStatus("Gagal: " + err.message); });
+}
+
+function saveSchema() {
+  var columns = [];
+  document.querySelectorAll("#columns tr").forEach(function (tr) {
+    var inputs = tr.querySelectorAll("input");
+    columns.push({original: tr.dataset.original, name: inputs[0].value, type: inputs[1].value, collation: inputs[2].value});
+  });
+  return fetch("/schema/" + table, {method: "POST", body: JSON.stringify(columns)}).then(function (r) { return r.json(); }).then(function (res) {
+    if (res.error) { throw new Error(res.error); }
+    return showSchema();
+  });
+}
+
+var drop = document.getElementById("drop");
+drop.addEventListener("dragover", function (e) { e.preventDefault(); drop.classList.add("over"); });
+drop.addEventListener("dragleave", function () { drop.classList.remove("over"); });
+drop.addEventListener("drop", function (e) {
+  e.preventDefault();
+  drop.classList.remove("over");
+  if (e.dataTransfer.files.length > 0) { upload(e.dataTransfer.files[0]); }
+});
+document.getElementById("file").addEventListener("change", function (e) {
+  if (e.target.files.length > 0) { upload(e.target.files[0]); }
+});
+document.getElementById("save").addEventListener("click", function () {
+  saveSchema().then(function () { setStatus("Skema disimpan."); }).catch(function (err) { setStatus("Gagal: " + err.message); });
+});
+document.getElementById("load").addEventListener("click", function () {
+  setStatus("Memuat " + table + " ke database...");
+  saveSchema().then(function () { return startJob({kind: "load", table: table}); }).then(function () {
+    setStatus("Tabel " + table + " selesai dimuat.");
+  }).catch(function (err) { setStatus("Gagal: " + err.message); });
+});
+</script>
+</body>
+</html>
+`
+
+// acquirePIDFile menulis PID proses ke path, kecuali path berisi PID proses
+// lain yang masih hidup.
+func acquirePIDFile(path string) error {
+	if content, err := os.ReadFile(path); err == nil {
+		if pid, err := strconv.Atoi(strings.TrimSpace(string(content))); err == nil && pid != os.Getpid() && processAlive(pid) {
+			return errors.New(tr("Program sudah berjalan dengan PID %d (file %s).", pid, path))
+		}
+	}
+	return writeFileAtomic(path, strconv.Itoa(os.Getpid())+"\n")
+}
+
+func processAlive(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	// Di Windows FindProcess gagal bila proses tidak ada
+	if runtime.GOOS == "windows" {
+		process.Release()
+		return true
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+const serviceName = "xlsx2mariadb"
+
+// runService menjalankan subperintah "service install|uninstall". Layanan
+// menjalankan perintah daemon di direktori kerja saat ini dengan opsi yang
+// diberikan sebelum kata service. Di Linux layanan dipasang sebagai unit
+// systemd, di Windows sebagai scheduled task yang berjalan saat komputer menyala.
+func runService(args []string) int {
+	if len(args) != 1 || (args[0] != "install" && args[0] != "uninstall") {
+		fmt.Println(tr("Penggunaan: xlsx2mariadb [opsi] service install|uninstall"))
+		return exitConfig
+	}
+
+	var err error
+	switch {
+	case runtime.GOOS == "windows" && args[0] == "install":
+		err = installWindowsTask()
+	case runtime.GOOS == "windows":
+		err = runCommand("schtasks", "/Delete", "/F", "/TN", serviceName)
+	case args[0] == "install":
+		err = installSystemdUnit()
+	default:
+		err = uninstallSystemdUnit()
+	}
+	if err != nil {
+		logError(err, tr("Gagal menjalankan service %s", args[0]))
+		return exitPartial
+	}
+	msg := tr("Layanan %s berhasil di-%s.", serviceName, args[0])
+	logRun(msg)
+	fmt.Println(msg)
+	return exitOK
+}
+
+// runBench membuat -bench-files workbook sintetis di -tmp-dir lalu mengukur
+// kecepatan konversi dengan -workers file sekaligus. Dengan argumen load,
+// hasil konversi juga dimuat ke database db.cfg dengan -db-workers file
+// sekaligus ke tabel sementara xlsx2mariadbbench<n> yang dihapus kembali.
+func runBench(args []string) int {
+	load := len(args) == 1 && args[0] == "load"
+	if len(args) > 1 || len(args) == 1 && !load || opts.benchRows < 1 || opts.benchCols < 1 || opts.benchFiles < 1 {
+		fmt.Println(tr("Penggunaan: xlsx2mariadb [-bench-rows N] [-bench-cols N] [-bench-files N] bench [load]"))
+		return exitConfig
+	}
+
+	var db *sql.DB
+	if load {
+		config, err := readDBConfig(dbConfigPath)
+		if err != nil {
+			logError(err, tr("Gagal membaca file konfigurasi database."))
+			return exitConfig
+		}
+		if db, err = createDBConnection(config); err != nil {
+			logError(err, tr("Gagal membuat koneksi ke database."))
+			return exitDB
+		}
+		defer db.Close()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handleSignals(cancel)
+
+	dir, err := os.MkdirTemp(opts.tmpDir, "xlsx2mariadb-bench-*")
+	if err != nil {
+		logError(err, tr("Gagal membuat direktori sementara"))
+		return exitConfig
+	}
+	defer os.RemoveAll(dir)
+
+	fmt.Println(tr("Membuat %d workbook sintetis: %d baris x %d kolom", opts.benchFiles, opts.benchRows, opts.benchCols))
+	paths := make([]string, opts.benchFiles)
+	var inputBytes int64
+	for i := range paths {
+		paths[i] = filepath.Join(dir, fmt.Sprintf("xlsx2mariadbbench%d.xlsx", i+1))
+		if err := writeBenchWorkbook(paths[i], opts.benchRows, opts.benchCols); err != nil {
+			logError(err, tr("Gagal membuat workbook sintetis"))
+			return exitConfig
+		}
+		if info, err := os.Stat(paths[i]); err == nil {
+			inputBytes += info.Size()
+		}
+	}
+
+	// Hasil konversi hanya disimpan bila akan dimuat
+	results := make([]*xlsx2sql.Result, len(paths))
+	data := make([]strings.Builder, len(paths))
+	var sqlBytes atomic.Int64
+	errs := make(chan error, len(paths))
+	start := time.Now()
+	runBenchJobs(len(paths), opts.workers, func(i int) {
+		out := &countingWriter{}
+		if load {
+			out.w = &data[i]
+		}
+		result, err := xlsx2sql.ConvertFile(ctx, paths[i], xlsx2sql.Options{
+			Table:         strings.TrimSuffix(filepath.Base(paths[i]), ".xlsx"),
+			Excelize:      excelizeOptions(paths[i]),
+			NumberFormats: opts.numberFormats,
+			Data:          out,
+			DDL:           tableOptionsFor(paths[i]),
+		})
+		if err != nil {
+			errs <- err
+			return
+		}
+		results[i] = result
+		sqlBytes.Add(out.n)
+	})
+	if err := benchError(ctx, errs); err != nil {
+		logError(err, tr("Benchmark konversi gagal"))
+		return exitPartial
+	}
+	rows := opts.benchFiles * opts.benchRows
+	printBenchResult(tr("Konversi"), rows, inputBytes, sqlBytes.Load(), time.Since(start))
+	if !load {
+		return exitOK
+	}
+
+	start = time.Now()
+	runBenchJobs(len(paths), opts.dbWorkers, func(i int) {
+		result := results[i]
+		defer execWithReconnect(context.Background(), db, "DROP TABLE IF EXISTS "+result.Table)
+		if err := execWithReconnect(ctx, db, "DROP TABLE IF EXISTS "+result.Table); err != nil {
+			errs <- err
+			return
+		}
+		if _, err := loader.Load(ctx, db, result.Table, result.CreateTable, data[i].String(), 0); err != nil {
+			errs <- err
+		}
+	})
+	if err := benchError(ctx, errs); err != nil {
+		logError(err, tr("Benchmark pemuatan gagal"))
+		return exitPartial
+	}
+	printBenchResult(tr("Pemuatan"), rows, inputBytes, sqlBytes.Load(), time.Since(start))
+	return exitOK
+}
+
+// runBenchJobs menjalankan job(0) sampai job(n-1) dengan paling banyak
+// workers job sekaligus dan menunggu semuanya selesai.
+func runBenchJobs(n, workers int, job func(i int)) {
+	sem := make(chan struct{}, max(workers, 1))
+	var jobs sync.WaitGroup
+	for i := 0; i < n; i++ {
+		sem <- struct{}{}
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			defer func() { <-sem }()
+			job(i)
+		}()
+	}
+	jobs.Wait()
+}
+
+// benchError mengembalikan kesalahan pertama dari errs, atau kesalahan ctx
+// bila benchmark dihentikan.
+func benchError(ctx context.Context, errs chan error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	select {
+	case err := <-errs:
+		return err
+	default:
+		return nil
+	}
+}
+
+func printBenchResult(phase string, rows int, inputBytes, sqlBytes int64, elapsed time.Duration) {
+	seconds := elapsed.Seconds()
+	msg := tr("%s: %d baris dalam %s, %.0f baris/detik, %.1f MB/detik xlsx, %.1f MB/detik SQL",
+		phase, rows, elapsed.Round(time.Millisecond), float64(rows)/seconds,
+		float64(inputBytes)/(1<<20)/seconds, float64(sqlBytes)/(1<<20)/seconds)
+	logRun(msg)
+	fmt.Println(msg)
+}
+
+// countingWriter menghitung byte yang ditulis dan meneruskannya ke w bila tidak nil.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	if c.w == nil {
+		return len(p), nil
+	}
+	return c.w.Write(p)
+}
+
+// writeBenchWorkbook menulis workbook dengan rows baris data dan cols kolom
+// berganti-ganti tipe (bilangan bulat, pecahan, teks dan tanggal) memakai
+// StreamWriter excelize agar workbook besar tidak dibangun di memori.
+func writeBenchWorkbook(path string, rows, cols int) error {
+	xlsx := excelize.NewFile()
+	defer xlsx.Close()
+	sheet := xlsx.GetSheetName(xlsx.GetActiveSheetIndex())
+	sw, err := xlsx.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+
+	row := make([]interface{}, cols)
+	for j := range row {
+		row[j] = fmt.Sprintf("Kolom %d", j+1)
+	}
+	if err := sw.SetRow("A1", row); err != nil {
+		return err
+	}
+	date := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= rows; i++ {
+		for j := range row {
+			switch j % 4 {
+			case 0:
+				row[j] = i
+			case 1:
+				row[j] = float64(i*(j+1)) / 100
+			case 2:
+				row[j] = fmt.Sprintf("teks %d-%d", i, j)
+			default:
+				row[j] = date.AddDate(0, 0, i%3650).Format("2006-01-02")
+			}
+		}
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		if err := sw.SetRow(cell, row); err != nil {
+			return err
+		}
+	}
+	if err := sw.Flush(); err != nil {
+		return err
+	}
+	return xlsx.SaveAs(path)
+}
+
+// serviceCommand mengembalikan path program, opsi dari baris perintah saat ini
+// dan direktori kerja untuk dijalankan oleh layanan.
+func serviceCommand() (string, []string, string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", nil, "", err
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", nil, "", err
+	}
+	flagArgs := append([]string(nil), os.Args[1:len(os.Args)-flag.NArg()]...)
+	return exe, append(flagArgs, "daemon"), dir, nil
+}
+
+func runCommand(name string, args ...string) error {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %v: %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func systemdUnitPath() string {
+	return filepath.Join("/etc/systemd/system", serviceName+".service")
+}
+
+func installSystemdUnit() error {
+	exe, args, dir, err := serviceCommand()
+	if err != nil {
+		return err
+	}
+	execStart := []string{strconv.Quote(exe)}
+	for _, arg := range args {
+		execStart = append(execStart, strconv.Quote(arg))
+	}
+
+	unit := fmt.Sprintf(`[Unit]
+Description=xlsx2mariadb: konversi dan pemuatan file Excel ke MariaDB
+After=network-online.target mariadb.service
+Wants=network-online.target
+
+[Service]
+Type=simple
+WorkingDirectory=%s
+ExecStart=%s
+Restart=on-failure
+RestartSec=10
+
+[Install]
+WantedBy=multi-user.target
+`, dir, strings.Join(execStart, " "))
+
+	if err := os.WriteFile(systemdUnitPath(), []byte(unit), 0644); err != nil {
+		return err
+	}
+	if err := runCommand("systemctl", "daemon-reload"); err != nil {
+		return err
+	}
+	return runCommand("systemctl", "enable", "--now", serviceName)
+}
+
+func uninstallSystemdUnit() error {
+	if err := runCommand("systemctl", "disable", "--now", serviceName); err != nil {
+		return err
+	}
+	if err := os.Remove(systemdUnitPath()); err != nil {
+		return err
+	}
+	return runCommand("systemctl", "daemon-reload")
+}
+
+func installWindowsTask() error {
+	exe, args, dir, err := serviceCommand()
+	if err != nil {
+		return err
+	}
+	// Scheduled task tidak dapat mengatur direktori kerja, sehingga dijalankan lewat cmd
+	command := fmt.Sprintf(`cmd /c cd /d "%s" && "%s"`, dir, exe)
+	for _, arg := range args {
+		command += " " + strconv.Quote(arg)
+	}
+	if err := runCommand("schtasks", "/Create", "/F", "/SC", "ONSTART", "/RU", "SYSTEM", "/TN", serviceName, "/TR", command); err != nil {
+		return err
+	}
+	return runCommand("schtasks", "/Run", "/TN", serviceName)
+}
+
+// errRunLocked menandai bahwa run lain sedang memproses direktori atau database yang sama.
+var errRunLocked = errors.New("run locked")
+
+// runLock adalah isi file lock direktori kerja.
+type runLock struct {
+	PID     int       `json:"pid"`
+	Host    string    `json:"host"`
+	RunID   string    `json:"run_id"`
+	Started time.Time `json:"started"`
+}
+
+const runLockPath = ".xlsx2mariadb.lock"
+
+// acquireRunLock membuat file lock di direktori kerja agar dua run tidak
+// menulis SQLTable dan SQLData secara bersamaan. Lock dari proses di host yang
+// sama yang sudah mati dianggap basi dan diambil alih. Pada -lock wait, fungsi
+// menunggu sampai lock dilepas, -lock-wait habis atau ctx dibatalkan.
+func acquireRunLock(ctx context.Context, path string) (func(), error) {
+	host, _ := os.Hostname()
+	content, err := json.Marshal(runLock{PID: os.Getpid(), Host: host, RunID: runID, Started: time.Now()})
+	if err != nil {
+		return nil, err
+	}
+
+	var deadline time.Time
+	if opts.lockWait > 0 {
+		deadline = time.Now().Add(opts.lockWait)
+	}
+	announced := false
+	for {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, err = file.Write(content)
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+			return func() { os.Remove(path) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, err
+		}
+
+		var holder runLock
+		if existing, err := os.ReadFile(path); err == nil && json.Unmarshal(existing, &holder) == nil &&
+			holder.Host == host && !processAlive(holder.PID) {
+			logRun(tr("File lock %s dari PID %d yang sudah berhenti diambil alih.", path, holder.PID))
+			os.Remove(path)
+			continue
+		}
+		msg := tr("Run lain (PID %d di %s, run %s) sedang memproses direktori ini sejak %s.", holder.PID, holder.Host, holder.RunID, holder.Started.Format("2006-01-02 15:04:05"))
+		if opts.lock != "wait" || (!deadline.IsZero() && time.Now().After(deadline)) {
+			return nil, fmt.Errorf("%w: %s", errRunLocked, msg)
+		}
+		if !announced {
+			logRun(msg)
+			fmt.Println(msg, tr("Menunggu run tersebut selesai..."))
+			announced = true
+		}
+		select {
+		case <-time.After(time.Second):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (t *dbTarget) lockName() string {
+	// Nama lock GET_LOCK dibatasi 64 karakter. Nama database yang terlalu
+	// panjang diganti hash-nya agar dua database dengan awalan nama yang sama
+	// tidak berbagi lock.
+	name := "xlsx2mariadb." + t.database
+	if len(name) > 64 {
+		sum := sha256.Sum256([]byte(t.database))
+		name = "xlsx2mariadb." + hex.EncodeToString(sum[:])[:32]
+	}
+	return name
+}
+
+// lockTargets mengambil advisory lock GET_LOCK pada setiap database tujuan,
+// sehingga dua run yang memuat ke database yang sama tidak saling menyela
+// walaupun dijalankan dari direktori atau komputer yang berbeda. Lock dipegang
+// oleh satu koneksi khusus sampai closeTargets.
+func lockTargets(ctx context.Context, targets []*dbTarget) error {
+	if opts.lock == "off" {
+		return nil
+	}
+	timeout := 0
+	if opts.lock == "wait" {
+		// Nilai negatif berarti menunggu tanpa batas
+		timeout = -1
+		if opts.lockWait > 0 {
+			timeout = int(math.Ceil(opts.lockWait.Seconds()))
+		}
+	}
+	for _, t := range targets {
+		conn, err := t.db.Conn(ctx)
+		if err != nil {
+			return err
+		}
+		if timeout != 0 {
+			logRun(tr("Menunggu lock database %s", t.name))
+		}
+		var result sql.NullInt64
+		if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", t.lockName(), timeout).Scan(&result); err != nil {
+			conn.Close()
+			return err
+		}
+		if !result.Valid || result.Int64 != 1 {
+			conn.Close()
+			return fmt.Errorf("%w: %s", errRunLocked, tr("Run lain sedang memuat data ke database %s.", t.name))
+		}
+		t.lockConn = conn
+	}
+	return nil
+}
+
+// lockExitCode mengembalikan kode keluar untuk kegagalan mengambil lock.
+func lockExitCode(err error) int {
+	if errors.Is(err, errRunLocked) {
+		return exitLocked
+	}
+	if errors.Is(err, context.Canceled) {
+		return exitInterrupted
+	}
+	return exitDB
+}
+
+// Kode keluar program, agar pembungkus seperti cron atau CI dapat mendeteksi kegagalan
+const (
+	exitOK          = 0
+	exitPartial     = 1   // sebagian file, tabel atau data gagal diproses
+	exitConfig      = 2   // opsi, file konfigurasi atau direktori tidak valid
+	exitDB          = 3   // koneksi ke database gagal
+	exitLocked      = 4   // run lain sedang memproses direktori atau database yang sama
+	exitInterrupted = 130 // dihentikan oleh sinyal
+)
+
+func main() {
+	os.Exit(run())
+}
+
+func run() int {
+	parseFlags()
+	if _, err := os.Stat(dbConfigPath); err == nil {
+		config, err := readDBConfig(dbConfigPath)
+		if err == nil {
+			err = applyConfigOptions(config)
+		}
+		if err != nil {
+			fmt.Println(tr("Gagal membaca opsi dari file konfigurasi: %v", err))
+			return exitConfig
+		}
+	}
+	language = detectLanguage(opts.lang)
+	loader.Translate = tr
+	if err := validateIDColumn(); err != nil {
+		logError(err, tr("Opsi kolom id tidak valid"))
+		return exitConfig
+	}
+	if err := validateTableOptions(opts.engine, opts.rowFormat, opts.tableOptions); err != nil {
+		logError(err, tr("Opsi tabel tidak valid"))
+		return exitConfig
+	}
+	switch ddl.Dialect(opts.dialect) {
+	case "mariadb", ddl.Vertica, ddl.Redshift, ddl.Postgres:
+	default:
+		fmt.Println(tr("Nilai -dialect %q tidak dikenal, gunakan mariadb, vertica, redshift atau postgres.", opts.dialect))
+		return exitConfig
+	}
+	if opts.stdout && opts.ndjson == "-" {
+		fmt.Println(tr("Opsi -stdout dan -ndjson - tidak dapat dipakai bersamaan."))
+		return exitConfig
+	}
+	if opts.dialect != "mariadb" && opts.columnstore {
+		fmt.Println(tr("Opsi -columnstore hanya dapat dipakai dengan -dialect mariadb."))
+		return exitConfig
+	}
+	switch ddl.CreateMode(opts.createMode) {
+	case ddl.CreatePlain, ddl.CreateIfNotExists, ddl.CreateOrReplace:
+	default:
+		fmt.Println(tr("Nilai -create-mode %q tidak dikenal, gunakan plain, if-not-exists atau replace.", opts.createMode))
+		return exitConfig
+	}
+	switch opts.lock {
+	case "abort", "wait", "off":
+	default:
+		fmt.Println(tr("Nilai -lock %q tidak dikenal, gunakan abort, wait atau off.", opts.lock))
+		return exitConfig
+	}
+	for _, pattern := range append(globPatterns(opts.include), globPatterns(opts.exclude)...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			fmt.Println(tr("Pola glob %q tidak valid.", pattern))
+			return exitConfig
+		}
+	}
+	if opts.sheets != "active" && opts.sheets != "all" {
+		selection, err := parseSheetSelector(opts.sheets)
+		if err != nil {
+			fmt.Println(tr("Nilai -sheets %q tidak valid: %v", opts.sheets, err))
+			return exitConfig
+		}
+		sheetSelection = selection
+	}
+	if opts.headerRow < 1 || opts.headerRows < 1 || opts.skipRows < 0 {
+		fmt.Println(tr("Nilai -header-row dan -header-rows harus paling sedikit 1 dan -skip-rows tidak boleh negatif."))
+		return exitConfig
+	}
+	if opts.minConfidence <= 0 || opts.minConfidence > 1 {
+		fmt.Println(tr("Nilai -min-confidence harus lebih dari 0 dan paling besar 1."))
+		return exitConfig
+	}
+	if opts.columnNames != "" && !opts.noHeader {
+		fmt.Println(tr("Opsi -column-names hanya berlaku dengan -no-header."))
+		return exitConfig
+	}
+	if slices.Contains(headerNames(), "") {
+		fmt.Println(tr("Nama kolom -column-names tidak boleh kosong."))
+		return exitConfig
+	}
+	if opts.encryptColumns != "" && (opts.columnstore || warehouseDialect() != "") {
+		fmt.Println(tr("Opsi -encrypt-columns hanya berlaku untuk pernyataan INSERT MariaDB, tanpa -columnstore dan -dialect."))
+		return exitConfig
+	}
+	if opts.loadChunks < 1 {
+		fmt.Println(tr("Nilai -load-chunks harus paling sedikit 1."))
+		return exitConfig
+	}
+	if opts.adaptiveWorkers < 0 || opts.adaptiveWorkers > 0 && opts.loadCheckInterval <= 0 {
+		fmt.Println(tr("Nilai -adaptive-workers tidak boleh negatif dan -load-check-interval harus lebih dari 0."))
+		return exitConfig
+	}
+	if !slices.Contains(ddl.Namings, tableNaming()) {
+		fmt.Println(tr("Nilai -naming %q tidak dikenal, gunakan compact, snake_case, lowerCamel atau original.", opts.naming))
+		return exitConfig
+	}
+	if opts.tmpDir != "" {
+		// excelize membuat file sementara di os.TempDir()
+		os.Setenv("TMPDIR", opts.tmpDir)
+		os.Setenv("TMP", opts.tmpDir)
+	}
+	if opts.readRate < 0 || opts.maxProcs < 0 {
+		fmt.Println(tr("Nilai -read-rate dan -max-procs tidak boleh negatif."))
+		return exitConfig
+	}
+	if opts.nice < 0 || opts.nice > 19 {
+		fmt.Println(tr("Nilai -nice harus antara 0 dan 19."))
+		return exitConfig
+	}
+	if ioniceArgs, err := ioniceArguments(opts.ionice); err != nil {
+		fmt.Println(err)
+		return exitConfig
+	} else if err := lowerPriority(ioniceArgs); err != nil {
+		// Program tetap berjalan dengan prioritas normal
+		fmt.Println(tr("Gagal menurunkan prioritas proses: %v", err))
+	}
+	if opts.readRate > 0 {
+		sourceThrottle = &readThrottle{rate: float64(opts.readRate) * (1 << 20)}
+	}
+
+	switch flag.Arg(0) {
+	case "approve":
+		return runApprove(flag.Args()[1:])
+	case "service":
+		return runService(flag.Args()[1:])
+	case "bench":
+		return runBench(flag.Args()[1:])
+	case "runs":
+		return runRuns(flag.Args()[1:])
+	case "convert":
+		inputFiles = flag.Args()[1:]
+	case "daemon":
+		// Checkpoint dipertahankan agar setelah restart layanan file yang sudah
+		// dimuat tidak dimuat ulang
+		opts.watch, opts.watchLoad, opts.resume = true, true, true
+		if opts.pidFile == "" {
+			opts.pidFile = "xlsx2mariadb.pid"
+		}
+	}
+
+	// Argumen berupa file Excel diproses tanpa perlu kata convert
+	if len(inputFiles) == 0 && flag.NArg() > 0 && isInputArg(flag.Arg(0)) {
+		inputFiles = flag.Args()
+	}
+	if opts.passwords != "" {
+		entries, err := readPasswords(opts.passwords)
+		if err != nil {
+			logError(err, tr("Gagal membaca file password %s", opts.passwords))
+			return exitConfig
+		}
+		workbookPasswords = entries
+	}
+	if opts.tenants != "" {
+		entries, err := readTenants(opts.tenants)
+		if err != nil {
+			logError(err, tr("Gagal membaca file tenant %s", opts.tenants))
+			return exitConfig
+		}
+		tenantEntries = entries
+	}
+	if opts.manifest != "" {
+		files, err := readManifest(opts.manifest)
+		if err != nil {
+			logError(err, tr("Gagal membaca manifest %s", opts.manifest))
+			return exitConfig
+		}
+		if len(files) == 0 && len(inputFiles) == 0 {
+			fmt.Println(tr("Manifest %s tidak berisi file yang diproses.", opts.manifest))
+			return exitOK
+		}
+		inputFiles = append(inputFiles, files...)
+	}
+	if encryptionConfigured() && opts.encryptionKey == "" && !opts.stdout {
+		// Tanpa kunci AES_ENCRYPT menghasilkan NULL sehingga nilai hilang
+		fmt.Println(tr("Kolom terenkripsi membutuhkan -encryption-key atau encryption_key pada db.cfg."))
+		return exitConfig
+	}
+	for i, file := range inputFiles {
+		if file != "-" {
+			continue
+		}
+		path, cleanup, err := readStdinInput()
+		if err != nil {
+			logError(err, tr("Gagal membaca standard input"))
+			return exitConfig
+		}
+		defer cleanup()
+		inputFiles[i] = path
+	}
+	for i, file := range inputFiles {
+		if !isURLArg(file) {
+			continue
+		}
+		path, err := downloadURL(context.Background(), file)
+		if err != nil {
+			logError(err, tr("Gagal mengunduh %s", file))
+			return exitConfig
+		}
+		inputFiles[i] = path
+	}
+	if opts.gsheets != "" {
+		paths, cleanup, err := downloadGoogleSheets(context.Background())
+		if err != nil {
+			logError(err, tr("Gagal mengambil Google Sheets %s", opts.gsheets))
+			return exitConfig
+		}
+		defer cleanup()
+		inputFiles = append(inputFiles, paths...)
+	}
+	if opts.input != "" {
+		paths, cleanup, err := downloadS3(context.Background())
+		if err != nil {
+			logError(err, tr("Gagal mengambil input %s", opts.input))
+			return exitConfig
+		}
+		defer cleanup()
+		inputFiles = append(inputFiles, paths...)
+	}
+	if opts.sftp != "" {
+		source, err := openSFTP(opts.sftp)
+		if err != nil {
+			logError(err, tr("Gagal mengambil file SFTP %s", opts.sftp))
+			return exitConfig
+		}
+		defer source.close()
+		paths, err := source.download()
+		if err != nil {
+			logError(err, tr("Gagal mengambil file SFTP %s", opts.sftp))
+			return exitConfig
+		}
+		if len(paths) == 0 && len(inputFiles) == 0 {
+			fmt.Println(tr("Tidak ada file baru pada %s.", opts.sftp))
+			return exitOK
+		}
+		sftpInput = source
+		inputFiles = append(inputFiles, paths...)
+	}
+	if slices.ContainsFunc(inputFiles, isZipFile) {
+		var expanded []string
+		for _, file := range inputFiles {
+			if !isZipFile(file) {
+				expanded = append(expanded, file)
+				continue
+			}
+			paths, cleanup, err := extractZip(file)
+			if err != nil {
+				logError(err, tr("Gagal mengekstrak arsip ZIP %s", file))
+				return exitConfig
+			}
+			defer cleanup()
+			zipEntries[file] = paths
+			expanded = append(expanded, paths...)
+		}
+		inputFiles = expanded
+	}
+	if len(inputFiles) > 0 {
+		// Hanya tabel dari file-file tersebut yang dibuat dan dimuat
+		selectedTables = make(map[string]bool)
+		for _, file := range inputFiles {
+			path, _ := winpath.Resolve("", file)
+			selectedTables[tableNameFor(path)] = true
+		}
+	} else if flag.NArg() > 0 && flag.Arg(0) != "serve" && flag.Arg(0) != "daemon" && flag.Arg(0) != "convert" {
+		fmt.Println(tr("Perintah atau file %q tidak dikenal.", flag.Arg(0)))
+		return exitConfig
+	}
+
+	if opts.stdout || opts.ndjson == "-" || opts.progress == "json" {
+		// Pesan untuk pengguna dipindah ke stderr agar standard output hanya berisi
+		// SQL, NDJSON atau kejadian JSON. Bila SQL atau NDJSON ditulis ke
+		// standard output, kejadian ditulis ke stderr.
+		stdout := os.Stdout
+		os.Stdout = os.Stderr
+		if opts.stdout || opts.ndjson == "-" {
+			sqlStdout = stdout
+		}
+		if opts.stdout {
+			// Tidak ada file SQL yang ditulis sehingga state dan checkpoint tidak dipakai
+			opts.stateFile, opts.checkpoint = "", ""
+		}
+		if opts.progress == "json" {
+			progressOut = stdout
+			if sqlStdout != nil {
+				progressOut = os.Stderr
+			}
+		}
+	}
+
+	if opts.pidFile != "" {
+		if err := acquirePIDFile(opts.pidFile); err != nil {
+			fmt.Println(err)
+			return exitConfig
+		}
+		defer os.Remove(opts.pidFile)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handleSignals(cancel)
+	logRun(tr("Program mulai bekerja."))
+	procs := runtime.NumCPU()
+	if opts.maxProcs > 0 {
+		procs = min(procs, opts.maxProcs)
+	}
+	runtime.GOMAXPROCS(procs)
+
+	// Pada mode -stdout tidak ada file yang ditulis ke SQLTable dan SQLData
+	if opts.lock != "off" && !opts.stdout {
+		release, err := acquireRunLock(ctx, runLockPath)
+		if err != nil {
+			logError(err, tr("Gagal mengambil lock direktori kerja"))
+			return lockExitCode(err)
+		}
+		defer release()
+	}
+
+	excelDir := workPath(opts.xlsxDir)
+	sqlDir := workPath(opts.sqlTableDir)
+	sqlDataDir := workPath(opts.sqlDataDir)
+
+	// Semua direktori yang akan ditulisi diperiksa di awal, agar kegagalan
+	// tidak baru muncul sebagai error penulisan di tengah run
+	dirs := []string{logDirPath(), opts.tmpDir, opts.report}
+	if !opts.stdout {
+		dirs = append(dirs, sqlDir, sqlDataDir)
+	}
+	if opts.approval {
+		dirs = append(dirs, reviewDir)
+	}
+	if opts.preview != "" {
+		dirs = append(dirs, previewDir)
+	}
+	if err := ensureDirs(dirs...); err != nil {
+		// error.log mungkin tidak dapat ditulis, sehingga pesan hanya ditampilkan
+		fmt.Println(tr("Direktori kerja tidak dapat disiapkan: %v", err))
+		return exitConfig
+	}
+
+	var schedule *cronSchedule
+	if opts.schedule != "" {
+		var err error
+		if schedule, err = parseCronSchedule(opts.schedule); err != nil {
+			fmt.Println(tr("Jadwal -schedule tidak valid: %v", err))
+			return exitConfig
+		}
+	}
+
+	var err error
+	if opts.checkpoint != "" {
+		runCheckpoint, err = openCheckpoint(opts.checkpoint, opts.resume)
+		if err != nil {
+			logError(err, tr("Gagal membuka checkpoint %s", opts.checkpoint))
+			return exitConfig
+		}
+	}
+
+	if opts.stateFile != "" {
+		fileStates, err = openStateStore(opts.stateFile)
+		if err != nil {
+			logError(err, tr("Gagal membaca file state %s", opts.stateFile))
+			return exitConfig
+		}
+	}
+
+	if opts.metricsAddr != "" {
+		startMetricsServer(opts.metricsAddr)
+	}
+	if opts.pprofAddr != "" {
+		startPprofServer(opts.pprofAddr)
+	}
+	if opts.traceFile != "" {
+		stop, err := startTrace(opts.traceFile)
+		if err != nil {
+			logError(err, tr("Gagal membuat file trace %s", opts.traceFile))
+			return exitConfig
+		}
+		defer stop()
+	}
+
+	if opts.ndjson != "" {
+		target, err := openNDJSONTarget(opts.ndjson)
+		if err != nil {
+			logError(err, tr("Tujuan -ndjson %s tidak valid", opts.ndjson))
+			return exitConfig
+		}
+		defer target.close()
+		ndjsonOut = target
+	}
+	if opts.upload != "" && !opts.stdout {
+		target, err := newS3Target(opts.upload)
+		if err != nil {
+			logError(err, tr("Tujuan -upload %s tidak valid", opts.upload))
+			return exitConfig
+		}
+		s3Upload = target
+	}
+
+	if flag.Arg(0) == "serve" {
+		return runServer(ctx, excelDir, sqlDir, sqlDataDir)
+	}
+
+	if opts.watch {
+		return runWatch(ctx, excelDir, sqlDir, sqlDataDir)
+	}
+
+	if schedule != nil {
+		return runSchedule(ctx, schedule, excelDir, sqlDir, sqlDataDir)
+	}
+
+	code := runPipeline(ctx, excelDir, sqlDir, sqlDataDir, true)
+	sftpInput.moveProcessed()
+	if code == exitOK {
+		logRun(tr("Program selesai bekerja."))
+	}
+	notifyRun(code)
+	return code
+}
+
+// runPipeline mengonversi file-file Excel lalu membuat tabel dan memuat data ke
+// database. Bila interactive false, pertanyaan konfirmasi dilewati dan dianggap ya.
+// Mengembalikan kode keluar program.
+func runPipeline(ctx context.Context, excelDir, sqlDir, sqlDataDir string, interactive bool) int {
+	paths, err := inputPaths(excelDir)
+	if err != nil {
+		logError(err, tr("Error membaca direktori xlsx"))
+		return exitConfig
+	}
+	if first, second, table := tableCollision(paths); first != "" {
+		err := errors.New(tr("File %s dan %s menghasilkan tabel yang sama (%s)", first, second, table))
+		logError(err, tr("Ganti nama salah satu file atau atur kolom table pada manifest"))
+		return exitConfig
+	}
+
+	mu.Lock()
+	fileStatus = make(map[string]string)
+	mu.Unlock()
+	currentReport = newRunReport()
+	defer currentReport.write()
+	resetRunErrors()
+	totalFiles = len(paths)
+	sem := make(chan struct{}, max(opts.workers, 1))
+
+	if opts.prescan {
+		paths = prescanFiles(paths)
+	}
+
+	logRun(tr("Mulai memproses file-file Excel."))
+	dispatchFiles(ctx, paths, sem, sqlDir, sqlDataDir)
+	wg.Wait()
+	if ctx.Err() != nil {
+		logShutdownSummary()
+		return exitInterrupted
+	}
+	logRun(tr("Selesai memproses file-file Excel."))
+	fmt.Println(tr("Proses selesai."))
+	code := exitOK
+	if conversionFailed() {
+		code = exitPartial
+	}
+	if opts.stdout {
+		return code
+	}
+	if dialect := warehouseDialect(); dialect != "" {
+		// Tabel dan data dimuat ke database tujuan dengan perintah COPY
+		fmt.Println(tr("File untuk %s ditulis di SQLTable dan SQLData; jalankan file SQLTable pada database tujuan.", dialect))
+		return code
+	}
+
+	/* proses pembuatan tabel database */
+	if interactive && !askContinue(tr("Apakah akan melanjutkan membuat tabel atau tabel-tabel di database? (Ya/Tidak, default Ya): ")) {
+		fmt.Println(tr("Program dihentikan."))
+		return code
+	}
+
+	if _, err := os.Stat(dbConfigPath); os.IsNotExist(err) {
+		fmt.Println(tr("File konfigurasi database tidak ditemukan. Membuat file db.cfg..."))
+		err := createDefaultDBConfig()
+		if err != nil {
+			logError(err, tr("Gagal membuat file template konfigurasi database."))
+		}
+		fmt.Println(tr("File db.cfg telah dibuat. Silakan ubah file db.cfg yang telah dibuat."))
+		logError(err, tr("File konfigurasi database tidak ditemukan dan telah dibuat."))
+		return exitConfig
+	}
+
+	dbConfig, err := readDBConfig(dbConfigPath)
+	if err != nil {
+		logError(err, tr("Gagal membaca file konfigurasi database."))
+		return exitConfig
+	}
+
+	logRun(tr("Mulai membuat koneksi ke database"))
+	// Create connection pool ...
+	targets, err := openTargets(dbConfig)
+	if err != nil {
+		logError(err, tr("Gagal membuat koneksi ke database."))
+		return exitDB
+	} else {
+		logRun(tr("Sukses membuat koneksi ke database."))
+	}
+	defer closeTargets(targets)
+	if err := lockTargets(ctx, targets); err != nil {
+		logError(err, tr("Gagal mengambil lock database"))
+		return lockExitCode(err)
+	}
+	logRun(tr("Selesai membuat koneksi ke database"))
+
+	// Process SQL Table files ...
+	for _, t := range targets {
+		if len(targets) > 1 {
+			logRun(tr("Membuat tabel pada target %s", t.name))
+		}
+		processSQLTableFiles(ctx, t, sqlDir)
+	}
+	if ctx.Err() != nil {
+		logShutdownSummary()
+		logTargetSummary(targets)
+		return exitInterrupted
+	}
+	fmt.Println(tr("Proses pembuatan tabel database telah selesai."))
+
+	/* proses pengisian data dari file-file Excel ke database */
+	if interactive && !askContinue(tr("Apakah akan melanjutkan pengisian data dari file-file Excel ke database? (Ya/Tidak, default Ya): ")) {
+		fmt.Println(tr("Program dihentikan."))
+		logTargetSummary(targets)
+		return code
+	}
+	// Process SQL Data files ...
+	//processSQLDataFiles(db, sqlDataDir)
+	for _, t := range targets {
+		if len(targets) > 1 {
+			logRun(tr("Mengisi data pada target %s", t.name))
+		}
+		processSQLDataFiles(ctx, t)
+	}
+	if (opts.verify || opts.replica != "") && ctx.Err() == nil {
+		verifyTargets(ctx, targets)
+	}
+	if opts.indexAdvisor != "" && ctx.Err() == nil {
+		adviseIndexes(ctx, targets)
+	}
+	if ctx.Err() != nil {
+		logShutdownSummary()
+		logTargetSummary(targets)
+		return exitInterrupted
+	}
+	fmt.Println(tr("Proses pengisian data dari file-file Excel ke database telah selesai."))
+	logTargetSummary(targets)
+	for _, t := range targets {
+		if t.tablesFailed > 0 || t.filesFailed > 0 {
+			code = exitPartial
+		}
+	}
+	return code
+}
+
+// runReport merangkum satu run untuk report.json dan report.html.
+type runReport struct {
+	mu       sync.Mutex
+	RunID    string         `json:"run_id"`
+	Started  time.Time      `json:"started"`
+	Finished time.Time      `json:"finished"`
+	Duration string         `json:"duration"`
+	Files    []fileReport   `json:"files"`
+	Tables   []*tableReport `json:"tables"`
+}
+
+type fileReport struct {
+	File     string `json:"file"`
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+}
+
+type tableReport struct {
+	Table   string         `json:"table"`
+	Source  string         `json:"source"`
+	Rows    int            `json:"rows"`
+	Columns []columnReport `json:"columns"`
+	// CreatedOn dan LoadedRows dicatat per target database
+	CreatedOn  []string       `json:"created_on,omitempty"`
+	LoadedRows map[string]int `json:"loaded_rows,omitempty"`
+
+	// Rejected berisi sel yang ditolak saat pemuatan beserta saran perbaikan
+	Rejected []rejectionReport `json:"rejected,omitempty"`
+
+	// Warnings berisi peringatan skema dari schemaWarnings
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+type rejectionReport struct {
+	Target     string                `json:"target"`
+	Cell       string                `json:"cell"`
+	Column     string                `json:"column"`
+	Value      string                `json:"value"`
+	Error      string                `json:"error"`
+	Suggestion *inference.Suggestion `json:"suggestion,omitempty"`
+}
+
+type columnReport struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Comment string `json:"comment"`
+}
+
+var currentReport *runReport
+
+func newRunReport() *runReport {
+	return &runReport{RunID: runID, Started: time.Now()}
+}
+
+func (r *runReport) addFile(path, status string, duration time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Files = append(r.Files, fileReport{File: path, Status: status, Duration: duration.Round(time.Millisecond).String()})
+}
+
+func (r *runReport) addTable(tableName, source string, rows int, header, columnTypes []string) {
+	if r == nil {
+		return
+	}
+	columns := make([]columnReport, len(header))
+	for i, colCell := range header {
+		columns[i] = columnReport{Name: tableNaming().Column(colCell), Type: columnTypes[i], Comment: colCell}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Tables = append(r.Tables, &tableReport{Table: tableName, Source: source, Rows: rows, Columns: columns})
+}
+
+// table mencari atau menambah tabel, misalnya tabel yang dikonversi pada run sebelumnya.
+func (r *runReport) table(tableName string) *tableReport {
+	for _, t := range r.Tables {
+		if t.Table == tableName {
+			return t
+		}
+	}
+	t := &tableReport{Table: tableName}
+	r.Tables = append(r.Tables, t)
+	return t
+}
+
+func (r *runReport) tableWarnings(tableName string, warnings []string) {
+	if r == nil || len(warnings) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t := r.table(tableName)
+	t.Warnings = append(t.Warnings, warnings...)
+}
+
+func (r *runReport) tableCreated(tableName, target string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t := r.table(tableName)
+	t.CreatedOn = append(t.CreatedOn, target)
+}
+
+func (r *runReport) rowsLoaded(tableName, target string, rows int) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t := r.table(tableName)
+	if t.LoadedRows == nil {
+		t.LoadedRows = make(map[string]int)
+	}
+	t.LoadedRows[target] += rows
+}
+
+func (r *runReport) rowRejected(tableName, target string, rejection *rowRejection) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	t := r.table(tableName)
+	t.Rejected = append(t.Rejected, rejectionReport{
+		Target:     target,
+		Cell:       rejection.position,
+		Column:     rejection.column,
+		Value:      rejection.value,
+		Error:      rejection.err.Error(),
+		Suggestion: rejection.suggestion,
+	})
+}
+
+// write menulis report.json dan report.html ke direktori -report. Salinan
+// report.json juga disimpan sebagai runs/<run id>.json untuk perintah runs.
+func (r *runReport) write() {
+	if r == nil || opts.report == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Finished = time.Now()
+	r.Duration = r.Finished.Sub(r.Started).Round(time.Millisecond).String()
+
+	if err := os.MkdirAll(opts.report, 0755); err != nil {
+		logError(err, tr("Gagal menulis laporan run ke %s", opts.report))
+		return
+	}
+	content, err := json.MarshalIndent(r, "", "  ")
+	if err == nil {
+		err = writeFileAtomic(filepath.Join(opts.report, "report.json"), string(content))
+	}
+	if err == nil {
+		err = writeFileAtomic(filepath.Join(opts.report, "report.html"), r.html())
+	}
+	if err == nil {
+		err = os.MkdirAll(runsDir(), 0755)
+	}
+	if err == nil {
+		err = writeFileAtomic(filepath.Join(runsDir(), r.RunID+".json"), string(content))
+	}
+	if err != nil {
+		logError(err, tr("Gagal menulis laporan run ke %s", opts.report))
+	}
+}
+
+func (r *runReport) html() string {
+	var b strings.Builder
+	esc := html.EscapeString
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>xlsx2mariadb %s</title>\n", esc(r.RunID))
+	b.WriteString("<style>body{font-family:sans-serif}table{border-collapse:collapse;margin-bottom:1em}th,td{border:1px solid #ccc;padding:2px 6px;text-align:left}</style>\n</head>\n<body>\n")
+	fmt.Fprintf(&b, "<h1>Run %s</h1>\n<p>%s &ndash; %s (%s)</p>\n", esc(r.RunID), r.Started.Format("2006-01-02 15:04:05"), r.Finished.Format("2006-01-02 15:04:05"), esc(r.Duration))
+
+	counts := make(map[string]int)
+	for _, f := range r.Files {
+		counts[f.Status]++
+	}
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	b.WriteString("<h2>File</h2>\n<p>")
+	for i, status := range statuses {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s: %d", esc(status), counts[status])
+	}
+	b.WriteString("</p>\n<table>\n<tr><th>File</th><th>Status</th><th>Durasi</th></tr>\n")
+	for _, f := range r.Files {
+		fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s</td></tr>\n", esc(f.File), esc(f.Status), esc(f.Duration))
+	}
+	b.WriteString("</table>\n<h2>Tabel</h2>\n")
+	for _, t := range r.Tables {
+		fmt.Fprintf(&b, "<h3>%s</h3>\n<p>", esc(t.Table))
+		if t.Source != "" {
+			fmt.Fprintf(&b, "%s, %d baris", esc(t.Source), t.Rows)
+		}
+		for _, target := range t.CreatedOn {
+			fmt.Fprintf(&b, "<br>dibuat pada %s", esc(target))
+		}
+		targets := make([]string, 0, len(t.LoadedRows))
+		for target := range t.LoadedRows {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+		for _, target := range targets {
+			fmt.Fprintf(&b, "<br>%d baris dimuat ke %s", t.LoadedRows[target], esc(target))
+		}
+		b.WriteString("</p>\n")
+		if len(t.Columns) > 0 {
+			b.WriteString("<table>\n<tr><th>Kolom</th><th>Tipe</th><th>Header Excel</th></tr>\n")
+			for _, c := range t.Columns {
+				fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s</td></tr>\n", esc(c.Name), esc(c.Type), esc(c.Comment))
+			}
+			b.WriteString("</table>\n")
+		}
+		if len(t.Rejected) > 0 {
+			b.WriteString("<table>\n<tr><th>Sel ditolak</th><th>Kolom</th><th>Nilai</th><th>Saran</th><th>Target</th><th>Kesalahan</th></tr>\n")
+			for _, rej := range t.Rejected {
+				suggestion := ""
+				if rej.Suggestion != nil {
+					suggestion = rej.Suggestion.String()
+				}
+				fmt.Fprintf(&b, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td></tr>\n",
+					esc(rej.Cell), esc(rej.Column), esc(rej.Value), esc(suggestion), esc(rej.Target), esc(rej.Error))
+			}
+			b.WriteString("</table>\n")
+		}
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// runsDir mengembalikan direktori riwayat laporan run di bawah -report.
+func runsDir() string {
+	return filepath.Join(opts.report, "runs")
+}
+
+// readRunReports membaca semua laporan run dari runsDir, terbaru lebih dulu.
+func readRunReports() ([]*runReport, error) {
+	files, err := filepath.Glob(filepath.Join(runsDir(), "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var reports []*runReport
+	for _, file := range files {
+		report, err := readRunReportFile(file)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Started.After(reports[j].Started)
+	})
+	return reports, nil
+}
+
+// readRunReport membaca laporan run id dari runsDir.
+func readRunReport(id string) (*runReport, error) {
+	if !filepath.IsLocal(id) || strings.ContainsAny(id, `/\`) {
+		return nil, errors.New(tr("run %q tidak ditemukan", id))
+	}
+	report, err := readRunReportFile(filepath.Join(runsDir(), id+".json"))
+	if os.IsNotExist(err) {
+		return nil, errors.New(tr("run %q tidak ditemukan", id))
+	}
+	return report, err
+}
+
+func readRunReportFile(path string) (*runReport, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	report := &runReport{}
+	if err := json.Unmarshal(content, report); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return report, nil
+}
+
+// failedFiles menghitung file r yang gagal diproses.
+func (r *runReport) failedFiles() int {
+	failed := 0
+	for _, f := range r.Files {
+		if isFailedStatus(f.Status) {
+			failed++
+		}
+	}
+	return failed
+}
+
+// loaded mengembalikan jumlah baris yang dimuat ke semua target database.
+func (t *tableReport) loaded() int {
+	rows := 0
+	for _, n := range t.LoadedRows {
+		rows += n
+	}
+	return rows
+}
+
+// runRuns menjalankan perintah runs: list menampilkan riwayat run dari
+// laporan yang disimpan di runs/, show menampilkan file dan tabel satu run,
+// dan diff membandingkan dua run.
+func runRuns(args []string) int {
+	var err error
+	switch {
+	case len(args) == 1 && args[0] == "list":
+		err = listRuns()
+	case len(args) == 2 && args[0] == "show":
+		err = showRun(args[1])
+	case len(args) == 3 && args[0] == "diff":
+		err = diffRuns(args[1], args[2])
+	default:
+		fmt.Println(tr("Penggunaan: xlsx2mariadb [-report DIR] runs list | show <run id> | diff <run id> <run id>"))
+		return exitConfig
+	}
+	if err != nil {
+		logError(err, tr("Gagal membaca riwayat run di %s", runsDir()))
+		return exitConfig
+	}
+	return exitOK
+}
+
+func listRuns() error {
+	reports, err := readRunReports()
+	if err != nil {
+		return err
+	}
+	if len(reports) == 0 {
+		fmt.Println(tr("Belum ada run yang tercatat di %s.", runsDir()))
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, tr("RUN\tMULAI\tDURASI\tFILE\tGAGAL\tTABEL\tBARIS"))
+	for _, r := range reports {
+		rows := 0
+		for _, t := range r.Tables {
+			rows += t.Rows
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\t%d\n", r.RunID, r.Started.Format("2006-01-02 15:04:05"), r.Duration, len(r.Files), r.failedFiles(), len(r.Tables), rows)
+	}
+	return w.Flush()
+}
+
+func showRun(id string) error {
+	r, err := readRunReport(id)
+	if err != nil {
+		return err
+	}
+	fmt.Println(tr("Run %s: %s - %s (%s)", r.RunID, r.Started.Format("2006-01-02 15:04:05"), r.Finished.Format("2006-01-02 15:04:05"), r.Duration))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, tr("FILE\tSTATUS\tDURASI"))
+	for _, f := range r.Files {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.File, f.Status, f.Duration)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, tr("TABEL\tSUMBER\tBARIS\tDIMUAT\tDITOLAK"))
+	for _, t := range r.Tables {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", t.Table, t.Source, t.Rows, t.loaded(), len(t.Rejected))
+	}
+	return w.Flush()
+}
+
+// diffRuns menampilkan perbedaan run b terhadap run a: file dan tabel yang
+// baru (+) atau tidak ada lagi (-), serta status file, jumlah baris dan kolom
+// tabel yang berubah (~).
+func diffRuns(a, b string) error {
+	old, err := readRunReport(a)
+	if err != nil {
+		return err
+	}
+	cur, err := readRunReport(b)
+	if err != nil {
+		return err
+	}
+	var lines []string
+	add := func(format string, args ...interface{}) {
+		lines = append(lines, tr(format, args...))
+	}
+
+	oldFiles := make(map[string]string, len(old.Files))
+	for _, f := range old.Files {
+		oldFiles[f.File] = f.Status
+	}
+	curFiles := make(map[string]bool, len(cur.Files))
+	for _, f := range cur.Files {
+		curFiles[f.File] = true
+		status, ok := oldFiles[f.File]
+		switch {
+		case !ok:
+			add("+ file %s (%s)", f.File, f.Status)
+		case status != f.Status:
+			add("~ file %s: %s -> %s", f.File, status, f.Status)
+		}
+	}
+	for _, f := range old.Files {
+		if !curFiles[f.File] {
+			add("- file %s (%s)", f.File, f.Status)
+		}
+	}
+
+	oldTables := make(map[string]*tableReport, len(old.Tables))
+	for _, t := range old.Tables {
+		oldTables[t.Table] = t
+	}
+	curTables := make(map[string]bool, len(cur.Tables))
+	for _, t := range cur.Tables {
+		curTables[t.Table] = true
+		prev, ok := oldTables[t.Table]
+		if !ok {
+			add("+ tabel %s (%d baris)", t.Table, t.Rows)
+			continue
+		}
+		if prev.Rows != t.Rows {
+			add("~ tabel %s: %d -> %d baris (%+d)", t.Table, prev.Rows, t.Rows, t.Rows-prev.Rows)
+		}
+		if prev.loaded() != t.loaded() {
+			add("~ tabel %s: %d -> %d baris dimuat (%+d)", t.Table, prev.loaded(), t.loaded(), t.loaded()-prev.loaded())
+		}
+		// Kolom hanya dibandingkan bila kedua run mengonversi tabel ini
+		if len(prev.Columns) == 0 || len(t.Columns) == 0 {
+			continue
+		}
+		prevTypes := make(map[string]string, len(prev.Columns))
+		for _, c := range prev.Columns {
+			prevTypes[c.Name] = c.Type
+		}
+		curTypes := make(map[string]bool, len(t.Columns))
+		for _, c := range t.Columns {
+			curTypes[c.Name] = true
+			columnType, ok := prevTypes[c.Name]
+			switch {
+			case !ok:
+				add("~ tabel %s: kolom baru %s %s", t.Table, c.Name, c.Type)
+			case columnType != c.Type:
+				add("~ tabel %s: tipe kolom %s %s -> %s", t.Table, c.Name, columnType, c.Type)
+			}
+		}
+		for _, c := range prev.Columns {
+			if !curTypes[c.Name] {
+				add("~ tabel %s: kolom %s dihapus", t.Table, c.Name)
+			}
+		}
+	}
+	for _, t := range old.Tables {
+		if !curTables[t.Table] {
+			add("- tabel %s (%d baris)", t.Table, t.Rows)
+		}
+	}
+
+	fmt.Println(tr("Perbedaan run %s terhadap run %s:", cur.RunID, old.RunID))
+	if len(lines) == 0 {
+		fmt.Println(tr("Tidak ada perbedaan."))
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// runErrors menyimpan error run yang sedang berjalan untuk dilampirkan pada notifikasi.
+var (
+	runErrors   []string
+	runErrorsMu sync.Mutex
+)
+
+// Batas jumlah error yang disimpan agar lampiran tetap kecil
+const maxRunErrors = 1000
+
+func recordRunError(entry string) {
+	runErrorsMu.Lock()
+	defer runErrorsMu.Unlock()
+	if len(runErrors) < maxRunErrors {
+		runErrors = append(runErrors, entry)
+	}
+}
+
+func resetRunErrors() {
+	runErrorsMu.Lock()
+	runErrors = nil
+	runErrorsMu.Unlock()
+}
+
+// runSummary merangkum hasil run untuk notifikasi.
+func runSummary(code int) string {
+	var b strings.Builder
+	if code == exitOK {
+		fmt.Fprintln(&b, tr("Run %s selesai dengan sukses.", runID))
+	} else {
+		fmt.Fprintln(&b, tr("Run %s selesai dengan kode keluar %d.", runID, code))
+	}
+
+	r := currentReport
+	if r == nil {
+		return b.String()
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	counts := make(map[string]int)
+	for _, f := range r.Files {
+		counts[f.Status]++
+	}
+	statuses := make([]string, 0, len(counts))
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	for _, status := range statuses {
+		fmt.Fprintln(&b, tr("File %s: %d", status, counts[status]))
+	}
+	var loaded int
+	for _, t := range r.Tables {
+		for _, rows := range t.LoadedRows {
+			loaded += rows
+		}
+	}
+	fmt.Fprintln(&b, tr("Tabel: %d, baris dimuat: %d", len(r.Tables), loaded))
+	runErrorsMu.Lock()
+	fmt.Fprintln(&b, tr("Error: %d", len(runErrors)))
+	runErrorsMu.Unlock()
+	return b.String()
+}
+
+// notifyRun mengirim ringkasan run lewat email bila SMTP dikonfigurasi dan
+// ke webhook bila -webhook-url diisi.
+func notifyRun(code int) {
+	if opts.smtpHost != "" && opts.smtpTo != "" && (opts.smtpOn != "failure" || code != exitOK) {
+		if err := sendRunEmail(code); err != nil {
+			logError(err, tr("Gagal mengirim email ringkasan run"))
+		}
+	}
+	// Notifikasi file gagal dikirim lebih dulu agar urutannya di kanal tetap
+	webhookWG.Wait()
+	if opts.webhookURL != "" && (opts.webhookOn != "failure" || code != exitOK) {
+		payload := map[string]interface{}{
+			"text":      "xlsx2mariadb: " + runSummary(code),
+			"run_id":    runID,
+			"exit_code": code,
+		}
+		if err := postWebhook(payload); err != nil {
+			logError(err, tr("Gagal mengirim ringkasan run ke webhook"))
+		}
+	}
+}
+
+// webhookWG menunggu notifikasi file gagal yang masih dikirim di latar belakang.
+var webhookWG sync.WaitGroup
+
+// notifyFileFailure mengirim notifikasi file Excel yang gagal dikonversi ke webhook.
+func notifyFileFailure(path, status string) {
+	notifyFailure(path, map[string]interface{}{
+		"text":   tr("xlsx2mariadb run %s: file %s gagal (%s)", runID, filepath.Base(path), status),
+		"run_id": runID,
+		"file":   path,
+		"status": status,
+	})
+}
+
+// notifyLoadFailure mengirim notifikasi tabel yang gagal dibuat atau dimuat ke webhook.
+func notifyLoadFailure(target, tableName string) {
+	notifyFailure(tableName, map[string]interface{}{
+		"text":   tr("xlsx2mariadb run %s: tabel %s gagal dimuat ke %s", runID, tableName, target),
+		"run_id": runID,
+		"table":  tableName,
+		"target": target,
+		"status": "error",
+	})
+}
+
+// notifyFailure mengirim payload ke webhook di latar belakang agar pemrosesan
+// file lain tidak tertahan.
+func notifyFailure(name string, payload map[string]interface{}) {
+	if opts.webhookURL == "" {
+		return
+	}
+	webhookWG.Add(1)
+	go func() {
+		defer webhookWG.Done()
+		if err := postWebhook(payload); err != nil {
+			// logError tidak dipakai agar kegagalan webhook tidak memicu notifikasi lagi
+			logEntry := fmt.Sprintf("%s: %s: %v\n", time.Now().Format(time.RFC3339), tr("Gagal mengirim notifikasi %s ke webhook", name), err)
+			writeLog("error.log", logEntry)
+		}
+	}()
+}
+
+// postWebhook mengirim payload JSON ke -webhook-url. Field text dibaca oleh
+// Slack incoming webhook dan layanan yang kompatibel, field lain untuk
+// penerima yang mengolah data terstruktur.
+func postWebhook(payload map[string]interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Post(opts.webhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func sendRunEmail(code int) error {
+	var to []string
+	for _, addr := range strings.Split(opts.smtpTo, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			to = append(to, addr)
+		}
+	}
+	from := opts.smtpFrom
+	if from == "" {
+		from = opts.smtpUser
+	}
+	status := tr("sukses")
+	if code != exitOK {
+		status = tr("gagal (kode %d)", code)
+	}
+
+	var msg bytes.Buffer
+	mw := multipart.NewWriter(&msg)
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&msg, "Subject: xlsx2mariadb %s: %s\r\n", runID, status)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", mw.Boundary())
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}})
+	if err != nil {
+		return err
+	}
+	io.WriteString(part, strings.ReplaceAll(runSummary(code), "\n", "\r\n"))
+
+	runErrorsMu.Lock()
+	errorLog := strings.Join(runErrors, "\n")
+	runErrorsMu.Unlock()
+	if errorLog != "" {
+		part, err = mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {"text/plain; charset=utf-8"},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {`attachment; filename="error.log"`},
+		})
+		if err != nil {
+			return err
+		}
+		encoded := base64.StdEncoding.EncodeToString([]byte(errorLog))
+		for len(encoded) > 76 {
+			io.WriteString(part, encoded[:76]+"\r\n")
+			encoded = encoded[76:]
+		}
+		io.WriteString(part, encoded+"\r\n")
+	}
+	if err := mw.Close(); err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if opts.smtpUser != "" {
+		auth = smtp.PlainAuth("", opts.smtpUser, opts.smtpPassword, opts.smtpHost)
+	}
+	addr := fmt.Sprintf("%s:%d", opts.smtpHost, opts.smtpPort)
+	return smtp.SendMail(addr, auth, from, to, msg.Bytes())
+}
+
+// inputFiles adalah file Excel yang diberikan sebagai argumen program. Bila
+// kosong, semua file .xlsx di direktori xlsx diproses.
+var inputFiles []string
+
+// inputPaths mengembalikan path absolut file-file Excel yang akan diproses.
+// File yang disebutkan lebih dari sekali (di Windows tanpa membedakan huruf
+// besar/kecil) hanya diproses sekali.
+func inputPaths(excelDir string) ([]string, error) {
+	if len(inputFiles) > 0 {
+		paths := make([]string, 0, len(inputFiles))
+		for _, file := range inputFiles {
+			path, err := winpath.Resolve("", file)
+			if err != nil {
+				return nil, err
+			}
+			if _, err := os.Stat(path); err != nil {
+				return nil, err
+			}
+			paths = append(paths, path)
+		}
+		paths, duplicates := winpath.Dedup(paths)
+		for _, path := range duplicates {
+			logRun(tr("File %s disebutkan lebih dari sekali, hanya diproses sekali", path))
+		}
+		return paths, nil
+	}
+	if opts.include != "" || opts.exclude != "" {
+		return walkInputPaths(excelDir)
+	}
+
+	files, err := os.ReadDir(excelDir)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, file := range files {
+		if isWorkbookFile(file.Name()) {
+			path, err := winpath.Resolve(excelDir, file.Name())
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// walkInputPaths menelusuri excelDir sesuai -include dan -exclude. Path
+// dicocokkan relatif terhadap excelDir dengan pemisah /. Tanpa -include hanya
+// workbook langsung di excelDir yang diproses seperti biasa; dengan -include
+// file input apa pun yang cocok diproses, termasuk di subdirektori. Direktori
+// yang cocok dengan -exclude dilewati seluruhnya.
+func walkInputPaths(excelDir string) ([]string, error) {
+	include, exclude := globPatterns(opts.include), globPatterns(opts.exclude)
+	matchAny := func(patterns []string, name string) bool {
+		return slices.ContainsFunc(patterns, func(pattern string) bool { return matchGlob(pattern, name) })
+	}
+	var paths []string
+	err := filepath.WalkDir(excelDir, func(file string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(excelDir, file)
+		if err != nil || rel == "." {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if matchAny(exclude, rel) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.IsDir() {
+			if len(include) == 0 {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		if len(include) == 0 && !isWorkbookFile(entry.Name()) ||
+			len(include) > 0 && (!matchAny(include, rel) || !isInputArg(entry.Name()) || isZipFile(entry.Name())) {
+			return nil
+		}
+		path, err := winpath.Resolve(excelDir, file)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	return paths, err
+}
+
+// globPatterns memecah daftar pola glob yang dipisahkan koma.
+func globPatterns(list string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(list, ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// matchGlob mencocokkan path relatif name (dipisahkan /) dengan pattern
+// seperti path.Match, ditambah segmen ** yang cocok dengan nol atau lebih
+// direktori, misalnya **/*.xlsx cocok dengan a.xlsx dan 2024/01/a.xlsx.
+func matchGlob(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// tableCollision mengembalikan dua file yang menghasilkan nama tabel yang
+// sama. Nama tabel dibandingkan tanpa membedakan huruf besar/kecil karena
+// MariaDB di Windows (lower_case_table_names=1) menyimpan Penjualan dan
+// penjualan sebagai tabel yang sama, dan file SQL keduanya saling menimpa.
+func tableCollision(paths []string) (first, second, table string) {
+	seen := make(map[string]string, len(paths))
+	for _, path := range paths {
+		table := tableNameFor(path)
+		key := strings.ToLower(table)
+		if other, ok := seen[key]; ok {
+			return other, path, table
+		}
+		seen[key] = path
+	}
+	return "", "", ""
+}
+
+// manifestEntry adalah satu baris manifest. Kolom yang kosong memakai nilai default.
+type manifestEntry struct {
+	File      string `json:"file"`
+	Table     string `json:"table"`
+	Sheet     string `json:"sheet"`
+	HeaderRow int    `json:"header_row"`
+	// HeaderRows menggantikan -header-rows untuk header bertingkat
+	HeaderRows int `json:"header_rows"`
+	// SkipRows menggantikan -skip-rows bila diisi, juga dengan 0
+	SkipRows *int `json:"skip_rows"`
+	// Range menggantikan -range: rentang sel atau nama yang terdefinisi
+	Range string `json:"range"`
+	// Mode: append (default) menambah data ke tabel, replace menghapus tabel
+	// lama sebelum dibuat ulang, skip tidak memproses file
+	Mode string `json:"mode"`
+	// Priority: file dengan prioritas lebih tinggi dikerjakan lebih dulu di
+	// antara file yang tidak saling bergantung
+	Priority int `json:"priority"`
+	// After: nama tabel atau file lain pada manifest yang harus selesai
+	// dikonversi dan dimuat lebih dulu, misalnya data referensi sebelum data transaksi
+	After []string `json:"after"`
+	// Headers adalah kontrak header file berulang: nama kolom dan urutannya
+	// harus sama persis, bila tidak file gagal sebelum dikonversi
+	Headers []string `json:"headers"`
+	// XMLRows menggantikan -xml-rows untuk file XML ini
+	XMLRows string `json:"xml_rows"`
+	// Tenant menggantikan -tenants untuk file ini
+	Tenant string `json:"tenant"`
+	// Collations memetakan nama kolom atau teks header ke collation kolom,
+	// misalnya {"kode": "utf8mb4_bin"}; pada CSV ditulis kode=utf8mb4_bin
+	// dipisahkan titik koma
+	Collations map[string]string `json:"collations"`
+	// Encrypt menggantikan -encrypt-columns untuk file ini: nama kolom atau
+	// teks header yang disimpan terenkripsi; pada CSV dipisahkan titik koma
+	Encrypt []string `json:"encrypt"`
+	// Generated adalah definisi kolom turunan (ddl.ParseGenerated), misalnya
+	// "bulan VARCHAR(7) AS DATE_FORMAT(tanggal, '%Y-%m') STORED INDEX"; pada
+	// CSV dipisahkan titik koma
+	Generated []string `json:"generated"`
+	// Engine, RowFormat, KeyBlockSize dan TableOptions menggantikan -engine,
+	// -row-format, -key-block-size dan -table-options untuk tabel ini
+	Engine       string `json:"engine"`
+	RowFormat    string `json:"row_format"`
+	KeyBlockSize int    `json:"key_block_size"`
+	TableOptions string `json:"table_options"`
+}
+
+// manifestEntries memetakan path absolut file (winpath.Key) ke opsinya pada manifest.
+var manifestEntries = make(map[string]manifestEntry)
+
+func manifestEntryFor(path string) (manifestEntry, bool) {
+	entry, ok := manifestEntries[winpath.Key(path)]
+	return entry, ok
+}
+
+// readManifest membaca manifest JSON (array objek) atau CSV (baris pertama
+// berisi nama kolom file,table,sheet,header_row,mode) dan mengembalikan daftar
+// file yang diproses. Path relatif dihitung dari direktori manifest. Kolom
+// after dan headers pada CSV dipisahkan titik koma.
+func readManifest(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []manifestEntry
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		if err := json.Unmarshal(content, &entries); err != nil {
+			return nil, err
+		}
+	} else {
+		records, err := csv.NewReader(bytes.NewReader(content)).ReadAll()
+		if err != nil {
+			return nil, err
+		}
+		if len(records) == 0 {
+			return nil, nil
+		}
+		header := records[0]
+		for _, record := range records[1:] {
+			var entry manifestEntry
+			for i, value := range record {
+				if i >= len(header) {
+					break
+				}
+				value = strings.TrimSpace(value)
+				switch strings.ToLower(strings.TrimSpace(header[i])) {
+				case "file":
+					entry.File = value
+				case "table":
+					entry.Table = value
+				case "sheet":
+					entry.Sheet = value
+				case "range":
+					entry.Range = value
+				case "header_row":
+					if value != "" {
+						if entry.HeaderRow, err = strconv.Atoi(value); err != nil {
+							return nil, errors.New(tr("header_row %q tidak valid untuk %s", value, entry.File))
+						}
+					}
+				case "header_rows":
+					if value != "" {
+						if entry.HeaderRows, err = strconv.Atoi(value); err != nil {
+							return nil, errors.New(tr("header_rows %q tidak valid untuk %s", value, entry.File))
+						}
+					}
+				case "skip_rows":
+					if value != "" {
+						skip, err := strconv.Atoi(value)
+						if err != nil {
+							return nil, errors.New(tr("skip_rows %q tidak valid untuk %s", value, entry.File))
+						}
+						entry.SkipRows = &skip
+					}
+				case "mode":
+					entry.Mode = value
+				case "priority":
+					if value != "" {
+						if entry.Priority, err = strconv.Atoi(value); err != nil {
+							return nil, errors.New(tr("priority %q tidak valid untuk %s", value, entry.File))
+						}
+					}
+				case "engine":
+					entry.Engine = value
+				case "row_format":
+					entry.RowFormat = value
+				case "key_block_size":
+					if value != "" {
+						if entry.KeyBlockSize, err = strconv.Atoi(value); err != nil {
+							return nil, errors.New(tr("key_block_size %q tidak valid untuk %s", value, entry.File))
+						}
+					}
+				case "table_options":
+					entry.TableOptions = value
+				case "after":
+					for _, dep := range strings.Split(value, ";") {
+						if dep = strings.TrimSpace(dep); dep != "" {
+							entry.After = append(entry.After, dep)
+						}
+					}
+				case "xml_rows":
+					entry.XMLRows = value
+				case "tenant":
+					entry.Tenant = value
+				case "collations":
+					for _, pair := range strings.Split(value, ";") {
+						column, collation, ok := strings.Cut(pair, "=")
+						if !ok {
+							continue
+						}
+						if entry.Collations == nil {
+							entry.Collations = make(map[string]string)
+						}
+						entry.Collations[strings.TrimSpace(column)] = strings.TrimSpace(collation)
+					}
+				case "encrypt":
+					if value != "" {
+						entry.Encrypt = strings.Split(value, ";")
+					}
+				case "generated":
+					for _, definition := range strings.Split(value, ";") {
+						if definition = strings.TrimSpace(definition); definition != "" {
+							entry.Generated = append(entry.Generated, definition)
+						}
+					}
+				case "headers":
+					if value != "" {
+						for _, name := range strings.Split(value, ";") {
+							entry.Headers = append(entry.Headers, strings.TrimSpace(name))
+						}
+					}
+				}
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	var files []string
+	// tables memetakan nama tabel dan nama file pada manifest ke nama tabelnya,
+	// untuk mengurai kolom after
+	tables := make(map[string]string)
+	resolve := func(file string) (string, error) {
+		return winpath.Resolve(filepath.Dir(path), file)
+	}
+	for _, entry := range entries {
+		if entry.File == "" {
+			return nil, errors.New(tr("baris manifest tanpa kolom file"))
+		}
+		switch entry.Mode {
+		case "", "append", "replace", "skip":
+		default:
+			return nil, errors.New(tr("mode %q tidak dikenal untuk %s", entry.Mode, entry.File))
+		}
+		if err := validateTableOptions(entry.Engine, entry.RowFormat, entry.TableOptions); err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.File, err)
+		}
+		if entry.Tenant != "" {
+			if err := validateTenant(entry.Tenant, entry.File); err != nil {
+				return nil, err
+			}
+		}
+		if entry.HeaderRows < 0 {
+			return nil, errors.New(tr("header_rows %q tidak valid untuk %s", strconv.Itoa(entry.HeaderRows), entry.File))
+		}
+		if entry.SkipRows != nil && *entry.SkipRows < 0 {
+			return nil, errors.New(tr("skip_rows %q tidak valid untuk %s", strconv.Itoa(*entry.SkipRows), entry.File))
+		}
+		for _, collation := range entry.Collations {
+			if !validIdentifier.MatchString(collation) {
+				return nil, errors.New(tr("collation %q tidak valid untuk %s", collation, entry.File))
+			}
+		}
+		for _, definition := range entry.Generated {
+			if _, err := ddl.ParseGenerated(definition); err != nil {
+				return nil, fmt.Errorf("%s: %w", entry.File, err)
+			}
+		}
+		abs, err := resolve(entry.File)
+		if err != nil {
+			return nil, err
+		}
+		manifestEntries[winpath.Key(abs)] = entry
+		tables[tableNameFor(abs)] = tableNameFor(abs)
+		tables[winpath.Key(abs)] = tableNameFor(abs)
+		// File yang dilewati tetap dapat menjadi dependensi, tabelnya dianggap sudah dimuat sebelumnya
+		if entry.Mode == "skip" {
+			continue
+		}
+		files = append(files, abs)
+	}
+
+	for _, entry := range entries {
+		abs, _ := resolve(entry.File)
+		table := tableNameFor(abs)
+		for _, dep := range entry.After {
+			depTable, ok := tables[dep]
+			if !ok {
+				depPath, _ := resolve(dep)
+				if depTable, ok = tables[winpath.Key(depPath)]; !ok {
+					return nil, errors.New(tr("dependensi %q untuk %s tidak ada pada manifest", dep, entry.File))
+				}
+			}
+			if depTable != table {
+				tableDependencies[table] = append(tableDependencies[table], depTable)
+			}
+		}
+	}
+	if cycle := dependencyCycle(); cycle != nil {
+		return nil, errors.New(tr("dependensi melingkar pada manifest: %s", strings.Join(cycle, " -> ")))
+	}
+	return files, nil
+}
+
+// tableDependencies memetakan nama tabel ke tabel-tabel yang harus selesai
+// dikonversi dan dimuat lebih dulu, dari kolom after pada manifest.
+var tableDependencies = make(map[string][]string)
+
+// dependencyCycle mengembalikan satu siklus pada tableDependencies, atau nil
+// bila dependensi membentuk DAG.
+func dependencyCycle() []string {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var stack []string
+	var visit func(table string) []string
+	visit = func(table string) []string {
+		switch state[table] {
+		case visiting:
+			for i, t := range stack {
+				if t == table {
+					return append(append([]string(nil), stack[i:]...), table)
+				}
+			}
+		case visited:
+			return nil
+		}
+		state[table] = visiting
+		stack = append(stack, table)
+		for _, dep := range tableDependencies[table] {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[table] = visited
+		return nil
+	}
+
+	names := make([]string, 0, len(tableDependencies))
+	for table := range tableDependencies {
+		names = append(names, table)
+	}
+	sort.Strings(names)
+	for _, table := range names {
+		if cycle := visit(table); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// tablePriority mengembalikan kolom priority manifest untuk tabel.
+func tablePriority(table string) int {
+	for path, entry := range manifestEntries {
+		if tableNameFor(path) == table {
+			return entry.Priority
+		}
+	}
+	return 0
+}
+
+// tableLevel adalah panjang rantai dependensi terpanjang di bawah tabel.
+// Tabel tanpa dependensi berada pada level 0.
+func tableLevel(table string, levels map[string]int) int {
+	if level, ok := levels[table]; ok {
+		return level
+	}
+	level := 0
+	for _, dep := range tableDependencies[table] {
+		if l := tableLevel(dep, levels) + 1; l > level {
+			level = l
+		}
+	}
+	levels[table] = level
+	return level
+}
+
+// sortByLoadOrder mengurutkan nama file SQL sehingga tabel dependensi dimuat
+// lebih dulu, lalu berdasarkan prioritas manifest. Urutan lain tidak berubah.
+func sortByLoadOrder(files []os.DirEntry, tableOf func(name string) string) {
+	if len(tableDependencies) == 0 && len(manifestEntries) == 0 {
+		return
+	}
+	levels := make(map[string]int)
+	sort.SliceStable(files, func(i, j int) bool {
+		a, b := tableOf(files[i].Name()), tableOf(files[j].Name())
+		if la, lb := tableLevel(a, levels), tableLevel(b, levels); la != lb {
+			return la < lb
+		}
+		return tablePriority(a) > tablePriority(b)
+	})
+}
+
+// dependencyFailed mengembalikan tabel dependensi pertama yang ada di failed.
+func dependencyFailed(table string, failed map[string]bool) (string, bool) {
+	for _, dep := range tableDependencies[table] {
+		if failed[dep] {
+			return dep, true
+		}
+	}
+	return "", false
+}
+
+// dispatchFiles menjalankan processFile untuk setiap path dengan paling banyak
+// cap(sem) file sekaligus. File baru dimulai setelah semua file dependensinya
+// selesai dikonversi; file yang dependensinya gagal dicatat dengan status
+// blocked. Di antara file yang siap, prioritas manifest lebih tinggi didahulukan.
+func dispatchFiles(ctx context.Context, paths []string, sem chan struct{}, sqlDir, sqlDataDir string) {
+	pathOf := make(map[string]string, len(paths))
+	for _, path := range paths {
+		pathOf[tableNameFor(path)] = path
+	}
+	pending := append([]string(nil), paths...)
+	sort.SliceStable(pending, func(i, j int) bool {
+		pi, _ := manifestEntryFor(pending[i])
+		pj, _ := manifestEntryFor(pending[j])
+		return pi.Priority > pj.Priority
+	})
+
+	finished := make(map[string]bool)
+	failedTables := make(map[string]bool)
+	done := make(chan string, len(paths))
+	complete := func(path string) {
+		finished[path] = true
+		mu.Lock()
+		status := fileStatus[path]
+		mu.Unlock()
+		if isFailedStatus(status) {
+			failedTables[tableNameFor(path)] = true
+		}
+	}
+
+	for len(pending) > 0 {
+		next := -1
+		for i, path := range pending {
+			ready := true
+			for _, dep := range tableDependencies[tableNameFor(path)] {
+				if depPath, ok := pathOf[dep]; ok && !finished[depPath] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			// Semua file yang tersisa menunggu file yang sedang dikonversi
+			select {
+			case path := <-done:
+				complete(path)
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+
+		path := pending[next]
+		pending = append(pending[:next], pending[next+1:]...)
+		if dep, failed := dependencyFailed(tableNameFor(path), failedTables); failed {
+			logError(errors.New(tr("tabel dependensi %s gagal", dep)), tr("File %s tidak diproses", path))
+			logProcessing(path, "blocked", 0)
+			failedTables[tableNameFor(path)] = true
+			finished[path] = true
+			continue
+		}
+		if runCheckpoint.isConverted(path) {
+			logProcessing(path, "skipped", 0)
+			finished[path] = true
+			continue
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		wg.Add(1)
+		go func() {
+			processFile(ctx, path, sem, sqlDir, sqlDataDir)
+			done <- path
+		}()
+	}
+}
+
+// tableNameFor mengembalikan nama tabel untuk file Excel: nama dari manifest
+// bila ada, selain itu nama file.
+func tableNameFor(path string) string {
+	if entry, ok := manifestEntryFor(path); ok && entry.Table != "" {
+		return tableNaming().Table(entry.Table)
+	}
+	return tableNaming().Table(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+}
+
+// tableNaming mengembalikan kebijakan -naming untuk nama tabel dan kolom.
+func tableNaming() ddl.Naming {
+	return ddl.Naming(opts.naming)
+}
+
+func isInputArg(arg string) bool {
+	ext := strings.ToLower(filepath.Ext(arg))
+	return arg == "-" || isURLArg(arg) || isZipFile(arg) || ext == ".xlsx" || ext == ".xls" || ext == ".ods" || ext == ".csv" || isJSONFile(arg) || isParquetFile(arg) || isXMLFile(arg)
+}
+
+// waitStable menunggu sampai ukuran dan waktu modifikasi path tidak berubah
+// selama -stable-window, sehingga file yang masih disalin (misalnya dari
+// jaringan atau oleh program lain) tidak dibaca setengah jadi. File yang
+// terakhir diubah lebih lama dari -stable-window lalu dianggap stabil tanpa
+// menunggu. Setelah -stable-timeout tanpa jeda stabil, errFileUnstable
+// dikembalikan.
+func waitStable(ctx context.Context, path string) error {
+	if opts.stableWindow <= 0 {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if time.Since(info.ModTime()) >= opts.stableWindow {
+		return nil
+	}
+
+	deadline := time.Now().Add(opts.stableTimeout)
+	for {
+		select {
+		case <-time.After(opts.stableWindow):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		current, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if current.Size() == info.Size() && current.ModTime().Equal(info.ModTime()) {
+			return nil
+		}
+		if opts.stableTimeout > 0 && time.Now().After(deadline) {
+			return fmt.Errorf("%w: %s", errFileUnstable, opts.stableTimeout)
+		}
+		info = current
+	}
+}
+
+// errFileUnstable menandai file yang terus berubah selama -stable-timeout.
+var errFileUnstable = errors.New("file masih berubah")
+
+// retryRead menjalankan read dan mengulanginya hingga -read-retries kali bila
+// gagal karena kesalahan yang mungkin sementara, misalnya koneksi ke share
+// SMB/NFS terputus di tengah pembacaan. Jeda antar percobaan berawal dari
+// -read-retry-delay dan dilipatgandakan setiap kali.
+func retryRead(ctx context.Context, path string, read func() error) error {
+	delay := opts.readRetryDelay
+	for attempt := 0; ; attempt++ {
+		err := read()
+		if err == nil || !isTransientReadError(err) || attempt >= opts.readRetries || ctx.Err() != nil {
+			return err
+		}
+
+		logRun(tr("Gagal membaca %s (%v), mencoba ulang (%d/%d)", path, err, attempt+1, opts.readRetries))
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return err
+		}
+		delay *= 2
+	}
+}
+
+// isTransientReadError mengembalikan false untuk kesalahan yang tidak akan
+// hilang dengan mencoba ulang: file tidak ada, akses ditolak, sheet tidak ada
+// atau file terenkripsi, JSON, XML atau Parquet yang tidak valid, header yang
+// tidak sesuai kontrak, serta sheet tanpa data dan
+// kegagalan menulis data.
+func isTransientReadError(err error) bool {
+	var sheetErr *xlsx2sql.SheetError
+	var dataErr *xlsx2sql.DataError
+	var syntaxErr *json.SyntaxError
+	var headerErr *xlsx2sql.HeaderMismatchError
+	var xmlErr *xml.SyntaxError
+	switch {
+	case errors.Is(err, os.ErrNotExist), errors.Is(err, os.ErrPermission),
+		errors.As(err, &sheetErr), errors.Is(err, excelize.ErrWorkbookFileFormat), errors.Is(err, excelize.ErrWorkbookPassword),
+		errors.Is(err, xlsx2sql.ErrNoData), errors.As(err, &dataErr),
+		errors.Is(err, xlsx2sql.ErrJSONFormat), errors.As(err, &syntaxErr),
+		errors.Is(err, xlsx2sql.ErrParquetFormat), errors.Is(err, xlsx2sql.ErrParquetUnsupported),
+		errors.As(err, &headerErr), errors.Is(err, xlsx2sql.ErrXMLRows), errors.As(err, &xmlErr),
+		errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	}
+	return true
+}
+
+// localCopy menyalin path ke direktori sementara di -tmp-dir bila path berada
+// di share jaringan (atau selalu, sesuai -copy-remote), agar file hanya dibaca
+// sekali lewat jaringan dan excelize membaca salinan lokal yang utuh. Tanpa
+// penyalinan, path dikembalikan apa adanya. Fungsi cleanup menghapus salinan.
+// Dengan -read-rate file selalu disalin dengan laju terbatas.
+func localCopy(ctx context.Context, path string) (string, func(), error) {
+	// Dengan -read-rate semua file disalin agar hanya penyalinan yang membaca sumber
+	if sourceThrottle == nil && (opts.copyRemote == "off" || opts.copyRemote != "always" && !isRemotePath(path)) {
+		return path, func() {}, nil
+	}
+
+	dir, err := os.MkdirTemp(opts.tmpDir, "xlsx2mariadb-remote-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	local := filepath.Join(dir, filepath.Base(winpath.Short(path)))
+	err = retryRead(ctx, path, func() error {
+		return copyFile(path, local)
+	})
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return local, cleanup, nil
+}
+
+// sourceThrottle membatasi laju baca file input (-read-rate), nil berarti
+// tanpa batas.
+var sourceThrottle *readThrottle
+
+// readThrottle membagi laju baca rate byte per detik ke semua worker.
+type readThrottle struct {
+	mu   sync.Mutex
+	rate float64
+
+	// next adalah saat byte berikutnya boleh dibaca
+	next time.Time
+}
+
+// wait menunggu sampai n byte yang baru dibaca sesuai dengan laju t.
+func (t *readThrottle) wait(n int) {
+	t.mu.Lock()
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	t.next = t.next.Add(time.Duration(float64(n) / t.rate * float64(time.Second)))
+	delay := t.next.Sub(now)
+	t.mu.Unlock()
+	time.Sleep(delay)
+}
+
+// throttleChunk adalah ukuran baca terbesar sekali jalan, agar jeda antar
+// pembacaan pendek dan merata.
+const throttleChunk = 64 << 10
+
+// throttledReader membaca r dengan laju yang dibatasi throttle.
+type throttledReader struct {
+	r        io.Reader
+	throttle *readThrottle
+}
+
+func (r *throttledReader) Read(p []byte) (int, error) {
+	if len(p) > throttleChunk {
+		p = p[:throttleChunk]
+	}
+	n, err := r.r.Read(p)
+	if n > 0 {
+		r.throttle.wait(n)
+	}
+	return n, err
+}
+
+// priorityEnv menandai proses yang sudah dijalankan ulang di bawah nice dan
+// ionice, agar lowerPriority tidak menjalankannya berulang kali.
+const priorityEnv = "XLSX2MARIADB_LOW_PRIORITY"
+
+// ioniceArguments mengubah nilai -ionice menjadi argumen program ionice.
+func ioniceArguments(value string) ([]string, error) {
+	class, level, hasLevel := strings.Cut(value, ":")
+	switch {
+	case value == "":
+		return nil, nil
+	case class == "idle" && !hasLevel:
+		return []string{"-c", "3"}, nil
+	case class == "best-effort" && !hasLevel:
+		return []string{"-c", "2", "-n", "7"}, nil
+	case class == "best-effort":
+		if n, err := strconv.Atoi(level); err == nil && n >= 0 && n <= 7 {
+			return []string{"-c", "2", "-n", level}, nil
+		}
+	}
+	return nil, errors.New(tr("Nilai -ionice %q tidak dikenal, gunakan idle atau best-effort[:0-7].", value))
+}
+
+// lowerPriority menjalankan ulang program yang sama di bawah nice dan ionice
+// sesuai -nice dan -ionice, sehingga prioritas berlaku untuk semua thread
+// sejak awal. PID tidak berubah karena proses digantikan dengan exec. Di
+// luar Linux, atau bila keduanya tidak diisi, tidak ada yang dilakukan.
+func lowerPriority(ioniceArgs []string) error {
+	if runtime.GOOS != "linux" || os.Getenv(priorityEnv) != "" || opts.nice == 0 && ioniceArgs == nil {
+		return nil
+	}
+	var argv []string
+	if opts.nice > 0 {
+		nice, err := exec.LookPath("nice")
+		if err != nil {
+			return err
+		}
+		argv = append(argv, nice, "-n", strconv.Itoa(opts.nice))
+	}
+	if ioniceArgs != nil {
+		ionice, err := exec.LookPath("ionice")
+		if err != nil {
+			return err
+		}
+		argv = append(append(argv, ionice), ioniceArgs...)
+	}
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	argv = append(append(argv, self), os.Args[1:]...)
+	return syscall.Exec(argv[0], argv, append(os.Environ(), priorityEnv+"=1"))
+}
+
+// copyFile menyalin src ke dst dan memastikan ukuran salinan sama dengan
+// ukuran src, sehingga salinan yang terpotong dianggap gagal.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	var source io.Reader = in
+	if sourceThrottle != nil {
+		source = &throttledReader{r: in, throttle: sourceThrottle}
+	}
+	n, err := io.Copy(out, source)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && n != info.Size() {
+		err = errors.New(tr("%s: hanya %d dari %d byte tersalin", src, n, info.Size()))
+	}
+	return err
+}
+
+// networkFilesystems adalah tipe filesystem Linux yang dianggap share jaringan.
+var networkFilesystems = map[string]bool{
+	"nfs": true, "nfs4": true, "cifs": true, "smb3": true, "smbfs": true,
+	"fuse.sshfs": true, "9p": true, "afs": true, "ceph": true, "glusterfs": true,
+}
+
+// isRemotePath mengembalikan true untuk file di share jaringan: path UNC
+// (\\server\share) di Windows, atau file pada mount NFS/SMB di Linux menurut
+// /proc/mounts. Drive jaringan yang dipetakan ke huruf drive di Windows tidak
+// dikenali, gunakan -copy-remote always untuk drive tersebut.
+func isRemotePath(path string) bool {
+	switch runtime.GOOS {
+	case "windows":
+		return strings.HasPrefix(winpath.Short(path), `\\`)
+	case "linux":
+		content, err := os.ReadFile("/proc/mounts")
+		if err != nil {
+			return false
+		}
+		mountPoint, fsType := "", ""
+		for _, line := range strings.Split(string(content), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				continue
+			}
+			// Spasi pada mount point ditulis sebagai \040
+			dir := strings.ReplaceAll(fields[1], `\040`, " ")
+			if (path == dir || strings.HasPrefix(path, strings.TrimSuffix(dir, "/")+"/")) && len(dir) >= len(mountPoint) {
+				mountPoint, fsType = dir, fields[2]
+			}
+		}
+		return networkFilesystems[fsType]
+	}
+	return false
+}
+
+// googleSheetsScope adalah scope OAuth baca-saja untuk Sheets API.
+const googleSheetsScope = "https://www.googleapis.com/auth/spreadsheets.readonly"
+
+// spreadsheetIDPattern mengambil ID spreadsheet dari URL Google Sheets,
+// misalnya https://docs.google.com/spreadsheets/d/<ID>/edit.
+var spreadsheetIDPattern = regexp.MustCompile(`/spreadsheets/d/([a-zA-Z0-9_-]+)`)
+
+// gsheetSpreadsheet adalah bagian respons spreadsheets.get Sheets API v4 yang
+// dipakai.
+type gsheetSpreadsheet struct {
+	Properties struct {
+		Title string `json:"title"`
+	} `json:"properties"`
+	Sheets []struct {
+		Properties struct {
+			Title  string `json:"title"`
+			Hidden bool   `json:"hidden"`
+		} `json:"properties"`
+		Data []struct {
+			RowData []struct {
+				Values []gsheetCell `json:"values"`
+			} `json:"rowData"`
+		} `json:"data"`
+	} `json:"sheets"`
+}
+
+type gsheetCell struct {
+	EffectiveValue *struct {
+		NumberValue *float64 `json:"numberValue"`
+		StringValue *string  `json:"stringValue"`
+		BoolValue   *bool    `json:"boolValue"`
+	} `json:"effectiveValue"`
+	FormattedValue  string `json:"formattedValue"`
+	EffectiveFormat struct {
+		NumberFormat struct {
+			Type string `json:"type"`
+		} `json:"numberFormat"`
+	} `json:"effectiveFormat"`
+}
+
+// value mengembalikan nilai sel untuk ditulis ke xlsx. Angka berformat
+// tanggal atau waktu (nomor seri sejak 30 Desember 1899 seperti Excel)
+// ditulis sebagai teks tanggal agar dikenali inferensi, dan sel error seperti
+// #N/A ditulis sebagai teks tampilannya.
+func (c gsheetCell) value() interface{} {
+	v := c.EffectiveValue
+	switch {
+	case v == nil:
+		return nil
+	case v.NumberValue != nil:
+		t := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).Add(time.Duration(math.Round(*v.NumberValue*86400)) * time.Second)
+		switch c.EffectiveFormat.NumberFormat.Type {
+		case "DATE":
+			return t.Format("2006-01-02")
+		case "TIME":
+			return t.Format("15:04:05")
+		case "DATE_TIME":
+			return t.Format("2006-01-02 15:04:05")
+		}
+		return *v.NumberValue
+	case v.StringValue != nil:
+		return *v.StringValue
+	case v.BoolValue != nil:
+		return *v.BoolValue
+	}
+	return c.FormattedValue
+}
+
+// googleSheetsClient membuat klien HTTP Sheets API dari kredensial service
+// account -gsheets-credentials, atau dari Application Default Credentials.
+func googleSheetsClient(ctx context.Context) (*http.Client, error) {
+	if opts.gsheetsCredentials == "" {
+		return google.DefaultClient(ctx, googleSheetsScope)
+	}
+	content, err := os.ReadFile(opts.gsheetsCredentials)
+	if err != nil {
+		return nil, err
+	}
+	config, err := google.JWTConfigFromJSON(content, googleSheetsScope)
+	if err != nil {
+		return nil, err
+	}
+	return config.Client(ctx), nil
+}
+
+// downloadGoogleSheets mengambil setiap spreadsheet -gsheets melalui Sheets
+// API dan menyimpannya sebagai <judul spreadsheet>.xlsx di direktori
+// sementara, satu sheet Google menjadi satu sheet xlsx, sehingga diproses
+// seperti file xlsx lokal. Fungsi cleanup menghapus direktori tersebut.
+func downloadGoogleSheets(ctx context.Context) ([]string, func(), error) {
+	client, err := googleSheetsClient(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	dir, err := os.MkdirTemp(opts.tmpDir, "xlsx2mariadb-gsheets-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	var paths []string
+	for _, id := range strings.Split(opts.gsheets, ",") {
+		if id = strings.TrimSpace(id); id == "" {
+			continue
+		}
+		if m := spreadsheetIDPattern.FindStringSubmatch(id); m != nil {
+			id = m[1]
+		}
+		path, err := fetchGoogleSheet(ctx, client, id, filepath.Join(dir, id))
+		if err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("%s: %w", id, err)
+		}
+		logRun(tr("Google Sheets %s diambil ke %s", id, path))
+		paths = append(paths, path)
+	}
+	return paths, cleanup, nil
+}
+
+// fetchGoogleSheet menulis spreadsheet id ke file xlsx di dir. Nilai setiap
+// sheet diambil terpisah (includeGridData per range) agar respons satu
+// permintaan tidak memuat seluruh spreadsheet. Sheet tersembunyi tetap
+// tersembunyi pada xlsx sehingga dilewati -sheets all.
+func fetchGoogleSheet(ctx context.Context, client *http.Client, id, dir string) (string, error) {
+	base := "https://sheets.googleapis.com/v4/spreadsheets/" + url.PathEscape(id)
+	var meta gsheetSpreadsheet
+	if err := sheetsGet(ctx, client, base+"?fields="+url.QueryEscape("properties.title,sheets.properties(title,hidden)"), &meta); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, meta.Properties.Title)
+	if strings.TrimSpace(name) == "" {
+		name = id
+	}
+	path := filepath.Join(dir, name+".xlsx")
+
+	xlsx := excelize.NewFile()
+	defer xlsx.Close()
+	active := -1
+	var hidden []string
+	for i, sheet := range meta.Sheets {
+		title := excelSheetName(sheet.Properties.Title)
+		if i == 0 {
+			if err := xlsx.SetSheetName(xlsx.GetSheetName(0), title); err != nil {
+				return "", err
+			}
+		} else if _, err := xlsx.NewSheet(title); err != nil {
+			return "", err
+		}
+		var data gsheetSpreadsheet
+		query := url.Values{
+			"ranges":          {"'" + strings.ReplaceAll(sheet.Properties.Title, "'", "''") + "'"},
+			"includeGridData": {"true"},
+			"fields":          {"sheets.data.rowData.values(effectiveValue,formattedValue,effectiveFormat.numberFormat.type)"},
+		}
+		if err := sheetsGet(ctx, client, base+"?"+query.Encode(), &data); err != nil {
+			return "", err
+		}
+		sw, err := xlsx.NewStreamWriter(title)
+		if err != nil {
+			return "", err
+		}
+		for _, s := range data.Sheets {
+			for _, grid := range s.Data {
+				for r, rowData := range grid.RowData {
+					row := make([]interface{}, len(rowData.Values))
+					for c, cell := range rowData.Values {
+						row[c] = cell.value()
+					}
+					cell, _ := excelize.CoordinatesToCellName(1, r+1)
+					if err := sw.SetRow(cell, row); err != nil {
+						return "", err
+					}
+				}
+			}
+		}
+		if err := sw.Flush(); err != nil {
+			return "", err
+		}
+		if sheet.Properties.Hidden {
+			hidden = append(hidden, title)
+		} else if active < 0 {
+			active = i
+		}
+	}
+	// Sheet aktif tidak dapat disembunyikan
+	if active >= 0 {
+		xlsx.SetActiveSheet(active)
+		for _, title := range hidden {
+			if err := xlsx.SetSheetVisible(title, false); err != nil {
+				return "", err
+			}
+		}
+	}
+	return path, xlsx.SaveAs(path)
+}
+
+// excelSheetName menyesuaikan judul sheet Google dengan aturan nama sheet
+// Excel: tanpa karakter : \ / ? * [ ] dan paling panjang 31 karakter.
+func excelSheetName(title string) string {
+	name := []rune(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`:\/?*[]`, r) {
+			return '_'
+		}
+		return r
+	}, title))
+	if len(name) > 31 {
+		name = name[:31]
+	}
+	return string(name)
+}
+
+// sheetsGet memanggil Sheets API dan mendekode respons JSON ke v. Pesan
+// kesalahan dari API, misalnya spreadsheet belum dibagikan ke service
+// account, diteruskan apa adanya.
+func sheetsGet(ctx context.Context, client *http.Client, endpoint string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&apiErr)
+		if apiErr.Error.Message == "" {
+			apiErr.Error.Message = resp.Status
+		}
+		return fmt.Errorf("Sheets API: %s", apiErr.Error.Message)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// readStdinInput menyalin standard input ke file sementara bernama
+// <-stdin-table>.xlsx, .xls untuk OLE compound file, .ods untuk OpenDocument
+// Spreadsheet, .json untuk array JSON, .jsonl untuk JSON Lines, .parquet untuk
+// Parquet, .xml untuk XML, atau .csv untuk isi lainnya. Fungsi cleanup menghapus direktori sementara tersebut.
+func readStdinInput() (string, func(), error) {
+	dir, err := os.MkdirTemp(opts.tmpDir, "xlsx2mariadb-stdin-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	in := bufio.NewReader(os.Stdin)
+	ext := ".csv"
+	header, _ := in.Peek(128)
+	switch format := xlsx2sql.DetectFormat(header); format {
+	case "xlsx", "xls", "ods", "json", "jsonl", "parquet", "xml":
+		ext = "." + format
+	}
+	path := filepath.Join(dir, opts.stdinTable+ext)
+	file, err := os.Create(path)
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	_, err = io.Copy(file, in)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return path, cleanup, nil
+}
+
+// conversionFailed mengembalikan true bila ada file Excel yang gagal dikonversi
+// pada run ini.
+func conversionFailed() bool {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, status := range fileStatus {
+		if isFailedStatus(status) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFailedStatus mengembalikan true untuk status file yang gagal dikonversi.
+func isFailedStatus(status string) bool {
+	switch status {
+	case "error", "timeout", "incomplete", "blocked", "unstable":
+		return true
+	}
+	return false
+}
+
+// s3Location adalah bucket dan awalan key dari URL s3://bucket/awalan.
+type s3Location struct {
+	bucket string
+	prefix string
+}
+
+// parseS3URL mengurai raw berbentuk s3://bucket/awalan.
+func parseS3URL(raw string) (s3Location, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return s3Location{}, err
+	}
+	if u.Scheme != "s3" || u.Host == "" {
+		return s3Location{}, errors.New(tr("%q bukan URL s3://bucket/awalan", raw))
+	}
+	return s3Location{bucket: u.Host, prefix: strings.TrimPrefix(u.Path, "/")}, nil
+}
+
+// s3Client membuat klien S3 untuk -s3-endpoint, AWS_ENDPOINT_URL atau Amazon
+// S3. Kredensial diambil dari variabel lingkungan AWS_* atau MINIO_*, file
+// ~/.aws/credentials, lalu IAM role; tanpa kredensial permintaan dikirim
+// anonim sehingga bucket publik tetap dapat dibaca.
+func s3Client() (*minio.Client, error) {
+	endpoint := opts.s3Endpoint
+	if endpoint == "" {
+		endpoint = os.Getenv("AWS_ENDPOINT_URL")
+	}
+	if endpoint == "" {
+		endpoint = "https://s3.amazonaws.com"
+	}
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "https://" + endpoint
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	creds := credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvAWS{},
+		&credentials.EnvMinio{},
+		&credentials.FileAWSCredentials{},
+		&credentials.IAM{},
+	})
+	return minio.New(u.Host, &minio.Options{Creds: creds, Secure: u.Scheme == "https", Region: opts.s3Region})
+}
+
+// downloadS3 mengunduh setiap objek berformat input (lihat isInputArg) di
+// bawah awalan -input ke direktori sementara dengan struktur direktori sesuai
+// key, lalu diproses seperti file lokal. Fungsi cleanup menghapus direktori
+// tersebut.
+func downloadS3(ctx context.Context) ([]string, func(), error) {
+	location, err := parseS3URL(opts.input)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := s3Client()
+	if err != nil {
+		return nil, nil, err
+	}
+	dir, err := os.MkdirTemp(opts.tmpDir, "xlsx2mariadb-s3-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	var paths []string
+	for object := range client.ListObjects(ctx, location.bucket, minio.ListObjectsOptions{Prefix: location.prefix, Recursive: true}) {
+		if object.Err != nil {
+			cleanup()
+			return nil, nil, object.Err
+		}
+		// Key dengan .. atau path absolut tidak boleh keluar dari dir
+		name := filepath.FromSlash(object.Key)
+		if !filepath.IsLocal(name) || !isInputArg(name) || name == "-" {
+			continue
+		}
+		local := filepath.Join(dir, name)
+		if err := client.FGetObject(ctx, location.bucket, object.Key, local, minio.GetObjectOptions{}); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("%s: %w", object.Key, err)
+		}
+		// Objek sudah utuh, sehingga waitStable tidak perlu menunggu
+		os.Chtimes(local, object.LastModified, object.LastModified)
+		logRun(tr("Objek s3://%s/%s diunduh ke %s", location.bucket, object.Key, local))
+		paths = append(paths, local)
+	}
+	if len(paths) == 0 {
+		cleanup()
+		return nil, nil, errors.New(tr("Tidak ada file input pada s3://%s/%s", location.bucket, location.prefix))
+	}
+	return paths, cleanup, nil
+}
+
+// s3Target adalah tujuan -upload.
+type s3Target struct {
+	client   *minio.Client
+	location s3Location
+}
+
+// s3Upload adalah tujuan -upload, nil bila file SQL tidak diunggah.
+var s3Upload *s3Target
+
+func newS3Target(raw string) (*s3Target, error) {
+	location, err := parseS3URL(raw)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s3Client()
+	if err != nil {
+		return nil, err
+	}
+	return &s3Target{client: client, location: location}, nil
+}
+
+// upload mengunggah files ke <awalan><direktori file>/<nama file>, misalnya
+// SQLData/data_penjualan.sql menjadi impor/SQLData/data_penjualan.sql. Tanpa
+// -upload (t nil) tidak ada yang diunggah.
+func (t *s3Target) upload(ctx context.Context, files ...string) error {
+	if t == nil {
+		return nil
+	}
+	prefix := t.location.prefix
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	for _, file := range files {
+		key := prefix + filepath.Base(filepath.Dir(file)) + "/" + filepath.Base(file)
+		if _, err := t.client.FPutObject(ctx, t.location.bucket, key, file, minio.PutObjectOptions{}); err != nil {
+			return fmt.Errorf("s3://%s/%s: %w", t.location.bucket, key, err)
+		}
+	}
+	return nil
+}
+
+// sftpSource adalah sumber -sftp. Koneksi tetap terbuka selama run agar file
+// yang berhasil diproses dapat dipindahkan ke -sftp-processed.
+type sftpSource struct {
+	conn   *ssh.Client
+	client *sftp.Client
+	host   string
+	dir    string
+
+	// files memetakan path lokal file yang diunduh pada run ini ke path remote
+	files map[string]string
+}
+
+// sftpInput adalah sumber -sftp run ini, nil bila tidak ada file yang diunduh.
+var sftpInput *sftpSource
+
+// openSFTP membuka koneksi SFTP ke raw (sftp://user@host[:port]/direktori)
+// dengan autentikasi private key. Host key diverifikasi dengan known_hosts.
+func openSFTP(raw string) (*sftpSource, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "sftp" || u.Hostname() == "" || u.User == nil {
+		return nil, errors.New(tr("%q bukan URL sftp://user@host/direktori", raw))
+	}
+	signer, err := sftpSigner()
+	if err != nil {
+		return nil, err
+	}
+	home, _ := os.UserHomeDir()
+	knownHosts := opts.sftpKnownHosts
+	if knownHosts == "" {
+		knownHosts = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	hostKey, err := knownhosts.New(knownHosts)
+	if err != nil {
+		return nil, err
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "22")
+	}
+	conn, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            u.User.Username(),
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+		HostKeyCallback: hostKey,
+		Timeout:         30 * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	dir := u.Path
+	if dir == "" {
+		dir = "."
+	}
+	return &sftpSource{conn: conn, client: client, host: u.Hostname(), dir: dir, files: make(map[string]string)}, nil
+}
+
+// sftpSigner membaca private key -sftp-key, atau ~/.ssh/id_ed25519 dan
+// ~/.ssh/id_rsa bila kosong.
+func sftpSigner() (ssh.Signer, error) {
+	keys := []string{opts.sftpKey}
+	if opts.sftpKey == "" {
+		home, _ := os.UserHomeDir()
+		keys = []string{filepath.Join(home, ".ssh", "id_ed25519"), filepath.Join(home, ".ssh", "id_rsa")}
+	}
+	for _, key := range keys {
+		content, err := os.ReadFile(key)
+		if os.IsNotExist(err) && opts.sftpKey == "" {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return ssh.ParsePrivateKey(content)
+	}
+	return nil, errors.New(tr("private key untuk -sftp tidak ditemukan, isi -sftp-key"))
+}
+
+// download menyalin file input baru pada direktori remote ke sftp/<host>.
+// File yang salinannya sudah ada dengan ukuran dan waktu modifikasi sama
+// dianggap sudah pernah diproses dan dilewati. Subdirektori, termasuk
+// -sftp-processed, tidak dibaca.
+func (s *sftpSource) download() ([]string, error) {
+	entries, err := s.client.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	localDir, err := filepath.Abs(filepath.Join("sftp", s.host))
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(localDir, 0755); err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, entry := range entries {
+		if !entry.Mode().IsRegular() || !isInputArg(entry.Name()) {
+			continue
+		}
+		local := filepath.Join(localDir, entry.Name())
+		if info, err := os.Stat(local); err == nil && info.Size() == entry.Size() && info.ModTime().Equal(entry.ModTime()) {
+			continue
+		}
+		remote := path.Join(s.dir, entry.Name())
+		if err := s.fetch(remote, local); err != nil {
+			return nil, fmt.Errorf("%s: %w", remote, err)
+		}
+		// Waktu modifikasi remote menandai salinan yang sudah diunduh, dan
+		// file sudah utuh sehingga waitStable tidak perlu menunggu
+		os.Chtimes(local, entry.ModTime(), entry.ModTime())
+		logRun(tr("File SFTP %s diunduh ke %s", remote, local))
+		s.files[local] = remote
+		paths = append(paths, local)
+	}
+	return paths, nil
+}
+
+// fetch mengunduh remote ke file sementara lalu menggantinya ke local, agar
+// unduhan yang terputus tidak meninggalkan salinan yang dianggap utuh.
+func (s *sftpSource) fetch(remote, local string) error {
+	in, err := s.client.Open(remote)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.CreateTemp(filepath.Dir(local), ".sftp-*")
+	if err != nil {
+		return err
+	}
+	_, err = in.WriteTo(out)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(out.Name(), local)
+	}
+	if err != nil {
+		os.Remove(out.Name())
+	}
+	return err
+}
+
+// moveProcessed memindahkan file remote yang berhasil dikonversi pada run ini
+// ke -sftp-processed. Kegagalan hanya dicatat karena data sudah diproses.
+func (s *sftpSource) moveProcessed() {
+	if s == nil || opts.sftpProcessed == "" {
+		return
+	}
+	target := opts.sftpProcessed
+	if !path.IsAbs(target) {
+		target = path.Join(s.dir, target)
+	}
+	if err := s.client.MkdirAll(target); err != nil {
+		logError(err, tr("Gagal memindahkan file SFTP %s ke %s", s.dir, target))
+		return
+	}
+	mu.Lock()
+	statuses := make(map[string]string, len(s.files))
+	for local := range s.files {
+		statuses[local] = fileStatus[local]
+		// Arsip ZIP berhasil bila semua file di dalamnya berhasil
+		if entries, ok := zipEntries[local]; ok {
+			statuses[local] = "success"
+			for _, entry := range entries {
+				if status := fileStatus[entry]; status == "" || isFailedStatus(status) {
+					statuses[local] = status
+					break
+				}
+			}
+		}
+	}
+	mu.Unlock()
+	for local, remote := range s.files {
+		if status := statuses[local]; status == "" || isFailedStatus(status) {
+			continue
+		}
+		moved := path.Join(target, path.Base(remote))
+		err := s.client.PosixRename(remote, moved)
+		if err != nil {
+			// Server tanpa ekstensi posix-rename
+			err = s.client.Rename(remote, moved)
+		}
+		if err != nil {
+			logError(err, tr("Gagal memindahkan file SFTP %s ke %s", remote, moved))
+			continue
+		}
+		logRun(tr("File SFTP %s dipindahkan ke %s", remote, moved))
+	}
+}
+
+func (s *sftpSource) close() {
+	s.client.Close()
+	s.conn.Close()
+}
+
+// isURLArg mengenali argumen input berupa URL HTTP atau HTTPS.
+func isURLArg(arg string) bool {
+	return strings.HasPrefix(arg, "http://") || strings.HasPrefix(arg, "https://")
+}
+
+// urlCache adalah metadata unduhan terakhir sebuah URL input, disimpan sebagai
+// cache.json di samping salinannya.
+type urlCache struct {
+	URL          string `json:"url"`
+	File         string `json:"file"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// downloadURL mengunduh raw ke url/<host>/<hash URL>/<nama file> di direktori
+// kerja dan mengembalikan path salinannya. Bila salinan sebelumnya ada,
+// permintaan dikirim dengan If-None-Match dan If-Modified-Since dari ETag dan
+// Last-Modified unduhan itu, dan jawaban 304 Not Modified memakai salinan
+// tersebut tanpa mengunduh ulang.
+func downloadURL(ctx context.Context, raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	dir, err := filepath.Abs(filepath.Join("url", u.Hostname(), fingerprintString(raw)))
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	cachePath := filepath.Join(dir, "cache.json")
+	var cache urlCache
+	if content, err := os.ReadFile(cachePath); err == nil {
+		json.Unmarshal(content, &cache)
+	}
+	cached := ""
+	if cache.File != "" {
+		if _, err := os.Stat(filepath.Join(dir, cache.File)); err == nil {
+			cached = filepath.Join(dir, cache.File)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, raw, nil)
+	if err != nil {
+		return "", err
+	}
+	if cached != "" {
+		if cache.ETag != "" {
+			req.Header.Set("If-None-Match", cache.ETag)
+		}
+		if cache.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cache.LastModified)
+		}
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified && cached != "" {
+		logRun(tr("URL %s tidak berubah, memakai salinan %s", raw, cached))
+		return cached, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %s", raw, resp.Status)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".download-*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+	in := bufio.NewReader(resp.Body)
+	header, _ := in.Peek(128)
+	_, err = io.Copy(tmp, in)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", err
+	}
+	name := urlFileName(u, resp.Header, header)
+	path := filepath.Join(dir, name)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	if cache.File != "" && cache.File != name {
+		os.Remove(filepath.Join(dir, cache.File))
+	}
+	// Waktu modifikasi mengikuti server sehingga waitStable tidak menunggu
+	// file yang sudah utuh
+	if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		os.Chtimes(path, modified, modified)
+	}
+	cache = urlCache{URL: raw, File: name, ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+	content, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(cachePath, content, 0644); err != nil {
+		return "", err
+	}
+	logRun(tr("URL %s diunduh ke %s", raw, path))
+	return path, nil
+}
+
+// urlFileName menentukan nama file unduhan (dan karena itu nama tabelnya):
+// filename pada Content-Disposition, atau bagian terakhir path URL. Bila
+// nama tersebut tidak berekstensi input, ekstensi ditentukan dari isi file
+// seperti pada standard input.
+func urlFileName(u *url.URL, header http.Header, content []byte) string {
+	name := path.Base(u.Path)
+	if _, params, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+		name = filepath.Base(params["filename"])
+	}
+	if name == "." || name == "/" || !filepath.IsLocal(name) {
+		name = "download"
+	}
+	if !isInputArg(name) {
+		ext := ".csv"
+		switch format := xlsx2sql.DetectFormat(content); format {
+		case "xlsx", "xls", "ods", "json", "jsonl", "parquet", "xml":
+			ext = "." + format
+		}
+		name += ext
+	}
+	return name
+}
+
+// isZipFile mengenali arsip ZIP berisi file input.
+func isZipFile(name string) bool {
+	return strings.EqualFold(filepath.Ext(name), ".zip")
+}
+
+// zipEntries memetakan path arsip ZIP input ke path file hasil ekstraksinya,
+// sehingga status arsip dapat ditentukan dari status file di dalamnya.
+var zipEntries = make(map[string][]string)
+
+// extractZip mengekstrak file input di dalam arsip ZIP archive ke direktori
+// sementara di -tmp-dir dengan struktur direktori arsip. Entri disalin satu per
+// satu secara streaming sehingga isi arsip tidak dimuat ke memori. Direktori,
+// arsip ZIP bersarang, metadata macOS (__MACOSX dan ._*) dan file yang bukan
+// file input dilewati.
+func extractZip(archive string) ([]string, func(), error) {
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer reader.Close()
+	dir, err := os.MkdirTemp(opts.tmpDir, "xlsx2mariadb-zip-")
+	if err != nil {
+		return nil, nil, err
+	}
+	if dir, err = filepath.Abs(dir); err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	var paths []string
+	for _, entry := range reader.File {
+		name := filepath.FromSlash(entry.Name)
+		base := path.Base(entry.Name)
+		if entry.FileInfo().IsDir() || !filepath.IsLocal(name) || strings.HasPrefix(entry.Name, "__MACOSX/") ||
+			strings.HasPrefix(base, "._") || base == "-" || isZipFile(base) || !isInputArg(base) {
+			continue
+		}
+		target := filepath.Join(dir, name)
+		if err := extractZipEntry(entry, target); err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("%s: %w", entry.Name, err)
+		}
+		paths = append(paths, target)
+	}
+	if len(paths) == 0 {
+		cleanup()
+		return nil, nil, errors.New(tr("arsip ZIP %s tidak berisi file input", archive))
+	}
+	return paths, cleanup, nil
+}
+
+func extractZipEntry(entry *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	in, err := entry.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	// Waktu modifikasi mengikuti entri arsip sehingga waitStable tidak
+	// menunggu file yang sudah utuh
+	return os.Chtimes(target, entry.Modified, entry.Modified)
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/ddl"
+)
+
+func TestSplitStatements(t *testing.T) {
+	header := []string{"x'; DROP TABLE t; --", "a;b"}
+	columns := ddl.NamingOriginal.Columns(header, []string{"VARCHAR(10)", "INT"})
+	createTable := ddl.CreateTableWithOptions("`t`", columns, ddl.TableOptions{Naming: ddl.NamingOriginal}) + ";\nCREATE TABLE u (v INT COMMENT 'p\\\\');\n"
+
+	statements := splitStatements(createTable)
+	var nonEmpty []string
+	for _, stmt := range statements {
+		if strings.TrimSpace(stmt) != "" {
+			nonEmpty = append(nonEmpty, strings.TrimSpace(stmt))
+		}
+	}
+	if len(nonEmpty) != 2 {
+		t.Fatalf("splitStatements = %d pernyataan, ingin 2: %q", len(nonEmpty), nonEmpty)
+	}
+	if !strings.HasPrefix(nonEmpty[0], "CREATE TABLE `t`") || !strings.Contains(nonEmpty[0], "`a;b`") {
+		t.Errorf("pernyataan pertama = %q", nonEmpty[0])
+	}
+	if nonEmpty[1] != `CREATE TABLE u (v INT COMMENT 'p\\')` {
+		t.Errorf("pernyataan kedua = %q", nonEmpty[1])
+	}
+}
+
+// cronBits membentuk bitmask kolom cron dari nilai-nilai yang cocok.
+func cronBits(values ...int) uint64 {
+	var bits uint64
+	for _, v := range values {
+		bits |= 1 << uint(v)
+	}
+	return bits
+}
+
+func TestParseCronField(t *testing.T) {
+	tests := []struct {
+		field    string
+		min, max int
+		want     uint64
+	}{
+		{"*", 0, 7, cronBits(0, 1, 2, 3, 4, 5, 6, 7)},
+		{"5", 0, 59, cronBits(5)},
+		{"1,3,5", 1, 12, cronBits(1, 3, 5)},
+		{"9-12", 0, 23, cronBits(9, 10, 11, 12)},
+		{"*/15", 0, 59, cronBits(0, 15, 30, 45)},
+		{"10-20/5", 0, 59, cronBits(10, 15, 20)},
+		{"50/4", 0, 59, cronBits(50, 54, 58)},
+		{"1-3,20-31/10", 1, 31, cronBits(1, 2, 3, 20, 30)},
+	}
+	for _, test := range tests {
+		got, err := parseCronField(test.field, test.min, test.max)
+		if err != nil || got != test.want {
+			t.Errorf("parseCronField(%q) = %b, %v, ingin %b", test.field, got, err, test.want)
+		}
+	}
+}
+
+func TestParseCronScheduleInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"a * * * *",
+		"1-b * * * *",
+		"1,,2 * * * *",
+		"-1 * * * *",
+	} {
+		if _, err := parseCronSchedule(spec); err == nil {
+			t.Errorf("parseCronSchedule(%q) tidak gagal", spec)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	date := func(year int, month time.Month, day, hour, minute, second int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, time.UTC)
+	}
+	tests := []struct {
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"*/15 * * * *", date(2024, 1, 1, 10, 7, 30), date(2024, 1, 1, 10, 15, 0)},
+		// Waktu yang tepat cocok tidak dikembalikan lagi
+		{"*/15 * * * *", date(2024, 1, 1, 10, 15, 0), date(2024, 1, 1, 10, 30, 0)},
+		{"0 * * * *", date(2024, 1, 1, 23, 59, 0), date(2024, 1, 2, 0, 0, 0)},
+		// Jumat 5 Januari 2024 lewat jam 9, berikutnya Senin
+		{"0 9 * * 1-5", date(2024, 1, 5, 10, 0, 0), date(2024, 1, 8, 9, 0, 0)},
+		{"30 2 1 * *", date(2024, 1, 31, 12, 0, 0), date(2024, 2, 1, 2, 30, 0)},
+		{"0 0 1 1 *", date(2024, 6, 15, 0, 0, 0), date(2025, 1, 1, 0, 0, 0)},
+		{"0 0 29 2 *", date(2024, 3, 1, 0, 0, 0), date(2028, 2, 29, 0, 0, 0)},
+		// Minggu boleh ditulis 7
+		{"0 12 * * 7", date(2024, 1, 1, 0, 0, 0), date(2024, 1, 7, 12, 0, 0)},
+		// Tanggal dan hari sama-sama dibatasi: cukup salah satu yang cocok
+		{"0 0 13 * 5", date(2024, 1, 1, 0, 0, 0), date(2024, 1, 5, 0, 0, 0)},
+		{"0 0 13 * 5", date(2024, 1, 12, 1, 0, 0), date(2024, 1, 13, 0, 0, 0)},
+		// Hari dengan * tetap mengikuti tanggal saja
+		{"0 0 13 * *", date(2024, 1, 1, 0, 0, 0), date(2024, 1, 13, 0, 0, 0)},
+		// 31 Februari tidak pernah ada
+		{"0 0 31 2 *", date(2024, 1, 1, 0, 0, 0), time.Time{}},
+	}
+	for _, test := range tests {
+		sched, err := parseCronSchedule(test.spec)
+		if err != nil {
+			t.Fatalf("parseCronSchedule(%q) = %v", test.spec, err)
+		}
+		if got := sched.next(test.from); !got.Equal(test.want) {
+			t.Errorf("%q next(%v) = %v, ingin %v", test.spec, test.from, got, test.want)
+		}
+	}
+}
+
+func TestRotateLogSameSecond(t *testing.T) {
+	saved := opts
+	defer func() { opts = saved }()
+	opts.logDaily, opts.logMaxSize, opts.logKeep = true, 0, 0
+
+	logFile := filepath.Join(t.TempDir(), "xlsx2mariadb.log")
+	modTime := time.Now().AddDate(0, 0, -1).Truncate(time.Second)
+	for _, content := range []string{"pertama\n", "kedua\n", "ketiga\n"} {
+		if err := os.WriteFile(logFile, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(logFile, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+		if err := rotateLog(logFile); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	archives, err := filepath.Glob(strings.TrimSuffix(logFile, ".log") + "-*.log.gz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(archives)
+	var contents []string
+	for _, archive := range archives {
+		f, err := os.Open(archive)
+		if err != nil {
+			t.Fatal(err)
+		}
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(zr)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents = append(contents, string(content))
+	}
+	// Urutan nama arsip sama dengan urutan rotasi
+	if want := []string{"pertama\n", "kedua\n", "ketiga\n"}; !reflect.DeepEqual(contents, want) {
+		t.Errorf("isi arsip %q = %q, ingin %q", archives, contents, want)
+	}
+}
+
+func TestRequireAPITokenOrigin(t *testing.T) {
+	saved := opts
+	defer func() { opts = saved }()
+
+	handler := requireAPIToken(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	tests := []struct {
+		name    string
+		token   string
+		method  string
+		host    string
+		headers map[string]string
+		want    int
+	}{
+		{"curl", "", "POST", "localhost:8080", nil, http.StatusNoContent},
+		{"halaman sendiri", "", "POST", "localhost:8080", map[string]string{"Sec-Fetch-Site": "same-origin"}, http.StatusNoContent},
+		{"situs lain", "", "POST", "localhost:8080", map[string]string{"Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+		{"situs sama", "", "POST", "127.0.0.1:8080", map[string]string{"Sec-Fetch-Site": "same-site"}, http.StatusForbidden},
+		{"origin sama", "", "POST", "127.0.0.1:8080", map[string]string{"Origin": "http://127.0.0.1:8080"}, http.StatusNoContent},
+		{"origin lain", "", "POST", "127.0.0.1:8080", map[string]string{"Origin": "http://contoh.test"}, http.StatusForbidden},
+		{"origin null", "", "POST", "[::1]:8080", map[string]string{"Origin": "null"}, http.StatusForbidden},
+		{"GET lintas origin", "", "GET", "localhost:8080", map[string]string{"Sec-Fetch-Site": "cross-site"}, http.StatusNoContent},
+		{"DNS rebinding", "", "GET", "contoh.test:8080", nil, http.StatusForbidden},
+		{"host dengan token", "rahasia-0123456789", "GET", "contoh.test:8080", map[string]string{"Authorization": "Bearer rahasia-0123456789"}, http.StatusNoContent},
+		{"token lintas origin", "rahasia-0123456789", "POST", "contoh.test:8080", map[string]string{"Authorization": "Bearer rahasia-0123456789", "Sec-Fetch-Site": "cross-site"}, http.StatusForbidden},
+		{"token salah", "rahasia-0123456789", "POST", "contoh.test:8080", map[string]string{"Authorization": "Bearer salah"}, http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts.apiToken = test.token
+			r := httptest.NewRequest(test.method, "http://"+test.host+"/jobs?kind=convert", nil)
+			for name, value := range test.headers {
+				r.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+			if w.Code != test.want {
+				t.Errorf("status = %d, ingin %d", w.Code, test.want)
+			}
+		})
+	}
+}
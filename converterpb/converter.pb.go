@@ -0,0 +1,609 @@
+// API gRPC xlsx2mariadb, dijalankan oleh perintah xlsx2mariadb -grpc-listen
+// ALAMAT serve bersama API REST. Pekerjaan dari kedua API masuk ke antrean
+// yang sama dan dijalankan satu per satu.
+//
+// Kode Go di direktori ini dibuat dari file ini dengan protoc-gen-go dan
+// protoc-gen-go-grpc:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//	  --go-grpc_out=. --go-grpc_opt=paths=source_relative converter.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: converter.proto
+
+package converterpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type JobKind int32
+
+const (
+	JobKind_JOB_KIND_UNSPECIFIED JobKind = 0 // sama dengan JOB_KIND_CONVERT
+	JobKind_JOB_KIND_CONVERT     JobKind = 1
+	JobKind_JOB_KIND_LOAD        JobKind = 2
+)
+
+// Enum value maps for JobKind.
+var (
+	JobKind_name = map[int32]string{
+		0: "JOB_KIND_UNSPECIFIED",
+		1: "JOB_KIND_CONVERT",
+		2: "JOB_KIND_LOAD",
+	}
+	JobKind_value = map[string]int32{
+		"JOB_KIND_UNSPECIFIED": 0,
+		"JOB_KIND_CONVERT":     1,
+		"JOB_KIND_LOAD":        2,
+	}
+)
+
+func (x JobKind) Enum() *JobKind {
+	p := new(JobKind)
+	*p = x
+	return p
+}
+
+func (x JobKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_converter_proto_enumTypes[0].Descriptor()
+}
+
+func (JobKind) Type() protoreflect.EnumType {
+	return &file_converter_proto_enumTypes[0]
+}
+
+func (x JobKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobKind.Descriptor instead.
+func (JobKind) EnumDescriptor() ([]byte, []int) {
+	return file_converter_proto_rawDescGZIP(), []int{0}
+}
+
+type ConvertRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Kind  JobKind                `protobuf:"varint,1,opt,name=kind,proto3,enum=xlsx2mariadb.v1.JobKind" json:"kind,omitempty"`
+	// file adalah nama file di direktori xlsx; kosong berarti semua file
+	File string `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	// table membatasi JOB_KIND_LOAD pada satu tabel
+	Table         string `protobuf:"bytes,3,opt,name=table,proto3" json:"table,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertRequest) Reset() {
+	*x = ConvertRequest{}
+	mi := &file_converter_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertRequest) ProtoMessage() {}
+
+func (x *ConvertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_converter_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertRequest.ProtoReflect.Descriptor instead.
+func (*ConvertRequest) Descriptor() ([]byte, []int) {
+	return file_converter_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ConvertRequest) GetKind() JobKind {
+	if x != nil {
+		return x.Kind
+	}
+	return JobKind_JOB_KIND_UNSPECIFIED
+}
+
+func (x *ConvertRequest) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *ConvertRequest) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_converter_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_converter_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_converter_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type Job struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind  JobKind                `protobuf:"varint,2,opt,name=kind,proto3,enum=xlsx2mariadb.v1.JobKind" json:"kind,omitempty"`
+	Table string                 `protobuf:"bytes,3,opt,name=table,proto3" json:"table,omitempty"`
+	// status adalah queued, running, done atau failed
+	Status string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	// files berisi status setiap file, misalnya success atau error
+	Files         map[string]string      `protobuf:"bytes,5,rep,name=files,proto3" json:"files,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Created       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	Finished      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=finished,proto3" json:"finished,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_converter_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_converter_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_converter_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Job) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Job) GetKind() JobKind {
+	if x != nil {
+		return x.Kind
+	}
+	return JobKind_JOB_KIND_UNSPECIFIED
+}
+
+func (x *Job) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+func (x *Job) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Job) GetFiles() map[string]string {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *Job) GetCreated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Created
+	}
+	return nil
+}
+
+func (x *Job) GetFinished() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Finished
+	}
+	return nil
+}
+
+type StreamProgressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamProgressRequest) Reset() {
+	*x = StreamProgressRequest{}
+	mi := &file_converter_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamProgressRequest) ProtoMessage() {}
+
+func (x *StreamProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_converter_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamProgressRequest.ProtoReflect.Descriptor instead.
+func (*StreamProgressRequest) Descriptor() ([]byte, []int) {
+	return file_converter_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StreamProgressRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type ProgressEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// event adalah jenis kejadian, misalnya file_started atau file_done
+	Event string                 `protobuf:"bytes,1,opt,name=event,proto3" json:"event,omitempty"`
+	Time  *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	// fields berisi data kejadian lainnya, misalnya file dan status
+	Fields        map[string]string `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProgressEvent) Reset() {
+	*x = ProgressEvent{}
+	mi := &file_converter_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProgressEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProgressEvent) ProtoMessage() {}
+
+func (x *ProgressEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_converter_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProgressEvent.ProtoReflect.Descriptor instead.
+func (*ProgressEvent) Descriptor() ([]byte, []int) {
+	return file_converter_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProgressEvent) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *ProgressEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ProgressEvent) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type FetchArtifactsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Table         string                 `protobuf:"bytes,1,opt,name=table,proto3" json:"table,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FetchArtifactsRequest) Reset() {
+	*x = FetchArtifactsRequest{}
+	mi := &file_converter_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FetchArtifactsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FetchArtifactsRequest) ProtoMessage() {}
+
+func (x *FetchArtifactsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_converter_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FetchArtifactsRequest.ProtoReflect.Descriptor instead.
+func (*FetchArtifactsRequest) Descriptor() ([]byte, []int) {
+	return file_converter_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FetchArtifactsRequest) GetTable() string {
+	if x != nil {
+		return x.Table
+	}
+	return ""
+}
+
+type Artifact struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name adalah nama file, misalnya penjualan.sql atau data_penjualan.sql.gz
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// data adalah potongan isi file; potongan dengan name yang sama
+	// disambung sesuai urutan
+	Data          []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Artifact) Reset() {
+	*x = Artifact{}
+	mi := &file_converter_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Artifact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Artifact) ProtoMessage() {}
+
+func (x *Artifact) ProtoReflect() protoreflect.Message {
+	mi := &file_converter_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Artifact.ProtoReflect.Descriptor instead.
+func (*Artifact) Descriptor() ([]byte, []int) {
+	return file_converter_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Artifact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Artifact) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_converter_proto protoreflect.FileDescriptor
+
+const file_converter_proto_rawDesc = "" +
+	"\n" +
+	"\x0fconverter.proto\x12\x0fxlsx2mariadb.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"h\n" +
+	"\x0eConvertRequest\x12,\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x18.xlsx2mariadb.v1.JobKindR\x04kind\x12\x12\n" +
+	"\x04file\x18\x02 \x01(\tR\x04file\x12\x14\n" +
+	"\x05table\x18\x03 \x01(\tR\x05table\"\"\n" +
+	"\x10GetStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xd0\x02\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x18.xlsx2mariadb.v1.JobKindR\x04kind\x12\x14\n" +
+	"\x05table\x18\x03 \x01(\tR\x05table\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x125\n" +
+	"\x05files\x18\x05 \x03(\v2\x1f.xlsx2mariadb.v1.Job.FilesEntryR\x05files\x124\n" +
+	"\acreated\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\acreated\x126\n" +
+	"\bfinished\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bfinished\x1a8\n" +
+	"\n" +
+	"FilesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\".\n" +
+	"\x15StreamProgressRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"\xd4\x01\n" +
+	"\rProgressEvent\x12\x14\n" +
+	"\x05event\x18\x01 \x01(\tR\x05event\x12.\n" +
+	"\x04time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12B\n" +
+	"\x06fields\x18\x03 \x03(\v2*.xlsx2mariadb.v1.ProgressEvent.FieldsEntryR\x06fields\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"-\n" +
+	"\x15FetchArtifactsRequest\x12\x14\n" +
+	"\x05table\x18\x01 \x01(\tR\x05table\"2\n" +
+	"\bArtifact\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data*L\n" +
+	"\aJobKind\x12\x18\n" +
+	"\x14JOB_KIND_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10JOB_KIND_CONVERT\x10\x01\x12\x11\n" +
+	"\rJOB_KIND_LOAD\x10\x022\xc6\x02\n" +
+	"\tConverter\x12@\n" +
+	"\aConvert\x12\x1f.xlsx2mariadb.v1.ConvertRequest\x1a\x14.xlsx2mariadb.v1.Job\x12D\n" +
+	"\tGetStatus\x12!.xlsx2mariadb.v1.GetStatusRequest\x1a\x14.xlsx2mariadb.v1.Job\x12Z\n" +
+	"\x0eStreamProgress\x12&.xlsx2mariadb.v1.StreamProgressRequest\x1a\x1e.xlsx2mariadb.v1.ProgressEvent0\x01\x12U\n" +
+	"\x0eFetchArtifacts\x12&.xlsx2mariadb.v1.FetchArtifactsRequest\x1a\x19.xlsx2mariadb.v1.Artifact0\x01B2Z0github.com/MuhaeminSidiq/GOLearnbyAI/converterpbb\x06proto3"
+
+var (
+	file_converter_proto_rawDescOnce sync.Once
+	file_converter_proto_rawDescData []byte
+)
+
+func file_converter_proto_rawDescGZIP() []byte {
+	file_converter_proto_rawDescOnce.Do(func() {
+		file_converter_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_converter_proto_rawDesc), len(file_converter_proto_rawDesc)))
+	})
+	return file_converter_proto_rawDescData
+}
+
+var file_converter_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_converter_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_converter_proto_goTypes = []any{
+	(JobKind)(0),                  // 0: xlsx2mariadb.v1.JobKind
+	(*ConvertRequest)(nil),        // 1: xlsx2mariadb.v1.ConvertRequest
+	(*GetStatusRequest)(nil),      // 2: xlsx2mariadb.v1.GetStatusRequest
+	(*Job)(nil),                   // 3: xlsx2mariadb.v1.Job
+	(*StreamProgressRequest)(nil), // 4: xlsx2mariadb.v1.StreamProgressRequest
+	(*ProgressEvent)(nil),         // 5: xlsx2mariadb.v1.ProgressEvent
+	(*FetchArtifactsRequest)(nil), // 6: xlsx2mariadb.v1.FetchArtifactsRequest
+	(*Artifact)(nil),              // 7: xlsx2mariadb.v1.Artifact
+	nil,                           // 8: xlsx2mariadb.v1.Job.FilesEntry
+	nil,                           // 9: xlsx2mariadb.v1.ProgressEvent.FieldsEntry
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
+}
+var file_converter_proto_depIdxs = []int32{
+	0,  // 0: xlsx2mariadb.v1.ConvertRequest.kind:type_name -> xlsx2mariadb.v1.JobKind
+	0,  // 1: xlsx2mariadb.v1.Job.kind:type_name -> xlsx2mariadb.v1.JobKind
+	8,  // 2: xlsx2mariadb.v1.Job.files:type_name -> xlsx2mariadb.v1.Job.FilesEntry
+	10, // 3: xlsx2mariadb.v1.Job.created:type_name -> google.protobuf.Timestamp
+	10, // 4: xlsx2mariadb.v1.Job.finished:type_name -> google.protobuf.Timestamp
+	10, // 5: xlsx2mariadb.v1.ProgressEvent.time:type_name -> google.protobuf.Timestamp
+	9,  // 6: xlsx2mariadb.v1.ProgressEvent.fields:type_name -> xlsx2mariadb.v1.ProgressEvent.FieldsEntry
+	1,  // 7: xlsx2mariadb.v1.Converter.Convert:input_type -> xlsx2mariadb.v1.ConvertRequest
+	2,  // 8: xlsx2mariadb.v1.Converter.GetStatus:input_type -> xlsx2mariadb.v1.GetStatusRequest
+	4,  // 9: xlsx2mariadb.v1.Converter.StreamProgress:input_type -> xlsx2mariadb.v1.StreamProgressRequest
+	6,  // 10: xlsx2mariadb.v1.Converter.FetchArtifacts:input_type -> xlsx2mariadb.v1.FetchArtifactsRequest
+	3,  // 11: xlsx2mariadb.v1.Converter.Convert:output_type -> xlsx2mariadb.v1.Job
+	3,  // 12: xlsx2mariadb.v1.Converter.GetStatus:output_type -> xlsx2mariadb.v1.Job
+	5,  // 13: xlsx2mariadb.v1.Converter.StreamProgress:output_type -> xlsx2mariadb.v1.ProgressEvent
+	7,  // 14: xlsx2mariadb.v1.Converter.FetchArtifacts:output_type -> xlsx2mariadb.v1.Artifact
+	11, // [11:15] is the sub-list for method output_type
+	7,  // [7:11] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_converter_proto_init() }
+func file_converter_proto_init() {
+	if File_converter_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_converter_proto_rawDesc), len(file_converter_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_converter_proto_goTypes,
+		DependencyIndexes: file_converter_proto_depIdxs,
+		EnumInfos:         file_converter_proto_enumTypes,
+		MessageInfos:      file_converter_proto_msgTypes,
+	}.Build()
+	File_converter_proto = out.File
+	file_converter_proto_goTypes = nil
+	file_converter_proto_depIdxs = nil
+}
@@ -0,0 +1,268 @@
+// API gRPC xlsx2mariadb, dijalankan oleh perintah xlsx2mariadb -grpc-listen
+// ALAMAT serve bersama API REST. Pekerjaan dari kedua API masuk ke antrean
+// yang sama dan dijalankan satu per satu.
+//
+// Kode Go di direktori ini dibuat dari file ini dengan protoc-gen-go dan
+// protoc-gen-go-grpc:
+//
+//	protoc --go_out=. --go_opt=paths=source_relative \
+//	  --go-grpc_out=. --go-grpc_opt=paths=source_relative converter.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: converter.proto
+
+package converterpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Converter_Convert_FullMethodName        = "/xlsx2mariadb.v1.Converter/Convert"
+	Converter_GetStatus_FullMethodName      = "/xlsx2mariadb.v1.Converter/GetStatus"
+	Converter_StreamProgress_FullMethodName = "/xlsx2mariadb.v1.Converter/StreamProgress"
+	Converter_FetchArtifacts_FullMethodName = "/xlsx2mariadb.v1.Converter/FetchArtifacts"
+)
+
+// ConverterClient is the client API for Converter service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ConverterClient interface {
+	// Convert membuat pekerjaan baru: konversi file di direktori xlsx ke
+	// SQLTable dan SQLData, atau pembuatan tabel dan pemuatan data.
+	Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*Job, error)
+	// GetStatus mengembalikan status pekerjaan beserta status setiap file.
+	GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*Job, error)
+	// StreamProgress mengirim kejadian kemajuan (sama dengan -progress json)
+	// sampai pekerjaan job_id selesai, atau sampai klien berhenti bila job_id
+	// kosong.
+	StreamProgress(ctx context.Context, in *StreamProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProgressEvent], error)
+	// FetchArtifacts mengirim file SQL hasil konversi satu tabel
+	// (SQLTable/<tabel>.sql dan file datanya) dalam potongan berurutan.
+	FetchArtifacts(ctx context.Context, in *FetchArtifactsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Artifact], error)
+}
+
+type converterClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewConverterClient(cc grpc.ClientConnInterface) ConverterClient {
+	return &converterClient{cc}
+}
+
+func (c *converterClient) Convert(ctx context.Context, in *ConvertRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Converter_Convert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *converterClient) GetStatus(ctx context.Context, in *GetStatusRequest, opts ...grpc.CallOption) (*Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Job)
+	err := c.cc.Invoke(ctx, Converter_GetStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *converterClient) StreamProgress(ctx context.Context, in *StreamProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ProgressEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Converter_ServiceDesc.Streams[0], Converter_StreamProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamProgressRequest, ProgressEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Converter_StreamProgressClient = grpc.ServerStreamingClient[ProgressEvent]
+
+func (c *converterClient) FetchArtifacts(ctx context.Context, in *FetchArtifactsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Artifact], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Converter_ServiceDesc.Streams[1], Converter_FetchArtifacts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[FetchArtifactsRequest, Artifact]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Converter_FetchArtifactsClient = grpc.ServerStreamingClient[Artifact]
+
+// ConverterServer is the server API for Converter service.
+// All implementations must embed UnimplementedConverterServer
+// for forward compatibility.
+type ConverterServer interface {
+	// Convert membuat pekerjaan baru: konversi file di direktori xlsx ke
+	// SQLTable dan SQLData, atau pembuatan tabel dan pemuatan data.
+	Convert(context.Context, *ConvertRequest) (*Job, error)
+	// GetStatus mengembalikan status pekerjaan beserta status setiap file.
+	GetStatus(context.Context, *GetStatusRequest) (*Job, error)
+	// StreamProgress mengirim kejadian kemajuan (sama dengan -progress json)
+	// sampai pekerjaan job_id selesai, atau sampai klien berhenti bila job_id
+	// kosong.
+	StreamProgress(*StreamProgressRequest, grpc.ServerStreamingServer[ProgressEvent]) error
+	// FetchArtifacts mengirim file SQL hasil konversi satu tabel
+	// (SQLTable/<tabel>.sql dan file datanya) dalam potongan berurutan.
+	FetchArtifacts(*FetchArtifactsRequest, grpc.ServerStreamingServer[Artifact]) error
+	mustEmbedUnimplementedConverterServer()
+}
+
+// UnimplementedConverterServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedConverterServer struct{}
+
+func (UnimplementedConverterServer) Convert(context.Context, *ConvertRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Convert not implemented")
+}
+func (UnimplementedConverterServer) GetStatus(context.Context, *GetStatusRequest) (*Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
+}
+func (UnimplementedConverterServer) StreamProgress(*StreamProgressRequest, grpc.ServerStreamingServer[ProgressEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamProgress not implemented")
+}
+func (UnimplementedConverterServer) FetchArtifacts(*FetchArtifactsRequest, grpc.ServerStreamingServer[Artifact]) error {
+	return status.Errorf(codes.Unimplemented, "method FetchArtifacts not implemented")
+}
+func (UnimplementedConverterServer) mustEmbedUnimplementedConverterServer() {}
+func (UnimplementedConverterServer) testEmbeddedByValue()                   {}
+
+// UnsafeConverterServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ConverterServer will
+// result in compilation errors.
+type UnsafeConverterServer interface {
+	mustEmbedUnimplementedConverterServer()
+}
+
+func RegisterConverterServer(s grpc.ServiceRegistrar, srv ConverterServer) {
+	// If the following call pancis, it indicates UnimplementedConverterServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Converter_ServiceDesc, srv)
+}
+
+func _Converter_Convert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConverterServer).Convert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Converter_Convert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConverterServer).Convert(ctx, req.(*ConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Converter_GetStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConverterServer).GetStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Converter_GetStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConverterServer).GetStatus(ctx, req.(*GetStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Converter_StreamProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConverterServer).StreamProgress(m, &grpc.GenericServerStream[StreamProgressRequest, ProgressEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Converter_StreamProgressServer = grpc.ServerStreamingServer[ProgressEvent]
+
+func _Converter_FetchArtifacts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(FetchArtifactsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ConverterServer).FetchArtifacts(m, &grpc.GenericServerStream[FetchArtifactsRequest, Artifact]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Converter_FetchArtifactsServer = grpc.ServerStreamingServer[Artifact]
+
+// Converter_ServiceDesc is the grpc.ServiceDesc for Converter service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Converter_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "xlsx2mariadb.v1.Converter",
+	HandlerType: (*ConverterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Convert",
+			Handler:    _Converter_Convert_Handler,
+		},
+		{
+			MethodName: "GetStatus",
+			Handler:    _Converter_GetStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamProgress",
+			Handler:       _Converter_StreamProgress_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "FetchArtifacts",
+			Handler:       _Converter_FetchArtifacts_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "converter.proto",
+}
@@ -0,0 +1,319 @@
+// Package ddl membentuk nama tabel dan kolom serta pernyataan CREATE TABLE
+// dari header sheet dan tipe kolom hasil inferensi.
+package ddl
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// SanitizeTableName membuang semua karakter selain huruf dan angka dari nama
+// file sehingga dapat dipakai sebagai nama tabel.
+func SanitizeTableName(fileName string) string {
+	return nonAlphanumeric.ReplaceAllString(fileName, "")
+}
+
+// SanitizeColumnName mengubah teks header menjadi nama kolom huruf kecil
+// tanpa karakter selain huruf dan angka.
+func SanitizeColumnName(columnName string) string {
+	return nonAlphanumeric.ReplaceAllString(strings.ToLower(columnName), "")
+}
+
+// Column adalah satu kolom data tabel. Comment berisi teks header asli.
+type Column struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Comment string `json:"comment"`
+
+	// Collation, bila diisi, menggantikan collation tabel untuk kolom ini,
+	// misalnya utf8mb4_bin agar kolom kode dibandingkan peka huruf besar/kecil.
+	// Hanya berlaku untuk tipe teks (lihat Collatable) pada MariaDB.
+	Collation string `json:"collation,omitempty"`
+
+	// Encrypted menyimpan nilai kolom sebagai hasil AES_ENCRYPT dengan tipe
+	// EncryptedType; Type tetap tipe nilai aslinya (lihat DecryptView)
+	Encrypted bool `json:"encrypted,omitempty"`
+}
+
+// Collatable mengembalikan true bila columnType adalah tipe teks yang dapat
+// diberi COLLATE.
+func Collatable(columnType string) bool {
+	columnType = strings.ToUpper(columnType)
+	for _, prefix := range []string{"CHAR", "VARCHAR", "TINYTEXT", "TEXT", "MEDIUMTEXT", "LONGTEXT", "ENUM", "SET"} {
+		if columnType == prefix || strings.HasPrefix(columnType, prefix+"(") {
+			return true
+		}
+	}
+	return false
+}
+
+// stringEscaper meloloskan teks untuk literal string MariaDB. Baris baru
+// ditulis sebagai \n agar setiap kolom CREATE TABLE tetap satu baris.
+var stringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+
+// quoteString mengembalikan value sebagai literal string MariaDB, misalnya
+// untuk COMMENT kolom yang berisi teks header apa adanya.
+func quoteString(value string) string {
+	return "'" + stringEscaper.Replace(value) + "'"
+}
+
+// Columns membentuk kolom dari teks header dan tipe kolom pada posisi yang
+// sama, dengan nama kolom dari SanitizeColumnName.
+func Columns(header, types []string) []Column {
+	return NamingCompact.Columns(header, types)
+}
+
+// IDTypes adalah tipe yang dapat dipakai untuk IDColumn.
+var IDTypes = []string{"TINYINT", "SMALLINT", "MEDIUMINT", "INT", "BIGINT"}
+
+// IDColumn mengatur kolom primary key AUTO_INCREMENT. Nilai nol berarti kolom
+// <tabel>_id INT seperti sebelumnya.
+type IDColumn struct {
+	// Name adalah nama kolom, default <tabel>_id
+	Name string
+
+	// Type adalah salah satu IDTypes, default INT
+	Type string
+
+	// Unsigned menambahkan UNSIGNED sehingga jangkauan positif menjadi dua kali lipat
+	Unsigned bool
+}
+
+// NameFor mengembalikan nama kolom id untuk tabel tableName.
+func (c IDColumn) NameFor(tableName string) string {
+	return c.nameFor(tableName, NamingCompact)
+}
+
+func (c IDColumn) nameFor(tableName string, naming Naming) string {
+	if c.Name == "" {
+		return naming.ID(tableName)
+	}
+	return c.Name
+}
+
+// Definition mengembalikan tipe kolom id, misalnya INT atau BIGINT UNSIGNED.
+func (c IDColumn) Definition() string {
+	columnType := strings.ToUpper(c.Type)
+	if columnType == "" {
+		columnType = "INT"
+	}
+	if c.Unsigned {
+		columnType += " UNSIGNED"
+	}
+	return columnType
+}
+
+// CreateMode menentukan perilaku CREATE TABLE bila tabel sudah ada.
+type CreateMode string
+
+const (
+	// CreatePlain menghasilkan CREATE TABLE yang gagal bila tabel sudah ada
+	CreatePlain CreateMode = "plain"
+
+	// CreateIfNotExists mempertahankan tabel yang sudah ada beserta isinya
+	CreateIfNotExists CreateMode = "if-not-exists"
+
+	// CreateOrReplace mengganti tabel yang sudah ada (CREATE OR REPLACE TABLE MariaDB)
+	CreateOrReplace CreateMode = "replace"
+)
+
+func (m CreateMode) keyword() string {
+	switch m {
+	case CreateIfNotExists:
+		return "CREATE TABLE IF NOT EXISTS"
+	case CreateOrReplace:
+		return "CREATE OR REPLACE TABLE"
+	default:
+		return "CREATE TABLE"
+	}
+}
+
+// TenantColumn dan TenantType adalah nama dan tipe kolom identitas tenant
+// yang ditambahkan sebagai kolom pertama bila TableOptions.Tenant. Nama
+// dengan garis bawah tidak mungkin dihasilkan SanitizeColumnName sehingga
+// tidak bentrok dengan kolom data.
+const (
+	TenantColumn = "tenant_id"
+	TenantType   = "VARCHAR(64)"
+)
+
+// CreatedAtColumn dan UpdatedAtColumn adalah kolom waktu pencatatan yang
+// ditambahkan di akhir tabel bila TableOptions.Timestamps. Keduanya diisi
+// database, bukan oleh INSERT hasil konversi.
+const (
+	CreatedAtColumn = "created_at"
+	UpdatedAtColumn = "updated_at"
+)
+
+// TableOptions mengatur pernyataan yang dibentuk CreateTableWithOptions.
+// Nilai nol menghasilkan pernyataan yang sama dengan CreateTable.
+type TableOptions struct {
+	ID   IDColumn
+	Mode CreateMode
+
+	// Dialect membentuk CREATE TABLE untuk database tujuan selain MariaDB;
+	// Engine, RowFormat, KeyBlockSize dan Extra diabaikan
+	Dialect Dialect
+
+	// Engine adalah storage engine, misalnya InnoDB, Aria, MyISAM atau
+	// ColumnStore (default INNODB)
+	Engine string
+
+	// RowFormat diisi ke ROW_FORMAT, misalnya DYNAMIC atau COMPRESSED
+	RowFormat string
+
+	// KeyBlockSize diisi ke KEY_BLOCK_SIZE dalam KB, 0 berarti tidak ditulis
+	KeyBlockSize int
+
+	// Extra ditambahkan apa adanya setelah opsi lain, misalnya
+	// "DEFAULT CHARSET=utf8mb4 COMMENT='impor harian'"
+	Extra string
+
+	// Naming menentukan bentuk nama kolom id dan indeks, sama dengan
+	// kebijakan yang membentuk nama tabel dan kolom (default NamingCompact)
+	Naming Naming
+
+	// Tenant berarti kolom pertama adalah TenantColumn: kolom itu NOT NULL
+	// dan indeks dibentuk gabungan dengan kolom data pertama
+	Tenant bool
+
+	// Generated ditambahkan setelah kolom data, beserta indeksnya bila
+	// GeneratedColumn.Index. ColumnStore tidak mendukung kolom turunan
+	// sehingga diabaikan.
+	Generated []GeneratedColumn
+
+	// NoDataIndex menghilangkan indeks pada kolom data pertama, misalnya bila
+	// indeks ditentukan dari kardinalitas data setelah pemuatan
+	NoDataIndex bool
+
+	// Timestamps menambahkan CreatedAtColumn dengan DEFAULT CURRENT_TIMESTAMP
+	// dan UpdatedAtColumn yang juga diperbarui setiap baris diubah (ON UPDATE
+	// CURRENT_TIMESTAMP). Diabaikan pada ColumnStore.
+	Timestamps bool
+}
+
+// CreateTable membentuk pernyataan CREATE TABLE dengan kolom <tabel>_id
+// AUTO_INCREMENT sebagai primary key dan indeks pada kolom data pertama.
+func CreateTable(tableName string, columns []Column) string {
+	return CreateTableWithOptions(tableName, columns, TableOptions{})
+}
+
+// CreateTableWithID sama dengan CreateTable dengan kolom primary key sesuai id.
+func CreateTableWithID(tableName string, columns []Column, id IDColumn) string {
+	return CreateTableWithOptions(tableName, columns, TableOptions{ID: id})
+}
+
+// CreateTableWithOptions sama dengan CreateTable dengan kolom primary key
+// dan perilaku terhadap tabel yang sudah ada sesuai options. Dengan Engine
+// ColumnStore, yang tidak mendukung primary key dan indeks, tabel hanya
+// berisi kolom data dengan tipe dari ColumnStoreType.
+func CreateTableWithOptions(tableName string, columns []Column, options TableOptions) string {
+	if options.Dialect != "" {
+		return options.Dialect.createTable(tableName, columns, options)
+	}
+	columnStore := strings.EqualFold(options.Engine, "ColumnStore")
+	idName := options.ID.nameFor(tableName, options.Naming)
+	var buffer strings.Builder
+	fmt.Fprintf(&buffer, "%s %s (\n", options.Mode.keyword(), tableName)
+	if !columnStore {
+		fmt.Fprintf(&buffer, "%s %s NOT NULL AUTO_INCREMENT COMMENT 'row ID',\n", idName, options.ID.Definition())
+	}
+	for i, column := range columns {
+		if i > 0 {
+			buffer.WriteString(",\n")
+		}
+		columnType := column.Type
+		if columnStore {
+			columnType = ColumnStoreType(columnType)
+		}
+		null := "DEFAULT NULL"
+		if options.Tenant && i == 0 {
+			null = "NOT NULL"
+		}
+		if column.Encrypted {
+			columnType = EncryptedType(columnType)
+		} else if column.Collation != "" && Collatable(columnType) {
+			columnType += " COLLATE " + column.Collation
+		}
+		fmt.Fprintf(&buffer, "%s %s %s COMMENT %s", column.Name, columnType, null, quoteString(column.Comment))
+	}
+	if !columnStore {
+		for _, column := range options.Generated {
+			fmt.Fprintf(&buffer, ",\n%s %s", column.Name, column.Definition())
+		}
+		if options.Timestamps {
+			fmt.Fprintf(&buffer, ",\n%s TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'row created'", options.Naming.Fixed(CreatedAtColumn))
+			fmt.Fprintf(&buffer, ",\n%s TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'row updated'", options.Naming.Fixed(UpdatedAtColumn))
+		}
+	}
+
+	if !columnStore {
+		// Menambahkan Primary Key
+		fmt.Fprintf(&buffer, ",\nPRIMARY KEY (%s)", idName)
+
+		// Contoh menambahkan indeks untuk kolom yang sering digunakan dalam WHERE atau JOIN
+		// Misalnya kita anggap kolom pertama (selain id) sering digunakan dalam WHERE atau JOIN
+		data := columns
+		if options.Tenant {
+			data = columns[1:]
+		}
+		if len(data) > 1 && !options.NoDataIndex {
+			fmt.Fprintf(&buffer, ",\nINDEX %s (%s)", options.Naming.Index(data[0].Name), data[0].Name)
+		}
+		if options.Tenant {
+			fmt.Fprintf(&buffer, ",\nINDEX %s (%s)", options.Naming.Index(columns[0].Name), tenantIndex(columns))
+		}
+		for _, column := range options.Generated {
+			if column.Index {
+				fmt.Fprintf(&buffer, ",\nINDEX %s (%s)", options.Naming.Index(column.Name), column.Name)
+			}
+		}
+	}
+
+	engine := options.Engine
+	if engine == "" {
+		engine = "INNODB"
+	}
+	fmt.Fprintf(&buffer, "\n) ENGINE = %s", engine)
+	if options.RowFormat != "" {
+		fmt.Fprintf(&buffer, " ROW_FORMAT = %s", options.RowFormat)
+	}
+	if options.KeyBlockSize > 0 {
+		fmt.Fprintf(&buffer, " KEY_BLOCK_SIZE = %d", options.KeyBlockSize)
+	}
+	if options.Extra != "" {
+		fmt.Fprintf(&buffer, " %s", options.Extra)
+	}
+	buffer.WriteString(";")
+	return buffer.String()
+}
+
+// tenantIndex mengembalikan kolom indeks tenant: kolom tenant (kolom pertama
+// columns) dan kolom data pertama, sehingga query per tenant yang memfilter
+// kolom itu memakai satu indeks.
+func tenantIndex(columns []Column) string {
+	if len(columns) == 1 {
+		return columns[0].Name
+	}
+	return columns[0].Name + ", " + columns[1].Name
+}
+
+// ColumnStoreType mengganti tipe hasil inferensi yang tidak didukung MariaDB
+// ColumnStore dengan padanannya.
+func ColumnStoreType(columnType string) string {
+	switch columnType {
+	case "BOOLEAN":
+		return "TINYINT"
+	case "YEAR":
+		return "SMALLINT"
+	case "JSON":
+		return "LONGTEXT"
+	case "UUID":
+		return "CHAR(36)"
+	default:
+		return columnType
+	}
+}
@@ -0,0 +1,19 @@
+package ddl
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCreateTableCommentEscaped(t *testing.T) {
+	header := []string{"x'; DROP TABLE t; --", "a\\b\nc"}
+	sql := CreateTable("t", Columns(header, []string{"VARCHAR(10)", "TEXT"}))
+	for _, want := range []string{
+		`xdroptablet VARCHAR(10) DEFAULT NULL COMMENT 'x\'; DROP TABLE t; --'`,
+		`abc TEXT DEFAULT NULL COMMENT 'a\\b\nc'`,
+	} {
+		if !strings.Contains(sql, want) {
+			t.Errorf("CreateTable tidak berisi %q:\n%s", want, sql)
+		}
+	}
+}
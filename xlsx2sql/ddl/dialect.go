@@ -0,0 +1,186 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dialect adalah database tujuan selain MariaDB yang memuat data dari file
+// CSV dengan COPY. Dialect kosong berarti MariaDB.
+type Dialect string
+
+const (
+	Vertica  Dialect = "vertica"
+	Redshift Dialect = "redshift"
+	Postgres Dialect = "postgres"
+)
+
+// Type mengganti tipe hasil inferensi (tipe MariaDB) dengan padanannya pada d.
+func (d Dialect) Type(columnType string) string {
+	switch columnType {
+	case "INT":
+		return "INTEGER"
+	case "FLOAT":
+		if d == Vertica {
+			return "FLOAT"
+		}
+		return "REAL"
+	case "DOUBLE":
+		if d == Vertica {
+			return "FLOAT"
+		}
+		return "DOUBLE PRECISION"
+	case "DATETIME":
+		return "TIMESTAMP"
+	case "DATETIME(3)", "DATETIME(6)":
+		// Presisi pecahan detik tipe dari skema Parquet dipertahankan
+		return "TIMESTAMP" + strings.TrimPrefix(columnType, "DATETIME")
+	case "YEAR":
+		return "SMALLINT"
+	case "JSON":
+		if d == Postgres {
+			return "JSONB"
+		}
+		return d.Type("LONGTEXT")
+	case "UUID":
+		if d == Postgres {
+			return "UUID"
+		}
+		return "CHAR(36)"
+	case "TEXT", "MEDIUMTEXT", "LONGTEXT":
+		switch d {
+		case Vertica:
+			return "LONG VARCHAR(32000000)"
+		case Redshift:
+			// Nilai lebih dari 65535 byte ditolak Redshift
+			return "VARCHAR(65535)"
+		}
+		return "TEXT"
+	default:
+		return columnType
+	}
+}
+
+// identity mengembalikan definisi kolom id yang diisi otomatis oleh d.
+func (d Dialect) identity(id IDColumn) string {
+	columnType := "BIGINT"
+	if strings.EqualFold(id.Type, "INT") || id.Type == "" {
+		columnType = "INTEGER"
+	}
+	switch d {
+	case Vertica:
+		return "IDENTITY(1,1)"
+	case Redshift:
+		return columnType + " IDENTITY(1,1)"
+	default:
+		return columnType + " GENERATED BY DEFAULT AS IDENTITY"
+	}
+}
+
+// createTable membentuk CREATE TABLE untuk d. Kolom id ikut dibuat tetapi
+// tidak ada pada file CSV, sehingga COPY menyebutkan kolom data saja. Nama
+// collation MariaDB pada Column.Collation tidak dikenal d sehingga diabaikan. Teks
+// header asli ditulis dengan COMMENT ON COLUMN pada Redshift dan PostgreSQL.
+// Kolom turunan hanya dibuat pada PostgreSQL, selalu sebagai STORED karena
+// PostgreSQL tidak mengenal kolom VIRTUAL; ekspresinya harus ditulis dalam
+// SQL PostgreSQL. Kolom waktu pencatatan memakai DEFAULT waktu sekarang;
+// UpdatedAtColumn diperbarui dengan trigger pada PostgreSQL, sedangkan
+// Vertica dan Redshift tidak mengenal trigger sehingga kolom itu hanya
+// berisi waktu baris dibuat.
+func (d Dialect) createTable(tableName string, columns []Column, options TableOptions) string {
+	idName := d.quote(options.ID.nameFor(tableName, options.Naming))
+	table := d.quote(tableName)
+	quoted := make([]Column, len(columns))
+	for i, column := range columns {
+		quoted[i] = column
+		quoted[i].Name = d.quote(column.Name)
+	}
+	var buffer strings.Builder
+	create := "CREATE TABLE"
+	if options.Mode == CreateIfNotExists {
+		create = "CREATE TABLE IF NOT EXISTS"
+	}
+	if options.Mode == CreateOrReplace {
+		fmt.Fprintf(&buffer, "DROP TABLE IF EXISTS %s;\n", table)
+	}
+	fmt.Fprintf(&buffer, "%s %s (\n", create, table)
+	fmt.Fprintf(&buffer, "%s %s NOT NULL,\n", idName, d.identity(options.ID))
+	for i, column := range quoted {
+		null := ""
+		if options.Tenant && i == 0 {
+			null = " NOT NULL"
+		}
+		fmt.Fprintf(&buffer, "%s %s%s,\n", column.Name, d.Type(column.Type), null)
+	}
+	if d == Postgres {
+		for _, column := range options.Generated {
+			fmt.Fprintf(&buffer, "%s %s GENERATED ALWAYS AS (%s) STORED,\n", d.quote(column.Name), d.Type(column.columnType()), column.Expr)
+		}
+	}
+	if options.Timestamps {
+		now := "CURRENT_TIMESTAMP"
+		if d == Redshift {
+			now = "GETDATE()"
+		}
+		for _, name := range []string{CreatedAtColumn, UpdatedAtColumn} {
+			fmt.Fprintf(&buffer, "%s TIMESTAMP NOT NULL DEFAULT %s,\n", d.quote(options.Naming.Fixed(name)), now)
+		}
+	}
+	fmt.Fprintf(&buffer, "PRIMARY KEY (%s)\n);", idName)
+	if options.Timestamps && d == Postgres {
+		function := d.quote(options.Naming.Concat(tableName, options.Naming.Fixed(UpdatedAtColumn)))
+		fmt.Fprintf(&buffer, "\nCREATE OR REPLACE FUNCTION %s() RETURNS trigger AS $$\nBEGIN\nNEW.%s := CURRENT_TIMESTAMP;\nRETURN NEW;\nEND\n$$ LANGUAGE plpgsql;", function, d.quote(options.Naming.Fixed(UpdatedAtColumn)))
+		fmt.Fprintf(&buffer, "\nDROP TRIGGER IF EXISTS %s ON %s;", function, table)
+		fmt.Fprintf(&buffer, "\nCREATE TRIGGER %s BEFORE UPDATE ON %s FOR EACH ROW EXECUTE FUNCTION %s();", function, table, function)
+	}
+	// Vertica dan Redshift tidak mengenal CREATE INDEX. Nama indeks PostgreSQL
+	// berlaku per schema sehingga memuat nama tabel.
+	if options.Tenant && d == Postgres {
+		index := d.quote(options.Naming.Index(options.Naming.Concat(tableName, columns[0].Name)))
+		fmt.Fprintf(&buffer, "\nCREATE INDEX IF NOT EXISTS %s ON %s (%s);", index, table, tenantIndex(quoted))
+	}
+	if d == Postgres {
+		for _, column := range options.Generated {
+			if column.Index {
+				index := d.quote(options.Naming.Index(options.Naming.Concat(tableName, column.Name)))
+				fmt.Fprintf(&buffer, "\nCREATE INDEX IF NOT EXISTS %s ON %s (%s);", index, table, d.quote(column.Name))
+			}
+		}
+	}
+	if d == Redshift || d == Postgres {
+		for _, column := range quoted {
+			fmt.Fprintf(&buffer, "\nCOMMENT ON COLUMN %s.%s IS '%s';", table, column.Name, strings.ReplaceAll(column.Comment, "'", "''"))
+		}
+	}
+	return buffer.String()
+}
+
+// Copy membentuk perintah COPY d yang memuat file CSV location (ditulis
+// dengan writer.CSVFormat) ke kolom data tableName. Pada Redshift location
+// biasanya path S3, dan kredensial diambil dari IAM role default cluster.
+func (d Dialect) Copy(tableName string, columns []Column, location string) string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = d.quote(column.Name)
+	}
+	target := fmt.Sprintf("%s (%s)", d.quote(tableName), strings.Join(names, ", "))
+	location = strings.ReplaceAll(location, "'", "''")
+	switch d {
+	case Vertica:
+		return fmt.Sprintf("COPY %s FROM LOCAL '%s' PARSER fcsvparser(header='false') ABORT ON ERROR;", target, location)
+	case Redshift:
+		return fmt.Sprintf("COPY %s FROM '%s' IAM_ROLE default FORMAT AS CSV EMPTYASNULL;", target, location)
+	default:
+		// Meta-command psql membaca file di sisi klien dan harus satu baris
+		return fmt.Sprintf("\\copy %s FROM '%s' WITH (FORMAT csv)", target, location)
+	}
+}
+
+// quote mengganti kutipan backtick NamingOriginal dengan tanda kutip ganda
+// standar SQL yang dipakai d.
+func (d Dialect) quote(name string) string {
+	if !isQuoted(name) {
+		return name
+	}
+	return `"` + strings.ReplaceAll(Unquote(name), `"`, `""`) + `"`
+}
@@ -0,0 +1,115 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KeyVariable adalah variabel sesi MariaDB berisi kunci enkripsi kolom yang
+// dipakai AES_ENCRYPT pada INSERT dan AES_DECRYPT pada view dekripsi. Kunci
+// tidak pernah ditulis ke file SQL: pemuat mengisinya pada setiap koneksi,
+// pembaca view mengisinya sendiri dengan SET @xlsx2sql_key = '...'.
+const KeyVariable = "@xlsx2sql_key"
+
+// KeyFunction adalah fungsi tersimpan yang mengembalikan KeyVariable, karena
+// definisi view MariaDB tidak boleh merujuk variabel sesi secara langsung.
+const KeyFunction = "xlsx2sql_key"
+
+// encryptedOtherSize adalah panjang VARBINARY kolom terenkripsi bertipe
+// selain teks: cukup untuk teks angka dan tanggal terpanjang, yaitu
+// DECIMAL(65,30) sepanjang 67 karakter.
+const encryptedOtherSize = 80
+
+// EncryptedType mengembalikan tipe kolom untuk nilai columnType yang disimpan
+// sebagai hasil AES_ENCRYPT. AES menambahkan padding sampai kelipatan 16
+// byte berikutnya, sehingga VARCHAR(n) utf8mb4 menjadi VARBINARY dengan
+// ruang 4n byte ditambah satu blok, dan TEXT menjadi BLOB satu tingkat lebih
+// besar.
+func EncryptedType(columnType string) string {
+	base := strings.ToUpper(strings.TrimSpace(columnType))
+	var n int
+	if i := strings.IndexByte(base, '('); i >= 0 {
+		fmt.Sscanf(base[i+1:], "%d", &n)
+		base = strings.TrimSpace(base[:i])
+	}
+	switch base {
+	case "CHAR", "VARCHAR":
+		size := 16 * (4*max(n, 1)/16 + 1)
+		if size > MaxRowSize {
+			return "MEDIUMBLOB"
+		}
+		return fmt.Sprintf("VARBINARY(%d)", size)
+	case "TINYTEXT":
+		return "BLOB"
+	case "TEXT":
+		return "MEDIUMBLOB"
+	case "MEDIUMTEXT", "LONGTEXT", "JSON":
+		return "LONGBLOB"
+	}
+	return fmt.Sprintf("VARBINARY(%d)", encryptedOtherSize)
+}
+
+// decryptedCast mengembalikan ekspresi yang mengubah hasil AES_DECRYPT
+// expr kembali ke tipe columnType.
+func decryptedCast(expr, columnType string) string {
+	upper := strings.ToUpper(strings.TrimSpace(columnType))
+	base := upper
+	if i := strings.IndexByte(base, '('); i >= 0 {
+		base = strings.TrimSpace(base[:i])
+	}
+	unsigned := strings.HasSuffix(upper, " UNSIGNED")
+	base = strings.TrimSuffix(base, " UNSIGNED")
+	switch base {
+	case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT", "BOOLEAN", "BOOL", "YEAR":
+		if unsigned {
+			return fmt.Sprintf("CAST(%s AS UNSIGNED)", expr)
+		}
+		return fmt.Sprintf("CAST(%s AS SIGNED)", expr)
+	case "FLOAT", "DOUBLE":
+		return fmt.Sprintf("CAST(%s AS DOUBLE)", expr)
+	case "DECIMAL", "NUMERIC", "DATE", "DATETIME", "TIME":
+		return fmt.Sprintf("CAST(%s AS %s)", expr, upper)
+	case "TIMESTAMP":
+		return fmt.Sprintf("CAST(%s AS DATETIME%s)", expr, strings.TrimPrefix(upper, "TIMESTAMP"))
+	}
+	return fmt.Sprintf("CONVERT(%s USING utf8mb4)", expr)
+}
+
+// DecryptView membentuk fungsi KeyFunction (bila belum ada) dan view
+// <tabel>_decrypted yang menampilkan tabel tableName dengan kolom
+// Encrypted sudah didekripsi ke tipe aslinya. Kolom tambahan dari options
+// (id, kolom turunan dan waktu pencatatan) ikut ditampilkan apa adanya.
+// Tanpa kolom Encrypted hasilnya kosong.
+func DecryptView(tableName string, columns []Column, options TableOptions) string {
+	encrypted := false
+	for _, column := range columns {
+		encrypted = encrypted || column.Encrypted
+	}
+	if !encrypted {
+		return ""
+	}
+	columnStore := strings.EqualFold(options.Engine, "ColumnStore")
+	var selected []string
+	if !columnStore {
+		selected = append(selected, options.ID.nameFor(tableName, options.Naming))
+	}
+	for _, column := range columns {
+		if column.Encrypted {
+			selected = append(selected, fmt.Sprintf("%s AS %s", decryptedCast(fmt.Sprintf("AES_DECRYPT(%s, %s())", column.Name, KeyFunction), column.Type), column.Name))
+		} else {
+			selected = append(selected, column.Name)
+		}
+	}
+	if !columnStore {
+		for _, column := range options.Generated {
+			selected = append(selected, column.Name)
+		}
+		if options.Timestamps {
+			selected = append(selected, options.Naming.Fixed(CreatedAtColumn), options.Naming.Fixed(UpdatedAtColumn))
+		}
+	}
+	var buffer strings.Builder
+	fmt.Fprintf(&buffer, "CREATE FUNCTION IF NOT EXISTS %s() RETURNS VARBINARY(1024) NOT DETERMINISTIC NO SQL RETURN %s;\n", KeyFunction, KeyVariable)
+	fmt.Fprintf(&buffer, "CREATE OR REPLACE VIEW %s AS SELECT\n%s\nFROM %s;", options.Naming.Concat(tableName, "decrypted"), strings.Join(selected, ",\n"), tableName)
+	return buffer.String()
+}
@@ -0,0 +1,181 @@
+package ddl
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Naming adalah kebijakan bentuk nama tabel dan kolom yang dibentuk dari
+// nama file dan teks header, juga nama kolom id dan indeks turunannya.
+type Naming string
+
+const (
+	// NamingCompact membuang semua karakter selain huruf dan angka; nama
+	// kolom juga menjadi huruf kecil (SanitizeTableName dan
+	// SanitizeColumnName). Nilai kosong berarti NamingCompact.
+	NamingCompact Naming = "compact"
+
+	// NamingSnake memisahkan kata dengan garis bawah dalam huruf kecil,
+	// misalnya "Tanggal Lahir" dan TanggalLahir menjadi tanggal_lahir
+	NamingSnake Naming = "snake_case"
+
+	// NamingCamel menggabungkan kata dengan huruf besar di awal setiap kata
+	// kecuali kata pertama, misalnya "Tanggal Lahir" menjadi tanggalLahir
+	NamingCamel Naming = "lowerCamel"
+
+	// NamingOriginal mempertahankan teks asli (tanpa spasi di awal dan akhir)
+	// dan mengutipnya dengan backtick, misalnya `Tanggal Lahir`
+	NamingOriginal Naming = "original"
+)
+
+// Namings adalah semua nilai Naming yang dikenal.
+var Namings = []Naming{NamingCompact, NamingSnake, NamingCamel, NamingOriginal}
+
+// Table mengembalikan nama tabel untuk text menurut n. Nama yang sudah
+// dibentuk dengan n tidak berubah bila dilewatkan lagi.
+func (n Naming) Table(text string) string {
+	switch n {
+	case NamingSnake, NamingCamel:
+		return n.join(identWords(text))
+	case NamingOriginal:
+		return quoteIdent(text)
+	default:
+		return SanitizeTableName(text)
+	}
+}
+
+// Column mengembalikan nama kolom untuk teks header text menurut n.
+func (n Naming) Column(text string) string {
+	switch n {
+	case NamingSnake, NamingCamel, NamingOriginal:
+		return n.Table(text)
+	default:
+		return SanitizeColumnName(text)
+	}
+}
+
+// Columns sama dengan fungsi Columns dengan nama kolom menurut n.
+func (n Naming) Columns(header, types []string) []Column {
+	columns := make([]Column, len(header))
+	for i, colCell := range header {
+		columns[i] = Column{Name: n.Column(colCell), Type: types[i], Comment: colCell}
+	}
+	return columns
+}
+
+// ID mengembalikan nama kolom id untuk tabel tableName (nama yang sudah
+// dibentuk dengan n), misalnya penjualan_id atau penjualanId.
+func (n Naming) ID(tableName string) string {
+	return n.Concat(tableName, "id")
+}
+
+// Index mengembalikan nama indeks untuk kolom column (nama yang sudah
+// dibentuk dengan n), misalnya idx_nama atau idxNama.
+func (n Naming) Index(column string) string {
+	return n.Concat("idx", column)
+}
+
+// Concat menggabungkan dua nama yang sudah dibentuk dengan n menjadi satu
+// nama, misalnya penjualan dan id menjadi penjualan_id, penjualanId atau
+// `penjualan_id`.
+func (n Naming) Concat(a, b string) string {
+	switch n {
+	case NamingCamel:
+		return a + upperFirst(b)
+	case NamingOriginal:
+		return quoteIdent(Unquote(a) + "_" + Unquote(b))
+	default:
+		return a + "_" + b
+	}
+}
+
+// TenantColumn mengembalikan nama kolom TenantColumn menurut n.
+func (n Naming) TenantColumn() string {
+	return n.Fixed(TenantColumn)
+}
+
+// Fixed mengembalikan nama kolom tambahan bergaris bawah seperti
+// TenantColumn atau CreatedAtColumn menurut n. Pada NamingCompact garis
+// bawahnya dipertahankan sehingga tidak bentrok dengan kolom data.
+func (n Naming) Fixed(name string) string {
+	switch n {
+	case NamingCamel, NamingOriginal:
+		return n.Column(name)
+	default:
+		return name
+	}
+}
+
+func (n Naming) join(words []string) string {
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+		if n == NamingCamel && i > 0 {
+			words[i] = upperFirst(words[i])
+		}
+	}
+	if n == NamingCamel {
+		return strings.Join(words, "")
+	}
+	return strings.Join(words, "_")
+}
+
+// identWords memecah text menjadi kata huruf dan angka ASCII. Karakter lain
+// memisahkan kata, begitu juga pergantian huruf kecil ke huruf besar
+// (tanggalLahir) dan akhir singkatan (HTTPServer menjadi HTTP dan Server).
+func identWords(text string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(text)
+	for i, r := range runes {
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if len(word) > 0 {
+				words = append(words, string(word))
+				word = nil
+			}
+			continue
+		}
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextLower {
+				words = append(words, string(word))
+				word = nil
+			}
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, string(word))
+	}
+	return words
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// quoteIdent mengutip text dengan backtick. Nama yang sudah dikutip tidak
+// dikutip ulang.
+func quoteIdent(text string) string {
+	text = strings.TrimSpace(text)
+	if isQuoted(text) {
+		return text
+	}
+	return "`" + strings.ReplaceAll(text, "`", "``") + "`"
+}
+
+func isQuoted(name string) bool {
+	return len(name) >= 2 && strings.HasPrefix(name, "`") && strings.HasSuffix(name, "`")
+}
+
+// Unquote mengembalikan nama tanpa kutipan backtick NamingOriginal. Nama tanpa
+// kutipan dikembalikan apa adanya.
+func Unquote(name string) string {
+	if !isQuoted(name) {
+		return name
+	}
+	return strings.ReplaceAll(name[1:len(name)-1], "``", "`")
+}
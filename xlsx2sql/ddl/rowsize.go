@@ -0,0 +1,152 @@
+package ddl
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Batas InnoDB yang dipakai EstimateRow dan pemeriksaan skema.
+const (
+	// MaxRowSize adalah batas ukuran baris MariaDB menurut definisi kolom,
+	// tanpa isi BLOB dan TEXT
+	MaxRowSize = 65535
+
+	// MaxInnoDBColumns adalah jumlah kolom terbanyak satu tabel InnoDB
+	MaxInnoDBColumns = 1017
+
+	// InnoDBPageSize adalah ukuran halaman InnoDB default
+	InnoDBPageSize = 16384
+
+	// maxInlineRecord adalah ukuran record terbesar yang muat di halaman
+	// 16 KB; kolom panjang dipindah ke halaman overflow sampai record muat
+	maxInlineRecord = 8126
+
+	// recordOverhead adalah header record DYNAMIC ditambah DB_TRX_ID dan
+	// DB_ROLL_PTR
+	recordOverhead = 5 + 6 + 7
+
+	// offPagePointer adalah sisa kolom di record bila nilainya dipindah ke
+	// halaman overflow
+	offPagePointer = 20
+)
+
+// RowEstimate adalah perkiraan ukuran satu baris tabel InnoDB dengan
+// ROW_FORMAT DYNAMIC dan charset utf8mb4.
+type RowEstimate struct {
+	// DeclaredBytes adalah ukuran baris menurut definisi kolom yang dibatasi
+	// MaxRowSize: VARCHAR(n) 4n byte ditambah byte panjang, TEXT dan JSON
+	// 12 byte
+	DeclaredBytes int
+
+	// StoredBytes adalah perkiraan byte tersimpan per baris bila setiap kolom
+	// berisi nilai terpanjangnya, termasuk halaman overflow yang dipakai
+	// sendiri oleh setiap nilai yang dipindah
+	StoredBytes int
+
+	// OffPage adalah jumlah kolom yang nilai terpanjangnya dipindah ke
+	// halaman overflow
+	OffPage int
+}
+
+// EstimateRow memperkirakan ukuran baris tabel dengan columns ditambah kolom
+// id bertipe idType (kosong bila tanpa kolom id). lengths adalah panjang
+// nilai terpanjang setiap kolom dalam byte pada posisi yang sama, misalnya
+// dari inference.Result.MaxLength; tanpa panjang, VARCHAR dianggap penuh dan
+// TEXT kosong.
+func EstimateRow(columns []Column, lengths []int, idType string) RowEstimate {
+	var estimate RowEstimate
+	record := recordOverhead + (len(columns)+7)/8
+	if idType != "" {
+		size, _, _ := columnSize(idType, -1)
+		estimate.DeclaredBytes += size
+		record += size
+	}
+	// long berisi byte tersimpan kolom yang dapat dipindah ke halaman overflow
+	var long []int
+	for i, column := range columns {
+		length := -1
+		if i < len(lengths) {
+			length = lengths[i]
+		}
+		declared, stored, movable := columnSize(column.Type, length)
+		estimate.DeclaredBytes += declared
+		record += stored
+		if movable {
+			long = append(long, stored)
+		}
+	}
+
+	// Kolom terpanjang dipindah lebih dulu; nilai sampai 40 byte tetap di record
+	sort.Sort(sort.Reverse(sort.IntSlice(long)))
+	overflow := 0
+	for _, stored := range long {
+		if record <= maxInlineRecord || stored <= 40 {
+			break
+		}
+		record += offPagePointer - stored
+		overflow += (stored + InnoDBPageSize - 1) / InnoDBPageSize * InnoDBPageSize
+		estimate.OffPage++
+	}
+	estimate.StoredBytes = record + overflow
+	return estimate
+}
+
+// columnSize mengembalikan ukuran kolom columnType menurut definisinya dan
+// perkiraan byte tersimpan untuk nilai sepanjang length (negatif bila tidak
+// diketahui), serta apakah nilainya dapat dipindah ke halaman overflow.
+func columnSize(columnType string, length int) (declared, stored int, movable bool) {
+	base := strings.ToUpper(strings.TrimSpace(columnType))
+	var n int
+	if i := strings.IndexByte(base, '('); i >= 0 {
+		fmt.Sscanf(base[i+1:], "%d", &n)
+		base = strings.TrimSpace(base[:i])
+	}
+	base = strings.TrimSuffix(base, " UNSIGNED")
+	switch base {
+	case "TINYINT", "BOOLEAN", "BOOL", "YEAR":
+		return 1, 1, false
+	case "SMALLINT":
+		return 2, 2, false
+	case "MEDIUMINT", "DATE", "TIME":
+		return 3, 3, false
+	case "INT", "INTEGER", "FLOAT", "TIMESTAMP":
+		return 4, 4, false
+	case "BIGINT", "DOUBLE", "DATETIME":
+		return 8, 8, false
+	case "DECIMAL", "NUMERIC":
+		if n == 0 {
+			n = 10
+		}
+		return n/2 + 1, n/2 + 1, false
+	case "UUID":
+		return 16, 16, false
+	case "CHAR":
+		return 4 * max(n, 1), 4 * max(n, 1), false
+	case "VARCHAR":
+		declared = 4 * n
+		stored = declared
+		if length >= 0 {
+			stored = min(length, declared)
+		}
+		prefix := 1
+		if declared > 255 {
+			prefix = 2
+		}
+		return declared + prefix, stored + prefix, declared > 255
+	}
+	if isTextType(base) {
+		return 12, max(length, 0) + 2, true
+	}
+	return 8, 8, false
+}
+
+// isTextType mengembalikan true untuk tipe TEXT, BLOB dan JSON yang isinya
+// tidak dihitung pada MaxRowSize.
+func isTextType(columnType string) bool {
+	switch strings.ToUpper(columnType) {
+	case "TINYTEXT", "TEXT", "MEDIUMTEXT", "LONGTEXT", "JSON", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package xlsx2sql
+
+// EventKind adalah jenis kejadian yang dikirim ke Options.OnEvent.
+type EventKind int
+
+const (
+	// FileStarted dikirim sebelum input mulai dibaca
+	FileStarted EventKind = iota
+
+	// RowBatchWritten dikirim setiap EventRows baris data yang ditulis ke
+	// Options.Data atau diteruskan ke OnRow, dan sekali lagi untuk sisa baris
+	// terakhir
+	RowBatchWritten
+
+	// FileCompleted dikirim setelah konversi berhasil, dengan Event.Result
+	FileCompleted
+
+	// Error dikirim bila konversi gagal, dengan Event.Err yang sama dengan
+	// kesalahan yang dikembalikan
+	Error
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case FileStarted:
+		return "file_started"
+	case RowBatchWritten:
+		return "row_batch_written"
+	case FileCompleted:
+		return "file_completed"
+	case Error:
+		return "error"
+	}
+	return "unknown"
+}
+
+// EventRows adalah jumlah baris data per kejadian RowBatchWritten.
+const EventRows = 10000
+
+// Event adalah satu kejadian kemajuan konversi. Aplikasi dapat memakainya
+// untuk menampilkan kemajuan sendiri tanpa membaca log.
+type Event struct {
+	Kind EventKind
+
+	// File adalah path yang diberikan ke ConvertFile, kosong untuk Convert
+	// dan Generate
+	File string
+
+	// Table adalah nama tabel tujuan yang sudah disanitasi
+	Table string
+
+	// Rows adalah jumlah baris data yang sudah ditulis sejauh ini pada
+	// RowBatchWritten dan FileCompleted
+	Rows int
+
+	// Result berisi skema tabel pada RowBatchWritten dan hasil lengkap pada
+	// FileCompleted; nil pada FileStarted dan Error
+	Result *Result
+
+	Err error
+}
+
+// EventChannel mengembalikan fungsi untuk Options.OnEvent yang mengirim
+// kejadian ke ch. Pengiriman menahan konversi sampai ch dibaca, kecuali ch
+// memiliki buffer yang cukup.
+func EventChannel(ch chan<- Event) func(Event) {
+	return func(event Event) {
+		ch <- event
+	}
+}
+
+// emit memanggil opts.OnEvent bila diatur.
+func (opts *Options) emit(event Event) {
+	if opts.OnEvent != nil {
+		opts.OnEvent(event)
+	}
+}
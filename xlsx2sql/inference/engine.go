@@ -0,0 +1,333 @@
+package inference
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Result adalah hasil inferensi satu kolom.
+type Result struct {
+	// Type adalah tipe kolom MariaDB, misalnya INT atau VARCHAR(20)
+	Type string `json:"type"`
+
+	// Detector adalah nama detector yang menentukan Type
+	Detector string `json:"detector"`
+
+	// Confidence adalah proporsi nilai tidak kosong yang cocok dengan Type (0-1)
+	Confidence float64 `json:"confidence"`
+
+	NonEmpty  int `json:"non_empty"`
+	MaxLength int `json:"max_length"`
+}
+
+// Detector mengenali satu jenis tipe kolom. New membuat Matcher baru untuk
+// setiap kolom, sehingga detector dapat menyimpan state per kolom (misalnya
+// IntDetector mencatat apakah ada nilai di luar jangkauan INT).
+type Detector interface {
+	Name() string
+	New() Matcher
+}
+
+// Matcher adalah state satu Detector untuk satu kolom. Match dipanggil sekali
+// untuk setiap nilai tidak kosong yang sudah di-trim, Type setelah semua
+// nilai diterima. Tipe kosong berarti detector tidak berlaku.
+type Matcher interface {
+	Match(value string) bool
+	Type(maxLength int) string
+}
+
+// Engine mencoba Detectors berurutan dan memakai detector pertama yang
+// confidence-nya mencapai MinConfidence. Bila tidak ada, tipe teks sesuai
+// panjang nilai terpanjang dipakai.
+type Engine struct {
+	Detectors []Detector
+
+	// MinConfidence adalah proporsi nilai minimal agar detector dipakai,
+	// 0 berarti 1 (semua nilai harus cocok)
+	MinConfidence float64
+}
+
+// DefaultEngine adalah engine yang dipakai DetermineColumnType dan program
+// xlsx2mariadb.
+var DefaultEngine = &Engine{Detectors: DefaultDetectors()}
+
+// DefaultDetectors mengembalikan detector bawaan dengan urutan prioritasnya.
+// Salinan baru dikembalikan setiap pemanggilan sehingga aman diubah, misalnya
+// untuk menyisipkan detector sendiri sebelum TextDetector.
+func DefaultDetectors() []Detector {
+	return []Detector{
+		MatchDetector{"boolean", "BOOLEAN", isBoolean},
+		IntDetector{},
+		FloatDetector{},
+		MatchDetector{"date", "DATE", dateRegex.MatchString},
+		MatchDetector{"datetime", "DATETIME", datetimeRegex.MatchString},
+		MatchDetector{"timestamp", "TIMESTAMP", timestampRegex.MatchString},
+		MatchDetector{"time", "TIME", timeRegex.MatchString},
+		MatchDetector{"year", "YEAR", yearRegex.MatchString},
+		MatchDetector{"json", "JSON", jsonRegex.MatchString},
+		MatchDetector{"uuid", "UUID", uuidRegex.MatchString},
+		TextDetector{},
+	}
+}
+
+func (e *Engine) minConfidence() float64 {
+	if e.MinConfidence <= 0 {
+		return 1
+	}
+	return e.MinConfidence
+}
+
+// Column adalah state inferensi satu kolom yang diperbarui nilai demi nilai,
+// sehingga baris dapat dibaca satu kali tanpa menyalin nilai setiap kolom.
+type Column struct {
+	engine    *Engine
+	matchers  []Matcher
+	matched   []int
+	failed    []bool
+	nonEmpty  int
+	maxLength int
+}
+
+// NewColumn membuat state inferensi untuk satu kolom.
+func (e *Engine) NewColumn() *Column {
+	c := &Column{
+		engine:   e,
+		matchers: make([]Matcher, len(e.Detectors)),
+		matched:  make([]int, len(e.Detectors)),
+		failed:   make([]bool, len(e.Detectors)),
+	}
+	for i, detector := range e.Detectors {
+		c.matchers[i] = detector.New()
+	}
+	return c
+}
+
+// Add menambahkan satu nilai sel. Nilai kosong diabaikan. Bila semua nilai
+// harus cocok (MinConfidence 1), detector yang sudah gagal tidak diperiksa lagi.
+func (c *Column) Add(value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	c.nonEmpty++
+	if len(value) > c.maxLength {
+		c.maxLength = len(value)
+	}
+	strict := c.engine.minConfidence() >= 1
+	for i, matcher := range c.matchers {
+		if strict && c.failed[i] {
+			continue
+		}
+		if matcher.Match(value) {
+			c.matched[i]++
+		} else {
+			c.failed[i] = true
+		}
+	}
+}
+
+// Result menentukan tipe kolom dari nilai-nilai yang sudah ditambahkan. Kolom
+// yang seluruhnya kosong diberi tipe dari detector pertama yang berlaku
+// dengan Confidence 0.
+func (c *Column) Result() Result {
+	result := Result{NonEmpty: c.nonEmpty, MaxLength: c.maxLength}
+	for i, matcher := range c.matchers {
+		columnType := matcher.Type(c.maxLength)
+		if columnType == "" {
+			continue
+		}
+		confidence := 0.0
+		if c.nonEmpty > 0 {
+			confidence = float64(c.matched[i]) / float64(c.nonEmpty)
+		}
+		if c.nonEmpty == 0 || confidence >= c.engine.minConfidence() {
+			result.Type = columnType
+			result.Detector = c.engine.Detectors[i].Name()
+			result.Confidence = confidence
+			return result
+		}
+	}
+	result.Type = TextType(c.maxLength)
+	result.Detector = "text"
+	result.Confidence = 1
+	return result
+}
+
+// Infer menentukan tipe kolom dari data, setara dengan menambahkan setiap
+// nilai ke NewColumn.
+func (e *Engine) Infer(data []string) Result {
+	c := e.NewColumn()
+	for _, value := range data {
+		c.Add(value)
+	}
+	return c.Result()
+}
+
+// MatchDetector menghasilkan Type untuk nilai-nilai yang cocok dengan Match.
+// Detector ini tidak menyimpan state sehingga New mengembalikan dirinya sendiri.
+type MatchDetector struct {
+	DetectorName string
+	ColumnType   string
+	Matches      func(value string) bool
+}
+
+func (d MatchDetector) Name() string {
+	return d.DetectorName
+}
+
+func (d MatchDetector) New() Matcher {
+	return d
+}
+
+func (d MatchDetector) Match(value string) bool {
+	return d.Matches(value)
+}
+
+func (d MatchDetector) Type(maxLength int) string {
+	return d.ColumnType
+}
+
+func isBoolean(value string) bool {
+	return value == "true" || value == "false" || value == "1" || value == "0"
+}
+
+// IntDetector mengenali bilangan bulat: INT, atau BIGINT bila ada nilai di
+// luar jangkauan INT 32-bit.
+type IntDetector struct{}
+
+func (IntDetector) Name() string {
+	return "int"
+}
+
+func (IntDetector) New() Matcher {
+	return &intMatcher{}
+}
+
+type intMatcher struct {
+	big bool
+}
+
+func (m *intMatcher) Match(value string) bool {
+	if _, err := strconv.Atoi(value); err != nil {
+		return false
+	}
+	// If number length is greater than 10 or equals 10 and greater than max int32 value
+	if len(value) > 10 || (len(value) == 10 && value > "2147483647") {
+		m.big = true
+	}
+	return true
+}
+
+func (m *intMatcher) Type(maxLength int) string {
+	if m.big {
+		return "BIGINT"
+	}
+	return "INT"
+}
+
+// FloatDetector mengenali bilangan pecahan: FLOAT bila nilai terpanjang paling
+// banyak 7 karakter, selain itu DOUBLE.
+type FloatDetector struct{}
+
+func (FloatDetector) Name() string {
+	return "float"
+}
+
+func (d FloatDetector) New() Matcher {
+	return d
+}
+
+func (FloatDetector) Match(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+	return err == nil
+}
+
+func (FloatDetector) Type(maxLength int) string {
+	if maxLength <= 7 {
+		return "FLOAT"
+	}
+	return "DOUBLE"
+}
+
+// TextDetector selalu berlaku dan menghasilkan TextType.
+type TextDetector struct{}
+
+func (TextDetector) Name() string {
+	return "text"
+}
+
+func (d TextDetector) New() Matcher {
+	return d
+}
+
+func (TextDetector) Match(value string) bool {
+	return true
+}
+
+func (TextDetector) Type(maxLength int) string {
+	return TextType(maxLength)
+}
+
+// TextType mengembalikan tipe teks terkecil untuk nilai sepanjang maxLength.
+func TextType(maxLength int) string {
+	switch {
+	case maxLength <= 255:
+		return fmt.Sprintf("VARCHAR(%d)", maxLength)
+	case maxLength <= 65535:
+		return "TEXT"
+	case maxLength <= 16777215:
+		return "MEDIUMTEXT"
+	default:
+		return "LONGTEXT"
+	}
+}
+
+// Fits mengembalikan true bila value dapat disimpan pada kolom columnType
+// tanpa menjadi NULL atau terpotong, misalnya untuk memeriksa baris di luar
+// sampel yang dipakai Infer. Nilai kosong selalu cocok.
+func Fits(value, columnType string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return true
+	}
+	var precision, scale int
+	if _, err := fmt.Sscanf(columnType, "DECIMAL(%d,%d)", &precision, &scale); err == nil {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false
+		}
+		whole, fraction, _ := strings.Cut(strconv.FormatFloat(math.Abs(number), 'f', -1, 64), ".")
+		return len(fraction) <= scale && (whole == "0" || len(whole) <= precision-scale)
+	}
+	switch columnType {
+	case "BOOLEAN":
+		return isBoolean(value)
+	case "INT", "BIGINT":
+		if _, err := strconv.Atoi(value); err != nil {
+			return false
+		}
+		return columnType == "BIGINT" || !(len(value) > 10 || (len(value) == 10 && value > "2147483647"))
+	case "FLOAT", "DOUBLE", "DECIMAL":
+		_, err := strconv.ParseFloat(value, 64)
+		return err == nil
+	case "DATE", "DATETIME", "TIMESTAMP", "TIME", "YEAR":
+		return IsValidDateTime(value, columnType)
+	case "JSON":
+		return jsonRegex.MatchString(value)
+	case "UUID":
+		return uuidRegex.MatchString(value)
+	case "TEXT":
+		return len(value) <= 65535
+	case "MEDIUMTEXT":
+		return len(value) <= 16777215
+	case "LONGTEXT":
+		return true
+	}
+	var size int
+	if _, err := fmt.Sscanf(columnType, "VARCHAR(%d)", &size); err == nil {
+		return len(value) <= size
+	}
+	return true
+}
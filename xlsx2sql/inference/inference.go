@@ -0,0 +1,71 @@
+// Package inference menentukan tipe kolom MariaDB dari nilai-nilai teks sel.
+package inference
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	dateRegex      = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	datetimeRegex  = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}$`)
+	timestampRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)
+	timeRegex      = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}$`)
+
+	// Nilai kolom DATETIME(n) dan TIME(n) boleh berisi pecahan detik
+	datetimeFractionRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d{1,6})?$`)
+	timeFractionRegex     = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}(\.\d{1,6})?$`)
+	yearRegex             = regexp.MustCompile(`^\d{4}$`)
+	jsonRegex             = regexp.MustCompile(`^\{.*\}$`)
+	uuidRegex             = regexp.MustCompile(`^[a-fA-F0-9]{8}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{4}-[a-fA-F0-9]{12}$`)
+)
+
+// DetermineColumnType mengembalikan tipe kolom paling sempit yang dapat
+// menampung semua nilai tidak kosong pada data menurut DefaultEngine,
+// misalnya INT, DATE atau VARCHAR(n).
+func DetermineColumnType(data []string) string {
+	return DefaultEngine.Infer(data).Type
+}
+
+// BaseType mengembalikan columnType tanpa panjang, presisi dan atributnya,
+// misalnya DECIMAL untuk DECIMAL(10,2) dan DATETIME untuk DATETIME(6).
+func BaseType(columnType string) string {
+	if i := strings.IndexAny(columnType, "( "); i >= 0 {
+		return columnType[:i]
+	}
+	return columnType
+}
+
+// IsDateTimeType mengembalikan true untuk tipe tanggal dan waktu.
+func IsDateTimeType(columnType string) bool {
+	switch BaseType(columnType) {
+	case "DATE", "DATETIME", "TIMESTAMP", "TIME", "YEAR":
+		return true
+	}
+	return false
+}
+
+// IsValidDateTime memeriksa apakah value sesuai format tipe tanggal/waktu
+// columnType. Tipe selain tanggal/waktu selalu menghasilkan false.
+func IsValidDateTime(value string, columnType string) bool {
+	switch {
+	case strings.HasPrefix(columnType, "DATETIME("):
+		return datetimeFractionRegex.MatchString(value)
+	case strings.HasPrefix(columnType, "TIME("):
+		return timeFractionRegex.MatchString(value)
+	}
+	switch columnType {
+	case "DATE":
+		return dateRegex.MatchString(value)
+	case "DATETIME":
+		return datetimeRegex.MatchString(value)
+	case "TIMESTAMP":
+		return timestampRegex.MatchString(value)
+	case "TIME":
+		return timeRegex.MatchString(value)
+	case "YEAR":
+		return yearRegex.MatchString(value)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,280 @@
+package inference
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Suggestion adalah saran perbaikan untuk nilai sel yang tidak sesuai tipe
+// kolomnya, agar pengguna dapat membetulkan spreadsheet sendiri.
+type Suggestion struct {
+	// Format menjelaskan bentuk nilai yang dikenali, misalnya
+	// "tanggal DD/MM/YYYY" atau "angka dengan satuan kg"
+	Format string `json:"format"`
+
+	// Value adalah nilai pengganti terdekat yang sesuai tipe kolom, kosong
+	// bila tidak ada
+	Value string `json:"value,omitempty"`
+}
+
+func (s Suggestion) String() string {
+	if s.Value == "" {
+		return s.Format
+	}
+	return fmt.Sprintf("%s, ganti menjadi %s", s.Format, s.Value)
+}
+
+// dateLayouts adalah format tanggal yang dikenali Suggest, berurutan dari
+// yang paling umum pada spreadsheet berbahasa Indonesia.
+var dateLayouts = []struct{ layout, name string }{
+	{"2006-1-2", "YYYY-MM-DD"},
+	{"2/1/2006", "DD/MM/YYYY"},
+	{"1/2/2006", "MM/DD/YYYY"},
+	{"2-1-2006", "DD-MM-YYYY"},
+	{"2.1.2006", "DD.MM.YYYY"},
+	{"2006/1/2", "YYYY/MM/DD"},
+	{"2006.1.2", "YYYY.MM.DD"},
+	{"20060102", "YYYYMMDD"},
+	{"2/1/06", "DD/MM/YY"},
+	{"2 Jan 2006", "DD Mon YYYY"},
+	{"2 January 2006", "DD Bulan YYYY"},
+	{"2-Jan-2006", "DD-Mon-YYYY"},
+	{"2-Jan-06", "DD-Mon-YY"},
+	{"Jan 2, 2006", "Mon DD, YYYY"},
+	{"January 2, 2006", "Bulan DD, YYYY"},
+}
+
+// timeLayouts adalah format waktu yang dikenali Suggest, sendiri atau di
+// belakang tanggal.
+var timeLayouts = []struct{ layout, name string }{
+	{"15:04:05", "HH:MM:SS"},
+	{"15:04", "HH:MM"},
+	{"15.04.05", "HH.MM.SS"},
+	{"15.04", "HH.MM"},
+	{"3:04:05 PM", "HH:MM:SS AM/PM"},
+	{"3:04 PM", "HH:MM AM/PM"},
+	{"3:04PM", "HH:MMAM/PM"},
+}
+
+// indonesianMonths mengenali nama bulan berbahasa Indonesia yang berbeda dari
+// nama bulan bahasa Inggris.
+var (
+	indonesianMonthRegex = regexp.MustCompile(`(?i)\b(januari|februari|maret|mei|juni|juli|agustus|oktober|desember|agu|okt|des)\b`)
+	indonesianMonths     = map[string]string{
+		"januari": "January", "februari": "February", "maret": "March", "mei": "May",
+		"juni": "June", "juli": "July", "agustus": "August", "oktober": "October",
+		"desember": "December", "agu": "Aug", "okt": "Oct", "des": "Dec",
+	}
+
+	currencyRegex = regexp.MustCompile(`(?i)^(rp\.?|idr|usd|us\$|\$|€|£|¥)\s*`)
+	unitRegex     = regexp.MustCompile(`\s*([^\d\s.,()+-]+)$`)
+	digitsRegex   = regexp.MustCompile(`^\d+(\.\d+)?$`)
+)
+
+// Suggest mengembalikan saran perbaikan untuk value yang tidak sesuai tipe
+// columnType, misalnya tanggal 05/03/2024 pada kolom DATE menjadi 2024-03-05
+// atau "12,5 kg" pada kolom DECIMAL menjadi 12.5. Hasil false berarti tidak
+// ada saran untuk tipe tersebut.
+func Suggest(value, columnType string) (Suggestion, bool) {
+	value = strings.TrimSpace(value)
+	switch base := BaseType(columnType); base {
+	case "DATE", "DATETIME", "TIMESTAMP", "YEAR":
+		return suggestDate(value, base)
+	case "TIME":
+		return suggestTime(value)
+	case "INT", "BIGINT", "TINYINT", "SMALLINT", "MEDIUMINT", "FLOAT", "DOUBLE", "DECIMAL":
+		return suggestNumber(value, columnType)
+	case "BOOLEAN":
+		return suggestBoolean(value)
+	case "UUID":
+		hex := strings.NewReplacer("{", "", "}", "", "-", "").Replace(value)
+		if len(hex) == 32 && uuidRegex.MatchString(hex[:8]+"-"+hex[8:12]+"-"+hex[12:16]+"-"+hex[16:20]+"-"+hex[20:]) {
+			return Suggestion{Format: "UUID tanpa format 8-4-4-4-12", Value: strings.ToLower(hex[:8] + "-" + hex[8:12] + "-" + hex[12:16] + "-" + hex[16:20] + "-" + hex[20:])}, true
+		}
+		return Suggestion{Format: "teks, bukan UUID"}, true
+	case "VARCHAR", "CHAR":
+		var size int
+		if _, err := fmt.Sscanf(columnType[len(base):], "(%d)", &size); err == nil && len(value) > size {
+			return Suggestion{Format: fmt.Sprintf("teks %d karakter, maksimal %d", len(value), size)}, true
+		}
+	}
+	return Suggestion{}, false
+}
+
+// parseDateTime mencoba dateLayouts, dengan atau tanpa waktu di belakangnya,
+// dan mengembalikan nama format yang cocok.
+func parseDateTime(value string) (time.Time, string, bool) {
+	value = indonesianMonthRegex.ReplaceAllStringFunc(value, func(month string) string {
+		return indonesianMonths[strings.ToLower(month)]
+	})
+	if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+		return t, "ISO 8601 dengan zona waktu", true
+	}
+	for _, d := range dateLayouts {
+		if t, err := time.Parse(d.layout, value); err == nil {
+			return t, d.name, true
+		}
+		for _, sep := range []string{" ", "T"} {
+			for _, tl := range timeLayouts {
+				if t, err := time.Parse(d.layout+sep+tl.layout, value); err == nil {
+					return t, d.name + sep + tl.name, true
+				}
+			}
+		}
+	}
+	return time.Time{}, "", false
+}
+
+// excelSerial mengubah nomor seri tanggal Excel (hari sejak 30 Desember 1899,
+// pecahan sebagai waktu) menjadi time.Time.
+func excelSerial(value string) (time.Time, bool) {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || f < 1 || f > 2958465 {
+		return time.Time{}, false
+	}
+	return time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).Add(time.Duration(math.Round(f*86400)) * time.Second), true
+}
+
+func suggestDate(value, base string) (Suggestion, bool) {
+	t, name, ok := parseDateTime(value)
+	format := "tanggal " + name
+	if !ok {
+		if t, ok = excelSerial(value); !ok {
+			return Suggestion{Format: "teks, bukan tanggal"}, true
+		}
+		format = "nomor seri tanggal Excel"
+	}
+	switch base {
+	case "DATE":
+		return Suggestion{Format: format, Value: t.Format("2006-01-02")}, true
+	case "YEAR":
+		return Suggestion{Format: format, Value: t.Format("2006")}, true
+	case "TIMESTAMP":
+		return Suggestion{Format: format, Value: t.UTC().Format("2006-01-02T15:04:05Z")}, true
+	}
+	return Suggestion{Format: format, Value: t.Format("2006-01-02 15:04:05")}, true
+}
+
+func suggestTime(value string) (Suggestion, bool) {
+	for _, tl := range timeLayouts {
+		if t, err := time.Parse(tl.layout, strings.ToUpper(value)); err == nil {
+			return Suggestion{Format: "waktu " + tl.name, Value: t.Format("15:04:05")}, true
+		}
+	}
+	if t, name, ok := parseDateTime(value); ok {
+		return Suggestion{Format: "tanggal " + name, Value: t.Format("15:04:05")}, true
+	}
+	// Excel menyimpan waktu sebagai pecahan hari
+	if f, err := strconv.ParseFloat(value, 64); err == nil && f >= 0 && f < 1 {
+		seconds := int(math.Round(f * 86400))
+		return Suggestion{Format: "pecahan hari Excel", Value: fmt.Sprintf("%02d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)}, true
+	}
+	return Suggestion{Format: "teks, bukan waktu"}, true
+}
+
+// suggestNumber membersihkan mata uang, satuan, persen, tanda kurung negatif
+// dan pemisah ribuan dari value.
+func suggestNumber(value, columnType string) (Suggestion, bool) {
+	integer := strings.Contains(BaseType(columnType), "INT")
+	s := value
+	var notes []string
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = strings.TrimSpace(s[1 : len(s)-1])
+		notes = append(notes, "negatif dalam kurung")
+	}
+	if strings.HasPrefix(s, "-") {
+		negative = !negative
+		s = strings.TrimSpace(s[1:])
+	}
+	if m := currencyRegex.FindString(s); m != "" {
+		s = s[len(m):]
+		notes = append(notes, "mata uang "+strings.TrimSpace(m))
+	}
+	if strings.HasPrefix(s, "-") {
+		negative = !negative
+		s = s[1:]
+	}
+	percent := false
+	if strings.HasSuffix(s, "%") {
+		percent = true
+		s = strings.TrimSpace(strings.TrimSuffix(s, "%"))
+		notes = append(notes, "persen")
+	} else if m := unitRegex.FindStringSubmatch(s); m != nil {
+		s = s[:len(s)-len(m[0])]
+		notes = append(notes, "satuan "+m[1])
+	}
+	if strings.ContainsAny(s, " \u00a0'") {
+		s = strings.NewReplacer(" ", "", "\u00a0", "", "'", "").Replace(s)
+		notes = append(notes, "pemisah ribuan spasi")
+	}
+
+	dots, commas := strings.Count(s, "."), strings.Count(s, ",")
+	// threeDigits berarti satu pemisah diikuti tepat tiga angka, misalnya 1.500
+	threeDigits := func(sep string) bool {
+		i := strings.Index(s, sep)
+		return i > 0 && len(s)-i-1 == 3
+	}
+	switch {
+	case dots > 0 && commas > 0:
+		if strings.LastIndex(s, ",") > strings.LastIndex(s, ".") {
+			s = strings.ReplaceAll(s, ".", "")
+			s = strings.Replace(s, ",", ".", 1)
+			notes = append(notes, "pemisah ribuan titik dan koma desimal")
+		} else {
+			s = strings.ReplaceAll(s, ",", "")
+			notes = append(notes, "pemisah ribuan koma")
+		}
+	case commas > 1 || commas == 1 && integer && threeDigits(","):
+		s = strings.ReplaceAll(s, ",", "")
+		notes = append(notes, "pemisah ribuan koma")
+	case commas == 1:
+		s = strings.Replace(s, ",", ".", 1)
+		notes = append(notes, "koma desimal")
+	case dots > 1 || dots == 1 && integer && threeDigits("."):
+		s = strings.ReplaceAll(s, ".", "")
+		notes = append(notes, "pemisah ribuan titik")
+	}
+	if !digitsRegex.MatchString(s) {
+		return Suggestion{Format: "teks, bukan angka"}, true
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return Suggestion{Format: "teks, bukan angka"}, true
+	}
+	if percent {
+		f /= 100
+	}
+	if negative {
+		f = -f
+	}
+	if integer && f != math.Trunc(f) {
+		f = math.Round(f)
+		notes = append(notes, "pecahan dibulatkan")
+	}
+	suggestion := Suggestion{Format: "angka"}
+	if len(notes) > 0 {
+		suggestion.Format += " dengan " + strings.Join(notes, ", ")
+	}
+	if result := strconv.FormatFloat(f, 'f', -1, 64); Fits(result, columnType) {
+		suggestion.Value = result
+	} else {
+		suggestion.Format += fmt.Sprintf(", di luar jangkauan %s", columnType)
+	}
+	return suggestion, true
+}
+
+func suggestBoolean(value string) (Suggestion, bool) {
+	switch strings.ToLower(value) {
+	case "true", "ya", "y", "yes", "benar", "aktif", "on":
+		return Suggestion{Format: "boolean berupa teks " + value, Value: "1"}, true
+	case "false", "tidak", "n", "no", "salah", "nonaktif", "off":
+		return Suggestion{Format: "boolean berupa teks " + value, Value: "0"}, true
+	}
+	return Suggestion{Format: "teks, bukan boolean"}, true
+}
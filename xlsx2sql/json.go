@@ -0,0 +1,179 @@
+package xlsx2sql
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// ErrJSONFormat dikembalikan bila file JSON bukan array objek, atau file JSON
+// Lines berisi nilai selain objek.
+var ErrJSONFormat = errors.New("xlsx2sql: JSON harus berupa array objek atau satu objek per baris")
+
+// jsonReader membaca file JSON berisi array objek datar, misalnya hasil
+// ekspor API, atau file JSON Lines (satu objek per baris, misalnya ekspor
+// log) sebagai baris: baris pertama berisi gabungan key semua objek sesuai
+// urutan kemunculannya dan setiap objek menjadi satu baris data. File dibaca
+// dua kali dengan json.Decoder, pertama untuk mengumpulkan key, kedua untuk
+// baris, sehingga isinya tidak dimuat utuh ke memori.
+type jsonReader struct {
+	file   *os.File
+	dec    *json.Decoder
+	header []string
+	index  map[string]int
+
+	// objects adalah jumlah objek yang sudah dibaca
+	objects int
+}
+
+// newJSONReader membuka path sebagai array JSON, atau sebagai JSON Lines bila
+// lines.
+func newJSONReader(path string, lines bool) (*jsonReader, error) {
+	header, err := jsonKeys(path, lines)
+	if err != nil {
+		return nil, err
+	}
+	file, dec, err := openJSON(path, lines)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(header))
+	for i, key := range header {
+		index[key] = i
+	}
+	return &jsonReader{file: file, dec: dec, header: header, index: index}, nil
+}
+
+func (r *jsonReader) Read() ([]string, error) {
+	if r.header != nil {
+		header := r.header
+		r.header = nil
+		return header, nil
+	}
+	if !r.dec.More() {
+		return nil, io.EOF
+	}
+	row := make([]string, len(r.index))
+	err := readJSONObject(r.dec, r.objects, func(key string, value json.RawMessage) error {
+		cell, err := jsonCell(value)
+		row[r.index[key]] = cell
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.objects++
+	return row, nil
+}
+
+func (r *jsonReader) Close() error {
+	return r.file.Close()
+}
+
+// jsonKeys mengumpulkan key semua objek pada file JSON path sesuai urutan
+// kemunculan pertamanya.
+func jsonKeys(path string, lines bool) ([]string, error) {
+	file, dec, err := openJSON(path, lines)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	keys := []string{}
+	seen := make(map[string]bool)
+	for i := 0; dec.More(); i++ {
+		err := readJSONObject(dec, i, func(key string, value json.RawMessage) error {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Token ] memastikan array tidak terpotong
+	if !lines {
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// openJSON membuka path dan membuang BOM UTF-8. Untuk array JSON (bukan
+// lines), token [ awal array juga dibaca.
+func openJSON(path string, lines bool) (*os.File, *json.Decoder, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	in := bufio.NewReader(file)
+	if bom, _ := in.Peek(3); bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		in.Discard(3)
+	}
+	dec := json.NewDecoder(in)
+	if lines {
+		return file, dec, nil
+	}
+	token, err := dec.Token()
+	if err == nil && token != json.Delim('[') {
+		err = ErrJSONFormat
+	}
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return file, dec, nil
+}
+
+// readJSONObject membaca satu objek berikutnya pada dec dan memanggil fn
+// untuk setiap pasangan key dan nilai. i adalah posisi objek pada array
+// (mulai 0) untuk pesan kesalahan.
+func readJSONObject(dec *json.Decoder, i int, fn func(key string, value json.RawMessage) error) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != json.Delim('{') {
+		return fmt.Errorf("%w: elemen %d bukan objek", ErrJSONFormat, i+1)
+	}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if err := fn(token.(string), value); err != nil {
+			return err
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// jsonCell mengubah nilai JSON menjadi isi sel: string tanpa tanda kutip,
+// null menjadi sel kosong, angka dan boolean apa adanya, dan objek atau array
+// bersarang sebagai teks JSON ringkas.
+func jsonCell(value json.RawMessage) (string, error) {
+	switch value[0] {
+	case 'n':
+		return "", nil
+	case '"':
+		var s string
+		err := json.Unmarshal(value, &s)
+		return s, err
+	case '{', '[':
+		var b bytes.Buffer
+		err := json.Compact(&b, value)
+		return b.String(), err
+	}
+	return string(value), nil
+}
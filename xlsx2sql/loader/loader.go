@@ -0,0 +1,388 @@
+// Package loader mengurai dan memuat file data INSERT yang dihasilkan
+// package writer ke MariaDB.
+package loader
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/ddl"
+)
+
+// Translate dipakai untuk menerjemahkan pesan kesalahan. Program xlsx2mariadb
+// menggantinya dengan katalog pesan sesuai -lang.
+var Translate func(format string, args ...interface{}) string = fmt.Sprintf
+
+// AuditError menjelaskan posisi dan penyebab kegagalan audit file INSERT.
+type AuditError struct {
+	Offset int
+	Reason string
+}
+
+func (e *AuditError) Error() string {
+	return fmt.Sprintf("offset %d: %s", e.Offset, e.Reason)
+}
+
+// sqlLexer adalah tokenizer sederhana untuk pernyataan INSERT yang dihasilkan writer.
+type sqlLexer struct {
+	src string
+	pos int
+}
+
+const (
+	tokEOF = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokSymbol
+	tokVariable
+)
+
+func (l *sqlLexer) skipSpace() {
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case ' ', '\t', '\n', '\r':
+			l.pos++
+		case '-':
+			// Komentar "-- " sampai akhir baris, misalnya header asal file
+			if !strings.HasPrefix(l.src[l.pos:], "-- ") {
+				return
+			}
+			if end := strings.IndexByte(l.src[l.pos:], '\n'); end >= 0 {
+				l.pos += end + 1
+			} else {
+				l.pos = len(l.src)
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *sqlLexer) next() (int, string, int, error) {
+	l.skipSpace()
+	start := l.pos
+	if l.pos >= len(l.src) {
+		return tokEOF, "", start, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case c == '\'':
+		l.pos++
+		var value strings.Builder
+		for l.pos < len(l.src) {
+			ch := l.src[l.pos]
+			switch ch {
+			case '\\':
+				if l.pos+1 >= len(l.src) {
+					return 0, "", start, &AuditError{start, Translate("escape tidak lengkap pada akhir string")}
+				}
+				value.WriteByte(l.src[l.pos+1])
+				l.pos += 2
+			case '\'':
+				l.pos++
+				return tokString, value.String(), start, nil
+			default:
+				value.WriteByte(ch)
+				l.pos++
+			}
+		}
+		return 0, "", start, &AuditError{start, Translate("string tidak ditutup")}
+	case c == '`':
+		// Nama dengan backtick dari ddl.NamingOriginal dikembalikan apa
+		// adanya; backtick di dalam nama ditulis ganda
+		l.pos++
+		for l.pos < len(l.src) {
+			if l.src[l.pos] != '`' {
+				l.pos++
+			} else if l.pos+1 < len(l.src) && l.src[l.pos+1] == '`' {
+				l.pos += 2
+			} else {
+				l.pos++
+				return tokIdent, l.src[start:l.pos], start, nil
+			}
+		}
+		return 0, "", start, &AuditError{start, Translate("nama dengan backtick tidak ditutup")}
+	case c == '-' || c == '+' || c == '.' || (c >= '0' && c <= '9'):
+		l.pos++
+		for l.pos < len(l.src) && isNumberByte(l.src[l.pos], l.src[l.pos-1]) {
+			l.pos++
+		}
+		lit := l.src[start:l.pos]
+		if !numberLiteralRegex.MatchString(lit) {
+			return 0, "", start, &AuditError{start, Translate("literal angka tidak valid: %q", lit)}
+		}
+		return tokNumber, lit, start, nil
+	case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		for l.pos < len(l.src) && isIdentByte(l.src[l.pos]) {
+			l.pos++
+		}
+		return tokIdent, l.src[start:l.pos], start, nil
+	case c == '@':
+		l.pos++
+		for l.pos < len(l.src) && isIdentByte(l.src[l.pos]) {
+			l.pos++
+		}
+		return tokVariable, l.src[start:l.pos], start, nil
+	case c == '(' || c == ')' || c == ',' || c == ';':
+		l.pos++
+		return tokSymbol, string(c), start, nil
+	default:
+		return 0, "", start, &AuditError{start, Translate("karakter tidak terduga %q", c)}
+	}
+}
+
+var numberLiteralRegex = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)([eE][-+]?\d+)?$`)
+
+func isNumberByte(c, prev byte) bool {
+	if c >= '0' && c <= '9' || c == '.' || c == 'e' || c == 'E' {
+		return true
+	}
+	return (c == '-' || c == '+') && (prev == 'e' || prev == 'E')
+}
+
+func isIdentByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// Statement adalah satu pernyataan INSERT yang dipecah menjadi bagian
+// "INSERT INTO ... VALUES" dan daftar tuple nilainya.
+type Statement struct {
+	Prefix  string
+	Columns []string
+	Rows    []string
+}
+
+// Audit memastikan isi file data hanya berisi pernyataan
+// INSERT INTO <tableName> (...) VALUES (...), ...; dengan satu INSERT per batch
+// dan hanya literal (angka, string, NULL, TRUE/FALSE) di dalam VALUES. Nilai
+// kolom terenkripsi boleh berbentuk AES_ENCRYPT(literal, @xlsx2sql_key)
+// dengan kunci ddl.KeyVariable.
+func Audit(content, tableName string) error {
+	_, err := Parse(content, tableName)
+	return err
+}
+
+// Parse mengurai content seperti Audit dan mengembalikan setiap pernyataan
+// INSERT beserta teks tuple-tuplenya.
+func Parse(content, tableName string) ([]Statement, error) {
+	l := &sqlLexer{src: content}
+
+	expectIdent := func(word string) error {
+		tok, val, pos, err := l.next()
+		if err != nil {
+			return err
+		}
+		if tok != tokIdent || !strings.EqualFold(val, word) {
+			return &AuditError{pos, Translate("diharapkan %s, ditemukan %q", word, val)}
+		}
+		return nil
+	}
+	expectSymbol := func(sym string) (int, error) {
+		tok, val, pos, err := l.next()
+		if err != nil {
+			return pos, err
+		}
+		if tok != tokSymbol || val != sym {
+			return pos, &AuditError{pos, Translate("diharapkan %q, ditemukan %q", sym, val)}
+		}
+		return pos, nil
+	}
+
+	var statements []Statement
+	for {
+		tok, val, stmtStart, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok == tokEOF {
+			break
+		}
+		if tok != tokIdent || !strings.EqualFold(val, "INSERT") {
+			return nil, &AuditError{stmtStart, Translate("diharapkan INSERT, ditemukan %q", val)}
+		}
+		if err := expectIdent("INTO"); err != nil {
+			return nil, err
+		}
+		tok, val, pos, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		if tok != tokIdent || ddl.Unquote(val) != ddl.Unquote(tableName) {
+			return nil, &AuditError{pos, Translate("nama tabel %q tidak sesuai dengan %q", val, tableName)}
+		}
+
+		if _, err := expectSymbol("("); err != nil {
+			return nil, err
+		}
+		var columns []string
+		for {
+			tok, val, pos, err = l.next()
+			if err != nil {
+				return nil, err
+			}
+			if tok != tokIdent {
+				return nil, &AuditError{pos, Translate("diharapkan nama kolom, ditemukan %q", val)}
+			}
+			columns = append(columns, val)
+			tok, val, pos, err = l.next()
+			if err != nil {
+				return nil, err
+			}
+			if tok == tokSymbol && val == ")" {
+				break
+			}
+			if tok != tokSymbol || val != "," {
+				return nil, &AuditError{pos, Translate("diharapkan \",\" atau \")\", ditemukan %q", val)}
+			}
+		}
+
+		if err := expectIdent("VALUES"); err != nil {
+			return nil, err
+		}
+		stmt := Statement{Prefix: content[stmtStart:l.pos], Columns: columns}
+		for {
+			rowStart, err := expectSymbol("(")
+			if err != nil {
+				return nil, err
+			}
+			values := 0
+			for {
+				tok, val, pos, err = l.next()
+				if err != nil {
+					return nil, err
+				}
+				switch tok {
+				case tokNumber, tokString:
+				case tokIdent:
+					switch strings.ToUpper(val) {
+					case "NULL", "TRUE", "FALSE":
+					case "AES_ENCRYPT":
+						if err := l.encrypted(); err != nil {
+							return nil, err
+						}
+					default:
+						return nil, &AuditError{pos, Translate("nilai bukan literal: %q", val)}
+					}
+				default:
+					return nil, &AuditError{pos, Translate("diharapkan literal, ditemukan %q", val)}
+				}
+				values++
+				tok, val, pos, err = l.next()
+				if err != nil {
+					return nil, err
+				}
+				if tok == tokSymbol && val == ")" {
+					break
+				}
+				if tok != tokSymbol || val != "," {
+					return nil, &AuditError{pos, Translate("diharapkan \",\" atau \")\", ditemukan %q", val)}
+				}
+			}
+			if values != len(columns) {
+				return nil, &AuditError{pos, Translate("jumlah nilai %d tidak sama dengan jumlah kolom %d", values, len(columns))}
+			}
+			stmt.Rows = append(stmt.Rows, content[rowStart:l.pos])
+
+			tok, val, pos, err = l.next()
+			if err != nil {
+				return nil, err
+			}
+			if tok == tokSymbol && val == "," {
+				continue
+			}
+			if tok == tokSymbol && val == ";" {
+				break
+			}
+			return nil, &AuditError{pos, Translate("diharapkan \",\" atau \";\", ditemukan %q", val)}
+		}
+		statements = append(statements, stmt)
+	}
+
+	if len(statements) == 0 {
+		return nil, &AuditError{0, Translate("tidak ada pernyataan INSERT")}
+	}
+	return statements, nil
+}
+
+// encrypted membaca sisa nilai AES_ENCRYPT(literal, @xlsx2sql_key) setelah
+// nama fungsinya.
+func (l *sqlLexer) encrypted() error {
+	for _, want := range []string{"(", "", ",", ddl.KeyVariable, ")"} {
+		tok, val, pos, err := l.next()
+		if err != nil {
+			return err
+		}
+		ok := tok != tokString && val == want
+		if want == "" {
+			// Posisi literal yang dienkripsi
+			ok = tok == tokString || tok == tokNumber
+		}
+		if !ok {
+			return &AuditError{pos, Translate("nilai AES_ENCRYPT tidak valid: %q", val)}
+		}
+	}
+	return nil
+}
+
+// TupleLiterals mengembalikan teks literal setiap nilai pada tuple "(...)",
+// termasuk pembungkus AES_ENCRYPT pada kolom terenkripsi.
+func TupleLiterals(row string) []string {
+	l := &sqlLexer{src: row}
+	var literals []string
+	depth, start := 0, 0
+	for {
+		tok, val, pos, err := l.next()
+		if err != nil || tok == tokEOF {
+			return literals
+		}
+		switch {
+		case tok == tokSymbol && val == "(":
+			depth++
+		case tok == tokSymbol && val == ")":
+			depth--
+			if depth == 1 {
+				literals[len(literals)-1] = row[start:l.pos]
+			}
+		case tok == tokSymbol || depth > 1:
+		default:
+			start = pos
+			literals = append(literals, row[pos:l.pos])
+		}
+	}
+}
+
+// Load membuat tabel dengan createTable (boleh kosong bila tabel sudah ada)
+// lalu memuat isi data, yaitu pernyataan INSERT untuk tableName, per batch
+// batchRows tuple (0 berarti satu INSERT utuh seperti pada data).
+func Load(ctx context.Context, db *sql.DB, tableName, createTable, data string, batchRows int) (int, error) {
+	if strings.TrimSpace(createTable) != "" {
+		if _, err := db.ExecContext(ctx, createTable); err != nil {
+			return 0, err
+		}
+	}
+	statements, err := Parse(data, tableName)
+	if err != nil {
+		return 0, err
+	}
+	loaded := 0
+	for _, stmt := range statements {
+		for start := 0; start < len(stmt.Rows); {
+			size := len(stmt.Rows) - start
+			if batchRows > 0 && batchRows < size {
+				size = batchRows
+			}
+			query := stmt.Prefix + "\n" + strings.Join(stmt.Rows[start:start+size], ",\n")
+			if _, err := db.ExecContext(ctx, query); err != nil {
+				return loaded, err
+			}
+			loaded += size
+			start += size
+		}
+	}
+	return loaded, nil
+}
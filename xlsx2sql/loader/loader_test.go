@@ -0,0 +1,169 @@
+package loader
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/ddl"
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/writer"
+)
+
+// writeInsert menulis rows dengan writer.New seperti file data xlsx2mariadb.
+func writeInsert(t *testing.T, iw func(*strings.Builder) *writer.InsertWriter, rows [][]string) string {
+	t.Helper()
+	var b strings.Builder
+	w := iw(&b)
+	for _, row := range rows {
+		if err := w.WriteRow(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestParseNamingOriginal(t *testing.T) {
+	naming := ddl.NamingOriginal
+	table := naming.Table("Data Penjualan")
+	columns := []string{naming.Column("Nama Barang"), naming.Column("Harga `Rp`")}
+	content := writeInsert(t, func(b *strings.Builder) *writer.InsertWriter {
+		return writer.New(b, table, columns, []string{"VARCHAR(20)", "INT"})
+	}, [][]string{{"apel", "1500"}, {"jeruk", "2000"}})
+
+	statements, err := Parse(content, table)
+	if err != nil {
+		t.Fatalf("Parse(%q) = %v", content, err)
+	}
+	if len(statements) != 1 || len(statements[0].Rows) != 2 {
+		t.Fatalf("Parse = %+v, ingin 1 pernyataan dengan 2 tuple", statements)
+	}
+	if want := []string{"`Nama Barang`", "`Harga ``Rp```"}; !reflect.DeepEqual(statements[0].Columns, want) {
+		t.Errorf("Columns = %q, ingin %q", statements[0].Columns, want)
+	}
+	// Nama tabel dibandingkan tanpa backtick
+	if err := Audit(content, ddl.Unquote(table)); err != nil {
+		t.Errorf("Audit tanpa backtick = %v", err)
+	}
+	if err := Audit(content, "`Data`"); err == nil {
+		t.Error("Audit dengan nama tabel lain tidak gagal")
+	}
+	if err := Audit(strings.Replace(content, "`Data Penjualan`", "`Data Penjualan", 1), table); err == nil {
+		t.Error("Audit dengan backtick tidak ditutup tidak gagal")
+	}
+}
+
+func TestParseWriterOutput(t *testing.T) {
+	columns := []string{"nama", "jumlah", "aktif", "catatan"}
+	types := []string{"VARCHAR(20)", "DOUBLE", "BOOLEAN", "TEXT"}
+	rows := [][]string{
+		{"apel", "1.5", "true", "it's \\ ok"},
+		{"jeruk", "-2e3", "false", ""},
+		{"", "", "", "(a), b; c"},
+	}
+	header := "-- Generated by xlsx2mariadb dev\n-- Source: data.xlsx\n-- Rows: 3\n"
+	tests := []struct {
+		name       string
+		batch      int
+		encrypted  []bool
+		header     string
+		statements int
+	}{
+		{"satu batch", 0, nil, "", 1},
+		{"per dua tuple", 2, nil, "", 2},
+		{"terenkripsi", 0, []bool{true, false, false, true}, "", 1},
+		{"header asal", 1, nil, header, 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			content := test.header + writeInsert(t, func(b *strings.Builder) *writer.InsertWriter {
+				w := writer.New(b, "penjualan", columns, types)
+				if test.batch > 0 {
+					w.BatchRows = test.batch
+				}
+				w.Encrypted = test.encrypted
+				return w
+			}, rows)
+			statements, err := Parse(content, "penjualan")
+			if err != nil {
+				t.Fatalf("Parse(%q) = %v", content, err)
+			}
+			if len(statements) != test.statements {
+				t.Fatalf("Parse = %d pernyataan, ingin %d", len(statements), test.statements)
+			}
+			total := 0
+			for _, stmt := range statements {
+				if !reflect.DeepEqual(stmt.Columns, columns) {
+					t.Errorf("Columns = %q, ingin %q", stmt.Columns, columns)
+				}
+				if !strings.HasPrefix(stmt.Prefix, "INSERT INTO penjualan") {
+					t.Errorf("Prefix = %q", stmt.Prefix)
+				}
+				total += len(stmt.Rows)
+			}
+			if total != len(rows) {
+				t.Errorf("jumlah tuple = %d, ingin %d", total, len(rows))
+			}
+		})
+	}
+}
+
+func TestAuditRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		reason  string
+	}{
+		{"kosong", "", "tidak ada pernyataan INSERT"},
+		{"hanya komentar", "-- Source: data.xlsx\n", "tidak ada pernyataan INSERT"},
+		{"fungsi", "INSERT INTO t (a) VALUES (SLEEP(5));", "nilai bukan literal"},
+		{"kolom", "INSERT INTO t (a) VALUES (a);", "nilai bukan literal"},
+		{"kolom berkutip", "INSERT INTO t (a) VALUES (`a`);", "nilai bukan literal"},
+		{"variabel", "INSERT INTO t (a) VALUES (@x);", "diharapkan literal"},
+		{"subquery", "INSERT INTO t (a) VALUES ((SELECT 1));", "diharapkan literal"},
+		{"pernyataan lain", "INSERT INTO t (a) VALUES (1);\nDROP TABLE t;", "diharapkan INSERT"},
+		{"ekor pernyataan", "INSERT INTO t (a) VALUES (1) ON DUPLICATE KEY UPDATE a = 2;", "diharapkan \",\" atau \";\""},
+		{"komentar blok", "INSERT INTO t (a) VALUES (1 /* x */);", "karakter tidak terduga"},
+		{"string tidak ditutup", "INSERT INTO t (a) VALUES ('abc);", "string tidak ditutup"},
+		{"escape di akhir", "INSERT INTO t (a) VALUES ('abc\\", "escape tidak lengkap"},
+		{"angka", "INSERT INTO t (a) VALUES (1e);", "literal angka tidak valid"},
+		{"tabel lain", "INSERT INTO u (a) VALUES (1);", "nama tabel \"u\" tidak sesuai"},
+		{"tabel berkutip lain", "INSERT INTO `t2` (a) VALUES (1);", "nama tabel \"`t2`\" tidak sesuai"},
+		{"jumlah nilai", "INSERT INTO t (a, b) VALUES (1);", "jumlah nilai 1 tidak sama"},
+		{"kunci lain", "INSERT INTO t (a) VALUES (AES_ENCRYPT('x', @kunci));", "nilai AES_ENCRYPT tidak valid"},
+		{"enkripsi kolom", "INSERT INTO t (a) VALUES (AES_ENCRYPT(a, @xlsx2sql_key));", "nilai AES_ENCRYPT tidak valid"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Audit(test.content, "t")
+			var auditErr *AuditError
+			if !errors.As(err, &auditErr) {
+				t.Fatalf("Audit(%q) = %v, ingin AuditError", test.content, err)
+			}
+			if !strings.Contains(auditErr.Reason, test.reason) {
+				t.Errorf("Audit(%q) = %q, ingin berisi %q", test.content, auditErr.Reason, test.reason)
+			}
+		})
+	}
+}
+
+func TestTupleLiterals(t *testing.T) {
+	tests := []struct {
+		row  string
+		want []string
+	}{
+		{"('a', 1, NULL)", []string{"'a'", "1", "NULL"}},
+		{"('a,b', '(c)', TRUE)", []string{"'a,b'", "'(c)'", "TRUE"}},
+		{"('x\\'y', -1.5e3)", []string{"'x\\'y'", "-1.5e3"}},
+		{"(AES_ENCRYPT('rahasia', @xlsx2sql_key), NULL)", []string{"AES_ENCRYPT('rahasia', @xlsx2sql_key)", "NULL"}},
+		{"()", nil},
+	}
+	for _, test := range tests {
+		if got := TupleLiterals(test.row); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("TupleLiterals(%q) = %q, ingin %q", test.row, got, test.want)
+		}
+	}
+}
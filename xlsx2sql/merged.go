@@ -0,0 +1,73 @@
+package xlsx2sql
+
+import (
+	"sort"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// mergedCells mengisi sel yang tertutup merged cell sheet xlsx dengan nilai
+// sel kiri atasnya (Options.FillMerged). Baris harus diisi berurutan.
+type mergedCells struct {
+	// pending adalah area yang belum dimulai, urut menurut baris pertama;
+	// active adalah area yang mencakup baris terakhir
+	pending []mergedArea
+	active  []mergedArea
+}
+
+// mergedArea adalah satu merged cell beserta nilai sel kiri atasnya.
+type mergedArea struct {
+	cellRange
+	value string
+}
+
+// newMergedCells membaca merged cell pada sheet, nil bila tidak ada.
+func newMergedCells(xlsx *excelize.File, sheet string) (*mergedCells, error) {
+	cells, err := xlsx.GetMergeCells(sheet)
+	if err != nil || len(cells) == 0 {
+		return nil, err
+	}
+	m := &mergedCells{}
+	for _, cell := range cells {
+		area, err := parseRange(cell.GetStartAxis() + ":" + cell.GetEndAxis())
+		if err != nil {
+			continue
+		}
+		m.pending = append(m.pending, mergedArea{cellRange: area})
+	}
+	sort.SliceStable(m.pending, func(i, j int) bool { return m.pending[i].firstRow < m.pending[j].firstRow })
+	return m, nil
+}
+
+// fill mengembalikan baris sheet nomor n (mulai 1) dengan setiap sel yang
+// tertutup merged cell berisi nilai sel kiri atasnya. Nilai diambil dari
+// baris itu sendiri sehingga mengikuti nilai yang sudah diubah
+// Options.NumberFormats.
+func (m *mergedCells) fill(n int, row []string) []string {
+	for len(m.pending) > 0 && m.pending[0].firstRow <= n {
+		area := m.pending[0]
+		m.pending = m.pending[1:]
+		if area.firstRow == n && area.firstCol <= len(row) {
+			area.value = row[area.firstCol-1]
+		}
+		m.active = append(m.active, area)
+	}
+	active := m.active[:0]
+	for _, area := range m.active {
+		if area.lastRow < n {
+			continue
+		}
+		active = append(active, area)
+		if area.value == "" {
+			continue
+		}
+		for len(row) < area.lastCol {
+			row = append(row, "")
+		}
+		for c := area.firstCol; c <= area.lastCol; c++ {
+			row[c-1] = area.value
+		}
+	}
+	m.active = active
+	return row
+}
@@ -0,0 +1,461 @@
+package xlsx2sql
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/inference"
+	"github.com/xuri/excelize/v2"
+)
+
+// numFmtKind adalah jenis format angka sel yang dipakai sebagai petunjuk tipe
+// kolom.
+type numFmtKind int
+
+const (
+	// numFmtNone adalah General dan format yang tidak dikenali, misalnya
+	// notasi ilmiah dan pecahan
+	numFmtNone numFmtKind = iota
+
+	// numFmtDate menampilkan tanggal dan/atau waktu
+	numFmtDate
+
+	// numFmtNumber menampilkan bilangan dengan jumlah desimal tetap,
+	// termasuk mata uang, akuntansi dan persen
+	numFmtNumber
+
+	// numFmtText adalah format teks @
+	numFmtText
+)
+
+// numFmt adalah hasil klasifikasi satu format angka. scale adalah jumlah
+// digit desimal nilai mentah yang ditampilkan format; persen menambah dua
+// digit karena 12,5% disimpan sebagai 0.125.
+type numFmt struct {
+	kind  numFmtKind
+	scale int
+}
+
+// builtinNumFmts adalah kode format angka bawaan xlsx yang bukan tanggal,
+// tanpa simbol mata uang dan warna yang tidak memengaruhi klasifikasi.
+var builtinNumFmts = map[int]string{
+	1:  "0",
+	2:  "0.00",
+	3:  "#,##0",
+	4:  "#,##0.00",
+	5:  "#,##0",
+	6:  "#,##0",
+	7:  "#,##0.00",
+	8:  "#,##0.00",
+	9:  "0%",
+	10: "0.00%",
+	11: "0.00E+00",
+	12: "# ?/?",
+	13: "# ??/??",
+	37: "#,##0",
+	38: "#,##0",
+	39: "#,##0.00",
+	40: "#,##0.00",
+	41: "#,##0",
+	42: "#,##0",
+	43: "#,##0.00",
+	44: "#,##0.00",
+	48: "##0.0E+0",
+	49: "@",
+}
+
+// classifyNumFmt menentukan jenis format angka id, dengan kode format code
+// untuk format buatan pengguna.
+func classifyNumFmt(id int, code string) numFmt {
+	if code == "" {
+		code = builtinNumFmts[id]
+	}
+	if id >= 0 && id <= math.MaxUint16 && xlsDateFormat(uint16(id), code) {
+		return numFmt{kind: numFmtDate}
+	}
+	stripped, ok := stripNumFmt(code)
+	if !ok {
+		return numFmt{}
+	}
+	// Hanya bagian pertama (bilangan positif) yang menentukan jenisnya
+	section, _, _ := strings.Cut(stripped, ";")
+	switch section = strings.TrimSpace(section); {
+	case section == "@":
+		return numFmt{kind: numFmtText}
+	case strings.ContainsAny(section, "eE/") || !strings.ContainsAny(section, "0#"):
+		return numFmt{}
+	}
+	format := numFmt{kind: numFmtNumber}
+	if _, fraction, ok := strings.Cut(section, "."); ok {
+		for _, c := range fraction {
+			if c != '0' && c != '#' && c != '?' {
+				break
+			}
+			format.scale++
+		}
+	}
+	format.scale += 2 * strings.Count(section, "%")
+	return format
+}
+
+// stripNumFmt membuang teks dalam tanda kutip, karakter yang diloloskan dan
+// bagian dalam kurung siku seperti warna [Red] atau mata uang [$Rp-421] dari
+// kode format angka; [h], [mm] dan [ss] dipertahankan tanpa kurungnya. false
+// dikembalikan bila kurung siku tidak ditutup.
+func stripNumFmt(code string) (string, bool) {
+	var b strings.Builder
+	quoted := false
+	for i := 0; i < len(code); i++ {
+		switch c := code[i]; {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '\\' || c == '_' || c == '*':
+			i++
+		case c == '[':
+			end := strings.IndexByte(code[i:], ']')
+			if end < 0 {
+				return "", false
+			}
+			if section := strings.ToLower(code[i+1 : i+end]); strings.Trim(section, "hms") == "" {
+				b.WriteString(section)
+			}
+			i += end
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), true
+}
+
+// serialTime mengubah nomor seri tanggal Excel menjadi 2006-01-02, 15:04:05
+// atau 2006-01-02 15:04:05, sesuai ada tidaknya bagian tanggal dan waktu.
+func serialTime(value float64, date1904 bool) string {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	if date1904 {
+		epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	days := math.Floor(value)
+	seconds := math.Round((value - days) * 86400)
+	t := epoch.AddDate(0, 0, int(days)).Add(time.Duration(seconds) * time.Second)
+	switch {
+	case days == 0:
+		return t.Format("15:04:05")
+	case seconds == 0:
+		return t.Format("2006-01-02")
+	default:
+		return t.Format("2006-01-02 15:04:05")
+	}
+}
+
+// xlsxCell adalah satu elemen <c> XML sheet: alamat, indeks style, tipe dan
+// nilai mentahnya.
+type xlsxCell struct {
+	R string `xml:"r,attr"`
+	S int    `xml:"s,attr"`
+	T string `xml:"t,attr"`
+	V string `xml:"v"`
+}
+
+// xlsxRow adalah satu elemen <row> XML sheet.
+type xlsxRow struct {
+	R int        `xml:"r,attr"`
+	C []xlsxCell `xml:"c"`
+}
+
+// cellFormats membaca XML sheet xlsx langsung dari arsip zip, sejajar dengan
+// iterator excelize yang tidak memberikan style sel. Nilai sel bertanggal
+// diubah menjadi tanggal ISO dan nilai sel berformat bilangan menjadi nilai
+// mentahnya, bukan teks yang ditampilkan, dan format sel tidak kosong setiap
+// kolom dikumpulkan untuk columnType.
+type cellFormats struct {
+	xlsx     *excelize.File
+	archive  *zip.ReadCloser
+	part     io.ReadCloser
+	decoder  *xml.Decoder
+	date1904 bool
+	styles   map[int]numFmt
+
+	// next adalah baris yang sudah dibaca tetapi belum diminta, last nomor
+	// baris terakhir yang dibaca
+	next *xlsxRow
+	last int
+
+	// track diaktifkan setelah baris header sehingga hints hanya berisi
+	// format baris data
+	track bool
+	hints []formatHint
+}
+
+// formatHint mengumpulkan format sel tidak kosong satu kolom.
+type formatHint struct {
+	kind numFmtKind
+
+	// mixed bila ada sel dengan jenis format lain atau tanpa format
+	mixed bool
+
+	// dateType adalah DATE, DATETIME atau TIME menurut nilai sel bertanggal
+	dateType string
+
+	// digits dan scale adalah jumlah digit bulat dan desimal terbanyak
+	// nilai, dengan scale paling sedikit sebanyak yang ditampilkan format
+	digits, scale int
+
+	// big bila ada bilangan bulat di luar jangkauan INT
+	big bool
+}
+
+// maxHintScale adalah jumlah desimal terbanyak agar petunjuk format menjadi
+// DECIMAL. Nilai dengan lebih banyak desimal biasanya hasil perhitungan
+// floating point, misalnya 0.30000000000000004, dan tipenya ditentukan dengan
+// inferensi.
+const maxHintScale = 10
+
+// openCellFormats membuka XML sheet dari file xlsx path yang sudah dibuka
+// sebagai xlsx. Workbook terenkripsi tidak dapat dibaca sebagai zip.
+func openCellFormats(xlsx *excelize.File, path, sheet string) (*cellFormats, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	part, err := sheetPart(&archive.Reader, sheet)
+	if err != nil {
+		archive.Close()
+		return nil, err
+	}
+	rc, err := archive.Open(part)
+	if err != nil {
+		archive.Close()
+		return nil, err
+	}
+	f := &cellFormats{xlsx: xlsx, archive: archive, part: rc, decoder: xml.NewDecoder(rc), styles: make(map[int]numFmt)}
+	if props, err := xlsx.GetWorkbookProps(); err == nil && props.Date1904 != nil {
+		f.date1904 = *props.Date1904
+	}
+	return f, nil
+}
+
+// sheetPart mengembalikan nama bagian XML sheet bernama sheet pada arsip,
+// misalnya xl/worksheets/sheet1.xml, dari workbook.xml dan relasinya.
+func sheetPart(archive *zip.Reader, sheet string) (string, error) {
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			ID   string `xml:"id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	var rels struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	if err := readZipXML(archive, "xl/workbook.xml", &workbook); err != nil {
+		return "", err
+	}
+	if err := readZipXML(archive, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return "", err
+	}
+	for _, s := range workbook.Sheets {
+		if s.Name != sheet {
+			continue
+		}
+		for _, rel := range rels.Relationships {
+			if rel.ID != s.ID {
+				continue
+			}
+			if strings.HasPrefix(rel.Target, "/") {
+				return strings.TrimPrefix(rel.Target, "/"), nil
+			}
+			return path.Join("xl", rel.Target), nil
+		}
+	}
+	return "", fmt.Errorf("xlsx2sql: bagian XML sheet %s tidak ditemukan", sheet)
+}
+
+func readZipXML(archive *zip.Reader, name string, v interface{}) error {
+	rc, err := archive.Open(name)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	return xml.NewDecoder(rc).Decode(v)
+}
+
+// rowCells mengembalikan sel baris sheet nomor n (mulai 1), nil bila baris
+// itu tidak ada pada XML. Baris harus diminta berurutan.
+func (f *cellFormats) rowCells(n int) ([]xlsxCell, error) {
+	for f.next == nil || f.next.R < n {
+		f.next = nil
+		token, err := f.decoder.Token()
+		if err == io.EOF {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+		var row xlsxRow
+		if err := f.decoder.DecodeElement(&row, &start); err != nil {
+			return nil, err
+		}
+		if row.R == 0 {
+			row.R = f.last + 1
+		}
+		f.last = row.R
+		f.next = &row
+	}
+	if f.next.R > n {
+		return nil, nil
+	}
+	cells := f.next.C
+	f.next = nil
+	return cells, nil
+}
+
+// style mengembalikan format angka style s, dengan cache per style.
+func (f *cellFormats) style(s int) numFmt {
+	if format, ok := f.styles[s]; ok {
+		return format
+	}
+	var format numFmt
+	if style, err := f.xlsx.GetStyle(s); err == nil {
+		if style.CustomNumFmt != nil {
+			format = classifyNumFmt(0, *style.CustomNumFmt)
+		} else {
+			format = classifyNumFmt(style.NumFmt, "")
+		}
+	}
+	f.styles[s] = format
+	return format
+}
+
+// apply mengganti nilai values (hasil excelize untuk baris sheet nomor n)
+// pada sel bertanggal dan berformat bilangan dengan nilai mentahnya, lalu
+// mencatat format setiap sel tidak kosong bila track aktif.
+func (f *cellFormats) apply(n int, values []string) ([]string, error) {
+	cells, err := f.rowCells(n)
+	if err != nil {
+		return nil, err
+	}
+	col := 0
+	for _, cell := range cells {
+		col++
+		if cell.R != "" {
+			if col, _, err = excelize.CellNameToCoordinates(cell.R); err != nil {
+				return nil, err
+			}
+		}
+		j := col - 1
+		format := f.style(cell.S)
+		kind := format.kind
+		value := ""
+		if j < len(values) {
+			value = values[j]
+		}
+		number, err := strconv.ParseFloat(cell.V, 64)
+		numeric := (cell.T == "" || cell.T == "n") && err == nil
+		switch {
+		case kind == numFmtDate && numeric && number >= 0:
+			value = serialTime(number, f.date1904)
+		case kind == numFmtNumber && numeric:
+			value = strconv.FormatFloat(number, 'f', -1, 64)
+		case kind != numFmtText:
+			kind = numFmtNone
+		}
+		if kind != numFmtNone && kind != numFmtText {
+			for len(values) <= j {
+				values = append(values, "")
+			}
+			values[j] = value
+		}
+		if f.track && strings.TrimSpace(value) != "" {
+			f.observe(j, kind, format.scale, value)
+		}
+	}
+	return values, nil
+}
+
+// observe mencatat sel tidak kosong kolom j dengan jenis format kind.
+func (f *cellFormats) observe(j int, kind numFmtKind, scale int, value string) {
+	for len(f.hints) <= j {
+		f.hints = append(f.hints, formatHint{})
+	}
+	h := &f.hints[j]
+	switch {
+	case h.mixed:
+		return
+	case kind == numFmtNone || h.kind != numFmtNone && h.kind != kind:
+		h.mixed = true
+		return
+	}
+	h.kind = kind
+	switch kind {
+	case numFmtDate:
+		dateType := "DATETIME"
+		switch len(value) {
+		case len("2006-01-02"):
+			dateType = "DATE"
+		case len("15:04:05"):
+			dateType = "TIME"
+		}
+		switch {
+		case h.dateType == "" || h.dateType == dateType:
+			h.dateType = dateType
+		case h.dateType != "TIME" && dateType != "TIME":
+			// Tanggal dengan dan tanpa waktu menjadi DATETIME
+			h.dateType = "DATETIME"
+		default:
+			h.mixed = true
+		}
+	case numFmtNumber:
+		whole, fraction, _ := strings.Cut(strings.TrimPrefix(value, "-"), ".")
+		h.digits = max(h.digits, len(whole))
+		h.scale = max(h.scale, scale, len(fraction))
+		if !inference.Fits(value, "INT") {
+			h.big = true
+		}
+	}
+}
+
+// columnType mengembalikan tipe kolom j menurut format semua sel tidak
+// kosongnya, kosong bila formatnya campuran atau tidak dikenali. maxLength
+// adalah panjang nilai terpanjang untuk kolom berformat teks.
+func (f *cellFormats) columnType(j, maxLength int) string {
+	if j >= len(f.hints) || f.hints[j].mixed {
+		return ""
+	}
+	h := f.hints[j]
+	switch h.kind {
+	case numFmtDate:
+		return h.dateType
+	case numFmtText:
+		return inference.TextType(maxLength)
+	case numFmtNumber:
+		switch {
+		case h.scale == 0 && !h.big:
+			return "INT"
+		case h.scale == 0 && h.digits <= 18:
+			return "BIGINT"
+		case h.scale > maxHintScale || h.digits+h.scale > 65:
+			return ""
+		}
+		return fmt.Sprintf("DECIMAL(%d,%d)", max(18, h.digits+h.scale), h.scale)
+	}
+	return ""
+}
+
+func (f *cellFormats) Close() error {
+	f.part.Close()
+	return f.archive.Close()
+}
@@ -0,0 +1,138 @@
+package xlsx2sql
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestClassifyNumFmt(t *testing.T) {
+	tests := []struct {
+		id   int
+		code string
+		want numFmt
+	}{
+		{0, "", numFmt{}},
+		{1, "", numFmt{kind: numFmtNumber}},
+		{4, "", numFmt{kind: numFmtNumber, scale: 2}},
+		{10, "", numFmt{kind: numFmtNumber, scale: 4}},
+		{11, "", numFmt{}},
+		{12, "", numFmt{}},
+		{14, "", numFmt{kind: numFmtDate}},
+		{21, "", numFmt{kind: numFmtDate}},
+		{49, "", numFmt{kind: numFmtText}},
+		{0, "dd/mm/yyyy", numFmt{kind: numFmtDate}},
+		{0, "[h]:mm:ss", numFmt{kind: numFmtDate}},
+		{0, `yyyy-mm-dd\ hh:mm`, numFmt{kind: numFmtDate}},
+		{0, `#,##0.000`, numFmt{kind: numFmtNumber, scale: 3}},
+		{0, `[$Rp-421]\ #,##0.00`, numFmt{kind: numFmtNumber, scale: 2}},
+		// Huruf d dalam kutip dan warna [Red] bukan bagian tanggal
+		{0, `#,##0 "days"`, numFmt{kind: numFmtNumber}},
+		{0, `[Red]0.0;[Blue]-0.0`, numFmt{kind: numFmtNumber, scale: 1}},
+		{0, `0.0%`, numFmt{kind: numFmtNumber, scale: 3}},
+		{0, `#,##0.00_);(#,##0.00)`, numFmt{kind: numFmtNumber, scale: 2}},
+		{0, `0.00E+00`, numFmt{}},
+		{0, `# ?/?`, numFmt{}},
+		{0, `"Rp"`, numFmt{}},
+		{0, `[Red0.00`, numFmt{}},
+		{0, `@`, numFmt{kind: numFmtText}},
+	}
+	for _, test := range tests {
+		if got := classifyNumFmt(test.id, test.code); got != test.want {
+			t.Errorf("classifyNumFmt(%d, %q) = %+v, ingin %+v", test.id, test.code, got, test.want)
+		}
+	}
+}
+
+func TestSerialTime(t *testing.T) {
+	tests := []struct {
+		value    float64
+		date1904 bool
+		want     string
+	}{
+		{45292, false, "2024-01-01"},
+		{45292.5, false, "2024-01-01 12:00:00"},
+		{0.75, false, "18:00:00"},
+		{0.999988, false, "23:59:59"},
+		{43830, true, "2024-01-01"},
+	}
+	for _, test := range tests {
+		if got := serialTime(test.value, test.date1904); got != test.want {
+			t.Errorf("serialTime(%v, %v) = %q, ingin %q", test.value, test.date1904, got, test.want)
+		}
+	}
+}
+
+func TestNumberFormats(t *testing.T) {
+	xlsx := excelize.NewFile()
+	defer xlsx.Close()
+	const sheet = "Sheet1"
+	style := func(numFmt int, code string) int {
+		s := &excelize.Style{NumFmt: numFmt}
+		if code != "" {
+			s.CustomNumFmt = &code
+		}
+		id, err := xlsx.NewStyle(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return id
+	}
+	columns := []struct {
+		header string
+		style  int
+		values []interface{}
+	}{
+		{"tanggal", style(0, "dd/mm/yyyy"), []interface{}{45292, 45293}},
+		{"waktu", style(0, "hh:mm"), []interface{}{0.5, 0.25}},
+		{"dicatat", style(22, ""), []interface{}{45292.5, 45293}},
+		{"harga", style(0, `[$Rp-421]\ #,##0.00`), []interface{}{1250.5, 3}},
+		{"persen", style(10, ""), []interface{}{0.125, 1}},
+		{"jumlah", style(3, ""), []interface{}{1000, 2000}},
+		{"besar", style(1, ""), []interface{}{5000000000, 1}},
+		{"kode", style(49, ""), []interface{}{"007", "0123"}},
+		{"campur", style(14, ""), []interface{}{45292, "teks"}},
+	}
+	for j, column := range columns {
+		cell, _ := excelize.CoordinatesToCellName(j+1, 1)
+		xlsx.SetCellValue(sheet, cell, column.header)
+		for i, value := range column.values {
+			cell, _ := excelize.CoordinatesToCellName(j+1, i+2)
+			xlsx.SetCellValue(sheet, cell, value)
+			xlsx.SetCellStyle(sheet, cell, cell, column.style)
+		}
+	}
+	path := filepath.Join(t.TempDir(), "format.xlsx")
+	if err := xlsx.SaveAs(path); err != nil {
+		t.Fatal(err)
+	}
+
+	var rows [][]string
+	opts := Options{
+		Table:         "format",
+		NumberFormats: true,
+		OnRow:         func(_ *Result, row []string) { rows = append(rows, append([]string(nil), row...)) },
+	}
+	result, err := ConvertFile(context.Background(), path, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantTypes := []string{"DATE", "TIME", "DATETIME", "DECIMAL(18,2)", "DECIMAL(18,4)", "INT", "BIGINT", "VARCHAR(4)"}
+	if !reflect.DeepEqual(result.Types[:len(wantTypes)], wantTypes) {
+		t.Errorf("Types = %q, ingin %q", result.Types[:len(wantTypes)], wantTypes)
+	}
+	// Kolom dengan format campuran ditentukan inferensi
+	if got := result.Types[len(wantTypes)]; got == "DATE" {
+		t.Errorf("tipe kolom campur = %q", got)
+	}
+	wantRows := [][]string{
+		{"2024-01-01", "12:00:00", "2024-01-01 12:00:00", "1250.5", "0.125", "1000", "5000000000", "007", "2024-01-01"},
+		{"2024-01-02", "06:00:00", "2024-01-02", "3", "1", "2000", "1", "0123", "teks"},
+	}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("rows = %q, ingin %q", rows, wantRows)
+	}
+}
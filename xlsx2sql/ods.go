@@ -0,0 +1,377 @@
+package xlsx2sql
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Namespace OpenDocument yang dipakai content.xml dan settings.xml.
+const (
+	odsTableNS  = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	odsOfficeNS = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	odsTextNS   = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	odsStyleNS  = "urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+	odsConfigNS = "urn:oasis:names:tc:opendocument:xmlns:config:1.0"
+)
+
+// ErrNoSheet dikembalikan bila sheet yang diminta tidak ada pada file ODS.
+var ErrNoSheet = errors.New("xlsx2sql: sheet tidak ditemukan")
+
+// odsReader membaca baris satu sheet OpenDocument Spreadsheet (.ods) langsung
+// dari content.xml dengan xml.Decoder, sehingga sheet tidak dimuat utuh ke
+// memori. Sel angka, tanggal, waktu dan boolean dibaca dari nilai mentahnya
+// (office:value, office:date-value, ...), bukan dari teks yang ditampilkan,
+// sehingga format tampilan LibreOffice tidak memengaruhi inferensi tipe.
+type odsReader struct {
+	archive *zip.ReadCloser
+	content io.ReadCloser
+	dec     *xml.Decoder
+	sheet   string
+
+	// repeat adalah jumlah pengulangan row yang belum dikembalikan, empty
+	// jumlah baris kosong yang belum dikembalikan sebelum row
+	row    []string
+	repeat int
+	empty  int
+	done   bool
+}
+
+// newODSReader membuka sheet sheetName pada file ODS path, default sheet
+// aktif yang tersimpan di settings.xml atau sheet pertama.
+func newODSReader(path, sheetName string) (*odsReader, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	if sheetName == "" {
+		sheetName = odsActiveSheet(archive)
+	}
+	content, err := archive.Open("content.xml")
+	if err != nil {
+		archive.Close()
+		return nil, err
+	}
+	r := &odsReader{archive: archive, content: content, dec: xml.NewDecoder(content)}
+
+	// Maju sampai elemen table:table yang diminta
+	for {
+		token, err := r.dec.Token()
+		if err == io.EOF {
+			r.Close()
+			return nil, &SheetError{Sheet: sheetName, Err: ErrNoSheet}
+		}
+		if err != nil {
+			r.Close()
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Space != odsTableNS || start.Name.Local != "table" {
+			continue
+		}
+		name := odsAttr(start, odsTableNS, "name")
+		if sheetName == "" || name == sheetName {
+			r.sheet = name
+			return r, nil
+		}
+		if err := r.dec.Skip(); err != nil {
+			r.Close()
+			return nil, err
+		}
+	}
+}
+
+func (r *odsReader) Read() ([]string, error) {
+	for {
+		if r.empty > 0 {
+			r.empty--
+			return []string{}, nil
+		}
+		if r.repeat > 0 {
+			r.repeat--
+			return append([]string(nil), r.row...), nil
+		}
+		if r.done {
+			return nil, io.EOF
+		}
+		if err := r.nextRow(); err != nil {
+			return nil, &SheetError{Sheet: r.sheet, Err: err}
+		}
+	}
+}
+
+// nextRow membaca table:table-row berikutnya ke r.row. Baris kosong hanya
+// dihitung dan dikembalikan bila ada baris berisi sesudahnya, karena
+// LibreOffice menulis sisa sheet sebagai satu baris kosong yang diulang
+// sampai jutaan kali.
+func (r *odsReader) nextRow() error {
+	empty := 0
+	for {
+		token, err := r.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.EndElement:
+			if t.Name.Space == odsTableNS && t.Name.Local == "table" {
+				r.done = true
+				return nil
+			}
+		case xml.StartElement:
+			if t.Name.Space != odsTableNS || t.Name.Local != "table-row" {
+				// table:table-row-group dan table:table-header-rows dimasuki,
+				// elemen lain seperti table:table-column dilewati
+				continue
+			}
+			repeat := odsRepeat(t, "number-rows-repeated")
+			row, err := r.readCells()
+			if err != nil {
+				return err
+			}
+			if len(row) == 0 {
+				empty += repeat
+				continue
+			}
+			r.empty, r.row, r.repeat = empty, row, repeat
+			return nil
+		}
+	}
+}
+
+// readCells membaca sel satu table:table-row sampai elemen penutupnya. Sel
+// kosong di akhir baris dibuang; sel kosong lain baru ditambahkan saat sel
+// berisi sesudahnya ditemukan.
+func (r *odsReader) readCells() ([]string, error) {
+	var row []string
+	empty := 0
+	for {
+		token, err := r.dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.EndElement:
+			if t.Name.Space == odsTableNS && t.Name.Local == "table-row" {
+				return row, nil
+			}
+		case xml.StartElement:
+			if t.Name.Space != odsTableNS || (t.Name.Local != "table-cell" && t.Name.Local != "covered-table-cell") {
+				continue
+			}
+			value, err := r.cellValue(t)
+			if err != nil {
+				return nil, err
+			}
+			repeat := odsRepeat(t, "number-columns-repeated")
+			if value == "" {
+				empty += repeat
+				continue
+			}
+			for ; empty > 0; empty-- {
+				row = append(row, "")
+			}
+			for ; repeat > 0; repeat-- {
+				row = append(row, value)
+			}
+		}
+	}
+}
+
+// cellValue membaca isi satu sel dan mengembalikan nilainya. Sel yang
+// tergabung (table:covered-table-cell) menjadi kosong.
+func (r *odsReader) cellValue(start xml.StartElement) (string, error) {
+	text, err := odsText(r.dec)
+	if err != nil || start.Name.Local == "covered-table-cell" {
+		return "", err
+	}
+	switch odsAttr(start, odsOfficeNS, "value-type") {
+	case "float", "percentage", "currency":
+		return odsAttr(start, odsOfficeNS, "value"), nil
+	case "date":
+		return odsDate(odsAttr(start, odsOfficeNS, "date-value")), nil
+	case "time":
+		return odsTime(odsAttr(start, odsOfficeNS, "time-value")), nil
+	case "boolean":
+		return odsAttr(start, odsOfficeNS, "boolean-value"), nil
+	}
+	return text, nil
+}
+
+// Close menutup content.xml dan arsip ODS.
+func (r *odsReader) Close() error {
+	r.content.Close()
+	return r.archive.Close()
+}
+
+// odsText mengumpulkan teks sel sampai elemen penutupnya. Paragraf
+// (text:p) dipisahkan baris baru; text:s, text:tab dan text:line-break
+// diganti spasi, tab dan baris baru.
+func odsText(dec *xml.Decoder) (string, error) {
+	var b strings.Builder
+	paragraphs, depth := 0, 1
+	for depth > 0 {
+		token, err := dec.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			if t.Name.Space == odsOfficeNS && t.Name.Local == "annotation" {
+				// Komentar sel bukan bagian nilai
+				if err := dec.Skip(); err != nil {
+					return "", err
+				}
+				depth--
+				continue
+			}
+			if t.Name.Space != odsTextNS {
+				continue
+			}
+			switch t.Name.Local {
+			case "p", "h":
+				if paragraphs > 0 {
+					b.WriteByte('\n')
+				}
+				paragraphs++
+			case "s":
+				count, err := strconv.Atoi(odsAttr(t, odsTextNS, "c"))
+				if err != nil || count < 1 {
+					count = 1
+				}
+				b.WriteString(strings.Repeat(" ", count))
+			case "tab":
+				b.WriteByte('\t')
+			case "line-break":
+				b.WriteByte('\n')
+			}
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			if paragraphs > 0 {
+				b.Write(t)
+			}
+		}
+	}
+	return b.String(), nil
+}
+
+// odsDate mengubah office:date-value seperti 2024-01-02T10:30:00.5 menjadi
+// 2024-01-02 10:30:00 agar dikenali sebagai DATETIME. Tanggal tanpa jam
+// ditulis LibreOffice tanpa bagian T dan tetap menjadi DATE.
+func odsDate(value string) string {
+	date, clock, ok := strings.Cut(value, "T")
+	if !ok {
+		return value
+	}
+	clock, _, _ = strings.Cut(clock, ".")
+	return date + " " + clock
+}
+
+// odsTime mengubah durasi ISO 8601 office:time-value seperti PT10H30M00S
+// menjadi 10:30:00.
+func odsTime(value string) string {
+	var hours, minutes int
+	var seconds float64
+	if _, err := fmt.Sscanf(value, "PT%dH%dM%fS", &hours, &minutes, &seconds); err != nil {
+		return value
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, int(seconds))
+}
+
+// odsRepeat mengembalikan atribut pengulangan table:name, minimal 1.
+func odsRepeat(start xml.StartElement, name string) int {
+	repeat, err := strconv.Atoi(odsAttr(start, odsTableNS, name))
+	if err != nil || repeat < 1 {
+		return 1
+	}
+	return repeat
+}
+
+func odsAttr(start xml.StartElement, space, local string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Space == space && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// odsActiveSheet membaca nama sheet aktif (config-item ActiveTable) dari
+// settings.xml. String kosong berarti sheet pertama.
+func odsActiveSheet(archive *zip.ReadCloser) string {
+	settings, err := archive.Open("settings.xml")
+	if err != nil {
+		return ""
+	}
+	defer settings.Close()
+	dec := xml.NewDecoder(settings)
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Space != odsConfigNS || start.Name.Local != "config-item" || odsAttr(start, odsConfigNS, "name") != "ActiveTable" {
+			continue
+		}
+		var name string
+		if dec.DecodeElement(&name, &start) != nil {
+			return ""
+		}
+		return name
+	}
+}
+
+// ODSSheets mengembalikan nama sheet file ODS path yang tidak disembunyikan,
+// sesuai urutan pada file.
+func ODSSheets(path string) ([]string, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.Close()
+	content, err := archive.Open("content.xml")
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+
+	// Sheet tersembunyi memakai style tabel dengan table:display="false"
+	hidden := make(map[string]bool)
+	style := ""
+	var sheets []string
+	dec := xml.NewDecoder(content)
+	for {
+		token, err := dec.Token()
+		if err == io.EOF {
+			return sheets, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch {
+		case start.Name.Space == odsStyleNS && start.Name.Local == "style":
+			style = odsAttr(start, odsStyleNS, "name")
+		case start.Name.Space == odsStyleNS && start.Name.Local == "table-properties":
+			if odsAttr(start, odsTableNS, "display") == "false" {
+				hidden[style] = true
+			}
+		case start.Name.Space == odsTableNS && start.Name.Local == "table":
+			if !hidden[odsAttr(start, odsTableNS, "style-name")] {
+				sheets = append(sheets, odsAttr(start, odsTableNS, "name"))
+			}
+			if err := dec.Skip(); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package xlsx2sql
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// testODSContent adalah content.xml dengan sheet tersembunyi, sheet Data dan
+// sheet Lain. Sheet Data berisi header di table:table-header-rows, baris
+// yang diulang, baris kosong, sel tergabung, sel bertipe dengan teks tampilan
+// yang berbeda dari office:value dan sisa sheet kosong yang diulang.
+const testODSContent = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content
+ xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+ xmlns:style="urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+ xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+ xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0">
+<office:automatic-styles>
+ <style:style style:name="ta1" style:family="table"><style:table-properties table:display="true"/></style:style>
+ <style:style style:name="ta2" style:family="table"><style:table-properties table:display="false"/></style:style>
+</office:automatic-styles>
+<office:body><office:spreadsheet>
+<table:table table:name="Tersembunyi" table:style-name="ta2">
+ <table:table-row><table:table-cell office:value-type="string"><text:p>rahasia</text:p></table:table-cell></table:table-row>
+</table:table>
+<table:table table:name="Data" table:style-name="ta1">
+ <table:table-column table:number-columns-repeated="7"/>
+ <table:table-header-rows>
+  <table:table-row>
+   <table:table-cell office:value-type="string"><text:p>nama</text:p></table:table-cell>
+   <table:table-cell office:value-type="string"><text:p>nilai</text:p></table:table-cell>
+   <table:table-cell office:value-type="string"><text:p>tanggal</text:p></table:table-cell>
+   <table:table-cell office:value-type="string"><text:p>waktu</text:p></table:table-cell>
+   <table:table-cell office:value-type="string"><text:p>aktif</text:p></table:table-cell>
+  </table:table-row>
+ </table:table-header-rows>
+ <table:table-row table:number-rows-repeated="2">
+  <table:table-cell office:value-type="string"><text:p>apel</text:p></table:table-cell>
+  <table:table-cell office:value-type="float" office:value="1.5"><text:p>1,50</text:p></table:table-cell>
+  <table:table-cell office:value-type="date" office:date-value="2024-01-02T10:30:00.5"><text:p>02/01/24</text:p></table:table-cell>
+  <table:table-cell office:value-type="time" office:time-value="PT10H30M00S"><text:p>10.30</text:p></table:table-cell>
+  <table:table-cell office:value-type="boolean" office:boolean-value="true"><text:p>BENAR</text:p></table:table-cell>
+  <table:table-cell table:number-columns-repeated="1019"/>
+ </table:table-row>
+ <table:table-row table:number-rows-repeated="2">
+  <table:table-cell table:number-columns-repeated="1024"/>
+ </table:table-row>
+ <table:table-row-group>
+  <table:table-row>
+   <table:table-cell table:number-columns-spanned="2" office:value-type="string"><text:p>gabung</text:p></table:table-cell>
+   <table:covered-table-cell office:value-type="string"><text:p>tertutup</text:p></table:covered-table-cell>
+   <table:table-cell table:number-columns-repeated="2" office:value-type="percentage" office:value="0.25"><text:p>25%</text:p></table:table-cell>
+   <table:table-cell office:value-type="currency" office:value="1250.5"><text:p>Rp1.250,50</text:p></table:table-cell>
+   <table:table-cell office:value-type="date" office:date-value="2024-03-04"><text:p>04/03/24</text:p></table:table-cell>
+   <table:table-cell/>
+   <table:table-cell office:value-type="string"><office:annotation><text:p>komentar</text:p></office:annotation><text:p>a<text:s text:c="3"/>b</text:p><text:p>baris<text:tab/>dua</text:p></table:table-cell>
+  </table:table-row>
+ </table:table-row-group>
+ <table:table-row table:number-rows-repeated="1048570">
+  <table:table-cell table:number-columns-repeated="1024"/>
+ </table:table-row>
+</table:table>
+<table:table table:name="Lain" table:style-name="ta1">
+ <table:table-row><table:table-cell office:value-type="string"><text:p>lain</text:p></table:table-cell></table:table-row>
+</table:table>
+</office:spreadsheet></office:body>
+</office:document-content>`
+
+// testODSSettings menandai sheet Data sebagai sheet aktif.
+const testODSSettings = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-settings
+ xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+ xmlns:config="urn:oasis:names:tc:opendocument:xmlns:config:1.0">
+<office:settings><config:config-item-set config:name="ooo:view-settings">
+ <config:config-item config:name="ActiveTable" config:type="string">Data</config:config-item>
+</config:config-item-set></office:settings>
+</office:document-settings>`
+
+// writeODS menulis file ODS berisi files (nama di arsip ke isi).
+func writeODS(t *testing.T, files map[string]string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "data.ods")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// readODS membaca semua baris sheet sheetName.
+func readODS(t *testing.T, path, sheetName string) (string, [][]string) {
+	t.Helper()
+	r, err := newODSReader(path, sheetName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	var rows [][]string
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return r.sheet, rows
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestODSReader(t *testing.T) {
+	path := writeODS(t, map[string]string{"content.xml": testODSContent, "settings.xml": testODSSettings})
+	sheet, rows := readODS(t, path, "")
+	if sheet != "Data" {
+		t.Errorf("sheet = %q, ingin Data", sheet)
+	}
+	record := []string{"apel", "1.5", "2024-01-02 10:30:00", "10:30:00", "true"}
+	want := [][]string{
+		{"nama", "nilai", "tanggal", "waktu", "aktif"},
+		record,
+		record,
+		{},
+		{},
+		{"gabung", "", "0.25", "0.25", "1250.5", "2024-03-04", "", "a   b\nbaris\tdua"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("rows = %q, ingin %q", rows, want)
+	}
+}
+
+func TestODSReaderSheet(t *testing.T) {
+	// Tanpa settings.xml sheet pertama dibaca walaupun tersembunyi
+	path := writeODS(t, map[string]string{"content.xml": testODSContent})
+	tests := []struct {
+		sheetName string
+		sheet     string
+		rows      [][]string
+	}{
+		{"", "Tersembunyi", [][]string{{"rahasia"}}},
+		{"Lain", "Lain", [][]string{{"lain"}}},
+	}
+	for _, test := range tests {
+		sheet, rows := readODS(t, path, test.sheetName)
+		if sheet != test.sheet || !reflect.DeepEqual(rows, test.rows) {
+			t.Errorf("sheet %q = %q %q, ingin %q %q", test.sheetName, sheet, rows, test.sheet, test.rows)
+		}
+	}
+	if _, err := newODSReader(path, "Tidak Ada"); !errors.Is(err, ErrNoSheet) {
+		t.Errorf("newODSReader(Tidak Ada) = %v, ingin %v", err, ErrNoSheet)
+	}
+}
+
+func TestODSSheets(t *testing.T) {
+	sheets, err := ODSSheets(writeODS(t, map[string]string{"content.xml": testODSContent}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Data", "Lain"}; !reflect.DeepEqual(sheets, want) {
+		t.Errorf("ODSSheets = %q, ingin %q", sheets, want)
+	}
+}
+
+func TestODSValues(t *testing.T) {
+	tests := []struct {
+		convert func(string) string
+		value   string
+		want    string
+	}{
+		{odsDate, "2024-01-02T10:30:00", "2024-01-02 10:30:00"},
+		{odsDate, "2024-01-02T10:30:00.123", "2024-01-02 10:30:00"},
+		{odsDate, "2024-01-02", "2024-01-02"},
+		{odsTime, "PT08H05M09.5S", "08:05:09"},
+		{odsTime, "PT36H00M00S", "36:00:00"},
+		{odsTime, "P1D", "P1D"},
+	}
+	for _, test := range tests {
+		if got := test.convert(test.value); got != test.want {
+			t.Errorf("konversi %q = %q, ingin %q", test.value, got, test.want)
+		}
+	}
+}
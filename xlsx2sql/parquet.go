@@ -0,0 +1,1096 @@
+package xlsx2sql
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// ErrParquetFormat dikembalikan bila file bukan Parquet atau metadatanya rusak.
+var ErrParquetFormat = errors.New("xlsx2sql: file Parquet tidak valid")
+
+// ErrParquetUnsupported dikembalikan untuk kolom bersarang atau berulang,
+// encoding dan kompresi Parquet yang tidak didukung.
+var ErrParquetUnsupported = errors.New("xlsx2sql: fitur Parquet tidak didukung")
+
+// Tipe fisik Parquet
+const (
+	parquetBoolean = iota
+	parquetInt32
+	parquetInt64
+	parquetInt96
+	parquetFloat
+	parquetDouble
+	parquetByteArray
+	parquetFixedLenByteArray
+)
+
+// Encoding Parquet
+const (
+	encodingPlain                = 0
+	encodingPlainDictionary      = 2
+	encodingRLE                  = 3
+	encodingDeltaBinaryPacked    = 5
+	encodingDeltaLengthByteArray = 6
+	encodingDeltaByteArray       = 7
+	encodingRLEDictionary        = 8
+)
+
+// maxParquetGroupRows adalah jumlah baris terbanyak satu row group. Seluruh
+// nilai row group dibaca ke memori sehingga row group yang lebih besar
+// ditolak, juga agar metadata rusak tidak memicu alokasi raksasa.
+const maxParquetGroupRows = 1 << 26
+
+// Jenis halaman Parquet
+const (
+	pageData       = 0
+	pageDictionary = 2
+	pageDataV2     = 3
+)
+
+// parquetColumn adalah satu kolom daun skema Parquet beserta cara mengubah
+// nilainya menjadi teks sel.
+type parquetColumn struct {
+	name     string
+	physical int
+	length   int // panjang FIXED_LEN_BYTE_ARRAY
+	optional bool
+
+	// sqlType adalah tipe MariaDB dari tipe logika kolom, kosong bila tipe
+	// ditentukan dengan inferensi (misalnya STRING)
+	sqlType string
+
+	// format mengubah nilai int32/int64 (tipe logika DATE, TIME, TIMESTAMP,
+	// DECIMAL dan unsigned); nil untuk bilangan biasa
+	format func(v int64) string
+
+	// scale adalah skala DECIMAL pada BYTE_ARRAY dan FIXED_LEN_BYTE_ARRAY,
+	// -1 bila bukan DECIMAL
+	scale int
+	uuid  bool
+}
+
+// parquetReader membaca file Parquet dengan skema datar per row group: semua
+// nilai satu row group dibaca ke memori lalu dikembalikan baris demi baris.
+// Tipe kolom diambil dari tipe logika Parquet (Types), sehingga inferensi
+// hanya dipakai untuk kolom teks.
+type parquetReader struct {
+	file    *os.File
+	size    int64
+	columns []parquetColumn
+	groups  []thriftStruct
+
+	header []string
+	group  int
+	values [][]string
+	row    int
+	rows   int
+}
+
+func newParquetReader(path string) (*parquetReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &parquetReader{file: file}
+	if err := r.readFooter(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	r.header = make([]string, len(r.columns))
+	for i, column := range r.columns {
+		r.header[i] = column.name
+	}
+	return r, nil
+}
+
+// readFooter membaca FileMetaData di akhir file: metadata Thrift compact,
+// panjangnya (4 byte) dan penanda PAR1.
+func (r *parquetReader) readFooter() error {
+	info, err := r.file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.Size() < 12 {
+		return ErrParquetFormat
+	}
+	r.size = info.Size()
+	tail := make([]byte, 8)
+	if _, err := r.file.ReadAt(tail, info.Size()-8); err != nil {
+		return err
+	}
+	size := int64(binary.LittleEndian.Uint32(tail))
+	if string(tail[4:]) != "PAR1" || size > info.Size()-12 {
+		return ErrParquetFormat
+	}
+	footer := make([]byte, size)
+	if _, err := r.file.ReadAt(footer, info.Size()-8-size); err != nil {
+		return err
+	}
+	t := &thriftReader{data: footer}
+	meta := t.readStruct()
+	if t.err != nil {
+		return t.err
+	}
+
+	schema := meta.list(2)
+	if len(schema) == 0 {
+		return ErrParquetFormat
+	}
+	for _, element := range schema[1:] {
+		element, ok := element.(thriftStruct)
+		if !ok {
+			return ErrParquetFormat
+		}
+		name := string(element.bytes(4))
+		if element.int(5) > 0 {
+			return fmt.Errorf("%w: kolom bersarang %s", ErrParquetUnsupported, name)
+		}
+		if element.int(3) == 2 {
+			return fmt.Errorf("%w: kolom berulang %s", ErrParquetUnsupported, name)
+		}
+		r.columns = append(r.columns, newParquetColumn(name, element))
+	}
+	for _, group := range meta.list(4) {
+		group, ok := group.(thriftStruct)
+		if !ok {
+			return ErrParquetFormat
+		}
+		r.groups = append(r.groups, group)
+	}
+	return nil
+}
+
+// newParquetColumn menentukan tipe MariaDB dan format nilai kolom dari
+// SchemaElement: tipe logika (LogicalType), atau ConvertedType pada file
+// dari penulis lama.
+func newParquetColumn(name string, element thriftStruct) parquetColumn {
+	c := parquetColumn{
+		name:     name,
+		physical: int(element.int(1)),
+		length:   int(element.int(2)),
+		optional: element.int(3) == 1,
+		scale:    -1,
+	}
+	logical := element.strct(10)
+	converted := int64(-1)
+	if _, ok := element[6]; ok {
+		converted = element.int(6)
+	}
+	unit := func(s thriftStruct) int64 {
+		switch {
+		case s.strct(2).has(1):
+			return int64(time.Millisecond)
+		case s.strct(2).has(2):
+			return int64(time.Microsecond)
+		}
+		return int64(time.Nanosecond)
+	}
+
+	switch {
+	case logical.has(5) || converted == 5:
+		scale, precision := element.int(7), element.int(8)
+		if logical.has(5) {
+			scale, precision = logical.strct(5).int(1), logical.strct(5).int(2)
+		}
+		if precision > 0 && precision <= 65 {
+			c.sqlType = fmt.Sprintf("DECIMAL(%d,%d)", precision, scale)
+		}
+		c.scale = int(scale)
+		c.format = func(v int64) string { return formatDecimal(big.NewInt(v), c.scale) }
+	case logical.has(6) || converted == 6:
+		c.sqlType = "DATE"
+		c.format = func(v int64) string { return time.Unix(v*86400, 0).UTC().Format("2006-01-02") }
+	case logical.has(7) || converted == 7 || converted == 8:
+		nanos := int64(time.Millisecond)
+		if logical.has(7) {
+			nanos = unit(logical.strct(7))
+		} else if converted == 8 {
+			nanos = int64(time.Microsecond)
+		}
+		c.sqlType, c.format = parquetTimeType("TIME", "15:04:05", nanos)
+	case logical.has(8) || converted == 9 || converted == 10:
+		nanos := int64(time.Millisecond)
+		if logical.has(8) {
+			nanos = unit(logical.strct(8))
+		} else if converted == 10 {
+			nanos = int64(time.Microsecond)
+		}
+		c.sqlType, c.format = parquetTimeType("DATETIME", "2006-01-02 15:04:05", nanos)
+	case logical.has(10) || converted >= 11 && converted <= 18:
+		bitWidth, signed := int64(32), converted >= 15
+		if c.physical == parquetInt64 {
+			bitWidth = 64
+		}
+		if logical.has(10) {
+			bitWidth, signed = logical.strct(10).int(1), logical.strct(10).bool(2)
+		}
+		switch {
+		case bitWidth == 64 && !signed:
+			c.sqlType = "DECIMAL(20,0)"
+			c.format = func(v int64) string { return strconv.FormatUint(uint64(v), 10) }
+		case bitWidth == 32 && !signed:
+			c.sqlType = "BIGINT"
+			c.format = func(v int64) string { return strconv.FormatUint(uint64(uint32(v)), 10) }
+		case bitWidth == 64:
+			c.sqlType = "BIGINT"
+		default:
+			c.sqlType = "INT"
+		}
+	case logical.has(12) || converted == 19:
+		c.sqlType = "JSON"
+	case logical.has(14):
+		c.sqlType = "UUID"
+		c.uuid = true
+	case logical.has(1) || logical.has(4) || converted == 0 || converted == 4:
+		// STRING dan ENUM: panjang VARCHAR ditentukan inferensi
+	default:
+		switch c.physical {
+		case parquetBoolean:
+			c.sqlType = "BOOLEAN"
+		case parquetInt32:
+			c.sqlType = "INT"
+		case parquetInt64:
+			c.sqlType = "BIGINT"
+		case parquetInt96:
+			c.sqlType = "DATETIME(6)"
+		case parquetFloat:
+			c.sqlType = "FLOAT"
+		case parquetDouble:
+			c.sqlType = "DOUBLE"
+		}
+	}
+	return c
+}
+
+// parquetTimeType mengembalikan tipe TIME atau DATETIME dengan presisi pecahan
+// detik sesuai satuan nanos (milidetik, mikrodetik atau nanodetik) beserta
+// fungsi format nilainya. Nanodetik dipotong menjadi mikrodetik, presisi
+// tertinggi MariaDB.
+func parquetTimeType(base, layout string, nanos int64) (string, func(int64) string) {
+	digits := 6
+	if nanos == int64(time.Millisecond) {
+		digits = 3
+	}
+	layout += "." + "000000"[:digits]
+	perSecond := int64(time.Second) / nanos
+	return fmt.Sprintf("%s(%d)", base, digits), func(v int64) string {
+		return time.Unix(v/perSecond, v%perSecond*nanos).UTC().Format(layout)
+	}
+}
+
+// Types mengembalikan tipe MariaDB setiap kolom dari skema Parquet; tipe
+// kosong untuk kolom teks yang ditentukan dengan inferensi.
+func (r *parquetReader) Types() []string {
+	types := make([]string, len(r.columns))
+	for i, column := range r.columns {
+		types[i] = column.sqlType
+	}
+	return types
+}
+
+func (r *parquetReader) Read() ([]string, error) {
+	if r.header != nil {
+		header := r.header
+		r.header = nil
+		return header, nil
+	}
+	for r.row >= r.rows {
+		if r.group >= len(r.groups) {
+			return nil, io.EOF
+		}
+		if err := r.readGroup(r.groups[r.group]); err != nil {
+			return nil, err
+		}
+		r.group++
+	}
+	row := make([]string, len(r.columns))
+	for i := range row {
+		row[i] = r.values[i][r.row]
+	}
+	r.row++
+	return row, nil
+}
+
+// readGroup membaca semua kolom satu RowGroup.
+func (r *parquetReader) readGroup(group thriftStruct) error {
+	chunks := group.list(1)
+	if len(chunks) != len(r.columns) {
+		return ErrParquetFormat
+	}
+	rows := group.int(3)
+	if rows < 0 {
+		return ErrParquetFormat
+	}
+	if rows > maxParquetGroupRows {
+		return fmt.Errorf("%w: row group %d baris", ErrParquetUnsupported, rows)
+	}
+	r.rows = int(rows)
+	r.row = 0
+	r.values = make([][]string, len(r.columns))
+	for i, chunk := range chunks {
+		chunk, ok := chunk.(thriftStruct)
+		if !ok {
+			return ErrParquetFormat
+		}
+		values, err := r.readChunk(&r.columns[i], chunk.strct(3), r.rows)
+		if err != nil {
+			return fmt.Errorf("kolom %s: %w", r.columns[i].name, err)
+		}
+		if len(values) != r.rows {
+			return fmt.Errorf("kolom %s: %w", r.columns[i].name, ErrParquetFormat)
+		}
+		r.values[i] = values
+	}
+	return nil
+}
+
+// readChunk membaca ColumnChunk meta (ColumnMetaData) berisi rows nilai:
+// halaman dictionary bila ada, lalu halaman data v1 atau v2.
+func (r *parquetReader) readChunk(column *parquetColumn, meta thriftStruct, rows int) ([]string, error) {
+	offset := meta.int(9)
+	if dict := meta.int(11); dict > 0 && dict < offset {
+		offset = dict
+	}
+	size := meta.int(7)
+	if size <= 0 || offset < 0 || size > r.size-offset {
+		return nil, ErrParquetFormat
+	}
+	data := make([]byte, size)
+	if _, err := r.file.ReadAt(data, offset); err != nil {
+		return nil, err
+	}
+	codec := meta.int(4)
+
+	var dict, values []string
+	for len(data) > 0 {
+		t := &thriftReader{data: data}
+		header := t.readStruct()
+		if t.err != nil {
+			return nil, t.err
+		}
+		compressed := int(header.int(3))
+		if compressed < 0 || t.pos+compressed > len(data) {
+			return nil, ErrParquetFormat
+		}
+		page := data[t.pos : t.pos+compressed]
+		data = data[t.pos+compressed:]
+
+		switch header.int(1) {
+		case pageDictionary:
+			page, err := decompress(codec, page, int(header.int(2)))
+			if err != nil {
+				return nil, err
+			}
+			// Setiap nilai PLAIN paling sedikit 1 bit (BOOLEAN)
+			n := header.strct(7).int(1)
+			if n < 0 || n > 8*int64(len(page)) {
+				return nil, ErrParquetFormat
+			}
+			if dict, _, err = column.plain(page, int(n)); err != nil {
+				return nil, err
+			}
+		case pageData:
+			page, err := decompress(codec, page, int(header.int(2)))
+			if err != nil {
+				return nil, err
+			}
+			dataHeader := header.strct(5)
+			n := dataHeader.int(1)
+			if n < 0 || n > int64(rows-len(values)) {
+				return nil, ErrParquetFormat
+			}
+			var defined []bool
+			if column.optional {
+				if len(page) < 4 {
+					return nil, ErrParquetFormat
+				}
+				length := int(binary.LittleEndian.Uint32(page))
+				if 4+length > len(page) {
+					return nil, ErrParquetFormat
+				}
+				if defined, err = definitionLevels(page[4:4+length], int(n)); err != nil {
+					return nil, err
+				}
+				page = page[4+length:]
+			}
+			cells, err := column.decodePage(page, int(dataHeader.int(2)), int(n), defined, dict)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, cells...)
+		case pageDataV2:
+			v2 := header.strct(8)
+			n := int(v2.int(1))
+			if n < 0 || n > rows-len(values) {
+				return nil, ErrParquetFormat
+			}
+			defLength, repLength := int(v2.int(5)), int(v2.int(6))
+			if defLength < 0 || repLength < 0 || defLength+repLength > len(page) {
+				return nil, ErrParquetFormat
+			}
+			var defined []bool
+			var err error
+			if column.optional {
+				if defined, err = definitionLevels(page[repLength:repLength+defLength], n); err != nil {
+					return nil, err
+				}
+			}
+			body := page[repLength+defLength:]
+			// is_compressed bernilai true bila tidak ditulis
+			if !v2.has(7) || v2.bool(7) {
+				if body, err = decompress(codec, body, int(header.int(2))-defLength-repLength); err != nil {
+					return nil, err
+				}
+			}
+			cells, err := column.decodePage(body, int(v2.int(4)), n, defined, dict)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, cells...)
+		}
+	}
+	return values, nil
+}
+
+// zstdDecoder dibuat sekali dan dipakai bersama; DecodeAll aman dipanggil
+// bersamaan.
+var (
+	zstdOnce    sync.Once
+	zstdDecoder *zstd.Decoder
+	zstdErr     error
+)
+
+// decompress membuka kompresi halaman dengan codec kolom: UNCOMPRESSED,
+// SNAPPY, GZIP atau ZSTD.
+func decompress(codec int64, page []byte, size int) ([]byte, error) {
+	switch codec {
+	case 0:
+		return page, nil
+	case 1:
+		return snappy.Decode(nil, page)
+	case 2:
+		zr, err := gzip.NewReader(bytes.NewReader(page))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		return io.ReadAll(zr)
+	case 6:
+		zstdOnce.Do(func() {
+			zstdDecoder, zstdErr = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+		})
+		if zstdErr != nil {
+			return nil, zstdErr
+		}
+		// Ukuran dari header hanya petunjuk kapasitas sehingga dibatasi
+		return zstdDecoder.DecodeAll(page, make([]byte, 0, min(max(size, 0), 1<<24)))
+	}
+	return nil, fmt.Errorf("%w: kompresi %d", ErrParquetUnsupported, codec)
+}
+
+// definitionLevels membaca definition level kolom OPTIONAL (lebar 1 bit):
+// false berarti NULL.
+func definitionLevels(data []byte, n int) ([]bool, error) {
+	levels, err := decodeHybrid(data, 1, n)
+	if err != nil {
+		return nil, err
+	}
+	defined := make([]bool, n)
+	for i, level := range levels {
+		defined[i] = level > 0
+	}
+	return defined, nil
+}
+
+// decodePage mengurai n nilai halaman data dengan encoding. defined (nil
+// untuk kolom REQUIRED) menandai nilai yang tidak NULL; hanya nilai tersebut
+// yang ditulis pada halaman.
+func (c *parquetColumn) decodePage(data []byte, encoding, n int, defined []bool, dict []string) ([]string, error) {
+	count := n
+	if defined != nil {
+		count = 0
+		for _, ok := range defined {
+			if ok {
+				count++
+			}
+		}
+	}
+
+	var values []string
+	var err error
+	switch encoding {
+	case encodingPlain:
+		values, _, err = c.plain(data, count)
+	case encodingPlainDictionary, encodingRLEDictionary:
+		if len(data) == 0 {
+			if count > 0 {
+				return nil, ErrParquetFormat
+			}
+			break
+		}
+		var indexes []int
+		if indexes, err = decodeHybrid(data[1:], int(data[0]), count); err != nil {
+			return nil, err
+		}
+		values = make([]string, count)
+		for i, index := range indexes {
+			if index >= len(dict) {
+				return nil, ErrParquetFormat
+			}
+			values[i] = dict[index]
+		}
+	case encodingRLE:
+		if c.physical != parquetBoolean || len(data) < 4 {
+			return nil, fmt.Errorf("%w: encoding RLE untuk tipe %d", ErrParquetUnsupported, c.physical)
+		}
+		var levels []int
+		if levels, err = decodeHybrid(data[4:], 1, count); err != nil {
+			return nil, err
+		}
+		values = make([]string, count)
+		for i, level := range levels {
+			values[i] = strconv.FormatBool(level > 0)
+		}
+	case encodingDeltaBinaryPacked:
+		var ints []int64
+		if ints, _, err = decodeDeltaBinaryPacked(data, count); err != nil {
+			return nil, err
+		}
+		values = make([]string, len(ints))
+		for i, v := range ints {
+			values[i] = c.integer(v)
+		}
+	case encodingDeltaLengthByteArray, encodingDeltaByteArray:
+		var arrays [][]byte
+		if encoding == encodingDeltaByteArray {
+			arrays, err = decodeDeltaByteArray(data, count)
+		} else {
+			arrays, _, err = decodeDeltaLengthByteArray(data, count)
+		}
+		if err != nil {
+			return nil, err
+		}
+		values = make([]string, len(arrays))
+		for i, b := range arrays {
+			values[i] = c.byteArray(b)
+		}
+	default:
+		return nil, fmt.Errorf("%w: encoding %d", ErrParquetUnsupported, encoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(values) < count {
+		return nil, ErrParquetFormat
+	}
+	if defined == nil {
+		return values[:n], nil
+	}
+	// Sel NULL menjadi kosong
+	cells := make([]string, n)
+	next := 0
+	for i, ok := range defined {
+		if ok {
+			cells[i] = values[next]
+			next++
+		}
+	}
+	return cells, nil
+}
+
+// plain mengurai n nilai berencoding PLAIN dan mengembalikan sisa data.
+func (c *parquetColumn) plain(data []byte, n int) ([]string, []byte, error) {
+	values := make([]string, n)
+	for i := range values {
+		switch c.physical {
+		case parquetBoolean:
+			if i/8 >= len(data) {
+				return nil, nil, ErrParquetFormat
+			}
+			values[i] = strconv.FormatBool(data[i/8]>>(i%8)&1 == 1)
+			if i == n-1 {
+				data = data[i/8+1:]
+			}
+			continue
+		case parquetInt32:
+			if len(data) < 4 {
+				return nil, nil, ErrParquetFormat
+			}
+			values[i] = c.integer(int64(int32(binary.LittleEndian.Uint32(data))))
+			data = data[4:]
+		case parquetInt64:
+			if len(data) < 8 {
+				return nil, nil, ErrParquetFormat
+			}
+			values[i] = c.integer(int64(binary.LittleEndian.Uint64(data)))
+			data = data[8:]
+		case parquetInt96:
+			if len(data) < 12 {
+				return nil, nil, ErrParquetFormat
+			}
+			values[i] = formatInt96(data[:12])
+			data = data[12:]
+		case parquetFloat:
+			if len(data) < 4 {
+				return nil, nil, ErrParquetFormat
+			}
+			values[i] = formatFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(data))), 32)
+			data = data[4:]
+		case parquetDouble:
+			if len(data) < 8 {
+				return nil, nil, ErrParquetFormat
+			}
+			values[i] = formatFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)), 64)
+			data = data[8:]
+		case parquetByteArray:
+			if len(data) < 4 {
+				return nil, nil, ErrParquetFormat
+			}
+			length := int(binary.LittleEndian.Uint32(data))
+			if length < 0 || 4+length > len(data) {
+				return nil, nil, ErrParquetFormat
+			}
+			values[i] = c.byteArray(data[4 : 4+length])
+			data = data[4+length:]
+		case parquetFixedLenByteArray:
+			if c.length <= 0 || c.length > len(data) {
+				return nil, nil, ErrParquetFormat
+			}
+			values[i] = c.byteArray(data[:c.length])
+			data = data[c.length:]
+		default:
+			return nil, nil, fmt.Errorf("%w: tipe %d", ErrParquetUnsupported, c.physical)
+		}
+	}
+	return values, data, nil
+}
+
+// integer mengubah nilai INT32 atau INT64 menjadi teks sesuai tipe logika.
+func (c *parquetColumn) integer(v int64) string {
+	if c.format != nil {
+		return c.format(v)
+	}
+	return strconv.FormatInt(v, 10)
+}
+
+// byteArray mengubah BYTE_ARRAY atau FIXED_LEN_BYTE_ARRAY menjadi teks:
+// DECIMAL dari bilangan big-endian two's complement, UUID dengan tanda
+// hubung, dan selain itu teks apa adanya.
+func (c *parquetColumn) byteArray(b []byte) string {
+	switch {
+	case c.scale >= 0:
+		v := new(big.Int).SetBytes(b)
+		if len(b) > 0 && b[0]&0x80 != 0 {
+			v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+		}
+		return formatDecimal(v, c.scale)
+	case c.uuid && len(b) == 16:
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	}
+	return string(b)
+}
+
+// formatDecimal menulis unscaled / 10^scale sebagai desimal tanpa eksponen.
+func formatDecimal(unscaled *big.Int, scale int) string {
+	if scale <= 0 {
+		return unscaled.String()
+	}
+	digits := new(big.Int).Abs(unscaled).String()
+	sign := ""
+	if unscaled.Sign() < 0 {
+		sign = "-"
+	}
+	for len(digits) <= scale {
+		digits = "0" + digits
+	}
+	return sign + digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+}
+
+// formatFloat menulis bilangan pecahan; NaN dan tak hingga, yang tidak dapat
+// disimpan MariaDB, menjadi sel kosong.
+func formatFloat(v float64, bitSize int) string {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return ""
+	}
+	return strconv.FormatFloat(v, 'g', -1, bitSize)
+}
+
+// formatInt96 mengubah timestamp INT96 (nanodetik dalam hari lalu hari
+// Julian) menjadi DATETIME(6).
+func formatInt96(b []byte) string {
+	nanos := int64(binary.LittleEndian.Uint64(b))
+	day := int64(binary.LittleEndian.Uint32(b[8:])) - 2440588
+	t := time.Unix(day*86400, 0).Add(time.Duration(nanos)).UTC()
+	return t.Format("2006-01-02 15:04:05.000000")
+}
+
+// decodeHybrid mengurai n nilai berencoding RLE/bit-packed hybrid selebar
+// bitWidth bit, dipakai untuk definition level, indeks dictionary dan boolean.
+func decodeHybrid(data []byte, bitWidth, n int) ([]int, error) {
+	if bitWidth < 0 || bitWidth > 32 {
+		return nil, ErrParquetFormat
+	}
+	// Kapasitas awal dibatasi panjang data agar n dari header rusak tidak
+	// memicu alokasi besar; run RLE tetap dapat menambah slice.
+	values := make([]int, 0, min(n, 8*len(data)))
+	for len(values) < n {
+		header, size := binary.Uvarint(data)
+		if size <= 0 {
+			return nil, ErrParquetFormat
+		}
+		data = data[size:]
+		if header&1 == 0 {
+			// Run: satu nilai diulang header>>1 kali
+			width := (bitWidth + 7) / 8
+			if len(data) < width {
+				return nil, ErrParquetFormat
+			}
+			value := 0
+			for i := 0; i < width; i++ {
+				value |= int(data[i]) << (8 * i)
+			}
+			data = data[width:]
+			for count := int(header >> 1); count > 0 && len(values) < n; count-- {
+				values = append(values, value)
+			}
+			continue
+		}
+		// Bit-packed: header>>1 kelompok berisi 8 nilai
+		groups := header >> 1
+		if bitWidth > 0 && groups > uint64(len(data)/bitWidth) {
+			return nil, ErrParquetFormat
+		}
+		size = int(groups) * bitWidth
+		count := n - len(values)
+		if groups <= uint64(count/8) {
+			count = int(groups) * 8
+		}
+		unpacked := unpackBits(data[:size], bitWidth, count)
+		data = data[size:]
+		for _, value := range unpacked {
+			if len(values) == n {
+				break
+			}
+			values = append(values, int(value))
+		}
+	}
+	return values, nil
+}
+
+// unpackBits membaca n bilangan selebar bitWidth bit yang dikemas mulai dari
+// bit terendah.
+func unpackBits(data []byte, bitWidth, n int) []uint64 {
+	values := make([]uint64, n)
+	if bitWidth == 0 {
+		return values
+	}
+	bit := 0
+	for i := range values {
+		var v uint64
+		for j := 0; j < bitWidth; j++ {
+			if data[bit/8]>>(bit%8)&1 == 1 {
+				v |= 1 << j
+			}
+			bit++
+		}
+		values[i] = v
+	}
+	return values
+}
+
+// decodeDeltaBinaryPacked mengurai paling banyak limit bilangan berencoding
+// DELTA_BINARY_PACKED dan mengembalikan panjang data yang terpakai.
+func decodeDeltaBinaryPacked(data []byte, limit int) ([]int64, int, error) {
+	pos := 0
+	uvarint := func() uint64 {
+		v, size := binary.Uvarint(data[pos:])
+		if size <= 0 {
+			pos = len(data) + 1
+			return 0
+		}
+		pos += size
+		return v
+	}
+	varint := func() int64 {
+		v, size := binary.Varint(data[pos:])
+		if size <= 0 {
+			pos = len(data) + 1
+			return 0
+		}
+		pos += size
+		return v
+	}
+	blockSize, miniblocks := int(uvarint()), int(uvarint())
+	total := int(uvarint())
+	value := varint()
+	if pos > len(data) || miniblocks <= 0 || blockSize <= 0 || blockSize%miniblocks != 0 || total < 0 || total > limit {
+		return nil, 0, ErrParquetFormat
+	}
+	perMiniblock := blockSize / miniblocks
+	values := make([]int64, 0, total)
+	if total > 0 {
+		values = append(values, value)
+	}
+	for len(values) < total {
+		minDelta := varint()
+		if pos+miniblocks > len(data) {
+			return nil, 0, ErrParquetFormat
+		}
+		widths := data[pos : pos+miniblocks]
+		pos += miniblocks
+		for _, width := range widths {
+			if len(values) == total {
+				break
+			}
+			if width > 64 || perMiniblock > 8*(len(data)-pos) && width > 0 {
+				return nil, 0, ErrParquetFormat
+			}
+			size := (perMiniblock*int(width) + 7) / 8
+			if pos+size > len(data) {
+				return nil, 0, ErrParquetFormat
+			}
+			for _, delta := range unpackBits(data[pos:pos+size], int(width), min(perMiniblock, total-len(values))) {
+				if len(values) == total {
+					break
+				}
+				value += minDelta + int64(delta)
+				values = append(values, value)
+			}
+			pos += size
+		}
+	}
+	return values, pos, nil
+}
+
+// decodeDeltaLengthByteArray mengurai DELTA_LENGTH_BYTE_ARRAY: panjang
+// berencoding DELTA_BINARY_PACKED lalu isi semua nilai berurutan, paling
+// banyak limit nilai.
+func decodeDeltaLengthByteArray(data []byte, limit int) ([][]byte, int, error) {
+	lengths, pos, err := decodeDeltaBinaryPacked(data, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	values := make([][]byte, len(lengths))
+	for i, length := range lengths {
+		if length < 0 || pos+int(length) > len(data) {
+			return nil, 0, ErrParquetFormat
+		}
+		values[i] = data[pos : pos+int(length)]
+		pos += int(length)
+	}
+	return values, pos, nil
+}
+
+// decodeDeltaByteArray mengurai DELTA_BYTE_ARRAY: panjang awalan yang sama
+// dengan nilai sebelumnya lalu akhiran berencoding DELTA_LENGTH_BYTE_ARRAY.
+func decodeDeltaByteArray(data []byte, limit int) ([][]byte, error) {
+	prefixes, pos, err := decodeDeltaBinaryPacked(data, limit)
+	if err != nil {
+		return nil, err
+	}
+	suffixes, _, err := decodeDeltaLengthByteArray(data[pos:], limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(suffixes) != len(prefixes) {
+		return nil, ErrParquetFormat
+	}
+	values := make([][]byte, len(prefixes))
+	var previous []byte
+	for i, prefix := range prefixes {
+		if prefix < 0 || int(prefix) > len(previous) {
+			return nil, ErrParquetFormat
+		}
+		value := append(append([]byte(nil), previous[:prefix]...), suffixes[i]...)
+		values[i] = value
+		previous = value
+	}
+	return values, nil
+}
+
+func (r *parquetReader) Close() error {
+	return r.file.Close()
+}
+
+// thriftStruct adalah struct Thrift yang diurai tanpa skema: nilai setiap
+// field menurut id-nya berupa int64, bool, float64, []byte, thriftStruct atau
+// []interface{} untuk list dan set.
+type thriftStruct map[int16]interface{}
+
+func (s thriftStruct) has(id int16) bool {
+	_, ok := s[id]
+	return ok
+}
+
+func (s thriftStruct) int(id int16) int64 {
+	v, _ := s[id].(int64)
+	return v
+}
+
+func (s thriftStruct) bool(id int16) bool {
+	v, _ := s[id].(bool)
+	return v
+}
+
+func (s thriftStruct) bytes(id int16) []byte {
+	v, _ := s[id].([]byte)
+	return v
+}
+
+func (s thriftStruct) strct(id int16) thriftStruct {
+	v, _ := s[id].(thriftStruct)
+	return v
+}
+
+func (s thriftStruct) list(id int16) []interface{} {
+	v, _ := s[id].([]interface{})
+	return v
+}
+
+// thriftReader mengurai Thrift compact protocol, format metadata dan header
+// halaman Parquet. Kesalahan pertama disimpan di err.
+type thriftReader struct {
+	data  []byte
+	pos   int
+	err   error
+	depth int
+}
+
+func (t *thriftReader) fail() {
+	if t.err == nil {
+		t.err = ErrParquetFormat
+	}
+	t.pos = len(t.data)
+}
+
+func (t *thriftReader) byte() byte {
+	if t.pos >= len(t.data) {
+		t.fail()
+		return 0
+	}
+	b := t.data[t.pos]
+	t.pos++
+	return b
+}
+
+func (t *thriftReader) uvarint() uint64 {
+	v, size := binary.Uvarint(t.data[t.pos:])
+	if size <= 0 {
+		t.fail()
+		return 0
+	}
+	t.pos += size
+	return v
+}
+
+func (t *thriftReader) varint() int64 {
+	v := t.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (t *thriftReader) readStruct() thriftStruct {
+	s := make(thriftStruct)
+	// Batas kedalaman mencegah rekursi tanpa akhir pada data rusak
+	if t.depth++; t.depth > 64 {
+		t.fail()
+	}
+	defer func() { t.depth-- }()
+	var id int16
+	for t.err == nil {
+		header := t.byte()
+		kind := header & 0x0f
+		if kind == 0 {
+			break
+		}
+		if delta := header >> 4; delta != 0 {
+			id += int16(delta)
+		} else {
+			id = int16(t.varint())
+		}
+		switch kind {
+		case 1:
+			s[id] = true
+		case 2:
+			s[id] = false
+		default:
+			s[id] = t.readValue(kind)
+		}
+	}
+	return s
+}
+
+func (t *thriftReader) readValue(kind byte) interface{} {
+	switch kind {
+	case 1, 2:
+		// Elemen boolean pada list ditulis satu byte
+		return t.byte() == 1
+	case 3:
+		return int64(int8(t.byte()))
+	case 4, 5, 6:
+		return t.varint()
+	case 7:
+		if t.pos+8 > len(t.data) {
+			t.fail()
+			return 0.0
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(t.data[t.pos:]))
+		t.pos += 8
+		return v
+	case 8:
+		length := t.uvarint()
+		if length > uint64(len(t.data)-t.pos) {
+			t.fail()
+			return []byte(nil)
+		}
+		b := t.data[t.pos : t.pos+int(length)]
+		t.pos += int(length)
+		return b
+	case 9, 10:
+		header := t.byte()
+		size := uint64(header >> 4)
+		if size == 15 {
+			size = t.uvarint()
+		}
+		if size > uint64(len(t.data)-t.pos) {
+			t.fail()
+			return []interface{}(nil)
+		}
+		list := make([]interface{}, 0, size)
+		for i := uint64(0); i < size && t.err == nil; i++ {
+			list = append(list, t.readValue(header&0x0f))
+		}
+		return list
+	case 11:
+		size := t.uvarint()
+		if size > uint64(len(t.data)-t.pos) {
+			t.fail()
+			return nil
+		}
+		if size > 0 {
+			types := t.byte()
+			for i := uint64(0); i < size && t.err == nil; i++ {
+				t.readValue(types >> 4)
+				t.readValue(types & 0x0f)
+			}
+		}
+		// Map tidak dipakai pembaca ini (key_value_metadata)
+		return nil
+	case 12:
+		return t.readStruct()
+	}
+	t.fail()
+	return nil
+}
@@ -0,0 +1,368 @@
+package xlsx2sql
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+)
+
+// thriftField adalah satu field struct Thrift untuk encodeThrift: int64,
+// bool, string, []byte, thriftFields (struct) atau []interface{} (list).
+type thriftField struct {
+	id    int16
+	value interface{}
+}
+
+type thriftFields []thriftField
+
+func thriftKind(value interface{}) byte {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return 1
+		}
+		return 2
+	case int64:
+		return 6
+	case string, []byte:
+		return 8
+	case []interface{}:
+		return 9
+	case thriftFields:
+		return 12
+	}
+	panic("tipe Thrift tidak dikenal")
+}
+
+func zigzag(v int64) uint64 {
+	return uint64(v<<1) ^ uint64(v>>63)
+}
+
+// encodeThrift menulis struct dengan Thrift compact protocol; field harus
+// urut menurut id.
+func encodeThrift(b []byte, fields thriftFields) []byte {
+	var last int16
+	for _, field := range fields {
+		kind := thriftKind(field.value)
+		if delta := field.id - last; delta > 0 && delta <= 15 {
+			b = append(b, byte(delta)<<4|kind)
+		} else {
+			b = binary.AppendUvarint(append(b, kind), zigzag(int64(field.id)))
+		}
+		last = field.id
+		if _, ok := field.value.(bool); !ok {
+			b = encodeThriftValue(b, field.value)
+		}
+	}
+	return append(b, 0)
+}
+
+func encodeThriftValue(b []byte, value interface{}) []byte {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return append(b, 1)
+		}
+		return append(b, 2)
+	case int64:
+		return binary.AppendUvarint(b, zigzag(v))
+	case string:
+		return append(binary.AppendUvarint(b, uint64(len(v))), v...)
+	case []byte:
+		return append(binary.AppendUvarint(b, uint64(len(v))), v...)
+	case []interface{}:
+		kind := byte(6)
+		if len(v) > 0 {
+			kind = thriftKind(v[0])
+		}
+		if len(v) < 15 {
+			b = append(b, byte(len(v))<<4|kind)
+		} else {
+			b = binary.AppendUvarint(append(b, 0xF0|kind), uint64(len(v)))
+		}
+		for _, element := range v {
+			b = encodeThriftValue(b, element)
+		}
+		return b
+	case thriftFields:
+		return encodeThrift(b, v)
+	}
+	panic("tipe Thrift tidak dikenal")
+}
+
+// testParquetColumn adalah kolom file Parquet uji. Nilai nil berarti NULL
+// dan hanya boleh ada pada kolom optional.
+type testParquetColumn struct {
+	name       string
+	physical   int64
+	optional   bool
+	str        bool
+	dictionary bool
+	values     []interface{}
+}
+
+// testParquetOptions menentukan cara file Parquet uji ditulis.
+type testParquetOptions struct {
+	codec     int64
+	pageV2    bool
+	groupRows int
+}
+
+// plainValues menulis nilai bukan NULL dengan encoding PLAIN.
+func plainValues(physical int64, values []interface{}) []byte {
+	var b []byte
+	for _, value := range values {
+		switch physical {
+		case parquetInt64:
+			b = binary.LittleEndian.AppendUint64(b, uint64(value.(int64)))
+		case parquetDouble:
+			b = binary.LittleEndian.AppendUint64(b, math.Float64bits(value.(float64)))
+		case parquetByteArray:
+			b = binary.LittleEndian.AppendUint32(b, uint32(len(value.(string))))
+			b = append(b, value.(string)...)
+		}
+	}
+	return b
+}
+
+// bitPackedLevels menulis definition level lebar 1 bit dengan bit-packing
+// hybrid: kelompok 8 nilai per byte.
+func bitPackedLevels(values []interface{}) []byte {
+	groups := (len(values) + 7) / 8
+	b := binary.AppendUvarint(nil, uint64(groups<<1|1))
+	packed := make([]byte, groups)
+	for i, value := range values {
+		if value != nil {
+			packed[i/8] |= 1 << (i % 8)
+		}
+	}
+	return append(b, packed...)
+}
+
+func compressPage(t testing.TB, codec int64, page []byte) []byte {
+	switch codec {
+	case 1:
+		return snappy.Encode(nil, page)
+	case 2:
+		var b bytes.Buffer
+		zw := gzip.NewWriter(&b)
+		zw.Write(page)
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		return b.Bytes()
+	}
+	return page
+}
+
+// pageHeader menulis PageHeader diikuti isi halaman.
+func pageHeader(kind, uncompressed int64, page []byte, header thriftField) []byte {
+	b := encodeThrift(nil, thriftFields{{1, kind}, {2, uncompressed}, {3, int64(len(page))}, header})
+	return append(b, page...)
+}
+
+// buildParquet menulis file Parquet dengan skema datar columns, satu halaman
+// data per kolom per row group.
+func buildParquet(t testing.TB, columns []testParquetColumn, options testParquetOptions) []byte {
+	rows := len(columns[0].values)
+	groupRows := options.groupRows
+	if groupRows <= 0 {
+		groupRows = rows
+	}
+	file := []byte("PAR1")
+	var groups []interface{}
+	for first := 0; first < rows; first += groupRows {
+		last := min(first+groupRows, rows)
+		var chunks []interface{}
+		for _, column := range columns {
+			values := column.values[first:last]
+			var present []interface{}
+			for _, value := range values {
+				if value != nil {
+					present = append(present, value)
+				}
+			}
+			start := int64(len(file))
+			var chunk []byte
+			meta := thriftFields{{1, column.physical}, {2, []interface{}{int64(encodingPlain)}}, {3, []interface{}{column.name}}, {4, options.codec}, {5, int64(len(values))}}
+
+			var body []byte
+			encoding := int64(encodingPlain)
+			if column.dictionary {
+				// Dictionary berisi nilai unik sesuai urutan kemunculan;
+				// indeks ditulis sebagai run RLE sepanjang satu nilai
+				index := make(map[interface{}]int)
+				var dict []interface{}
+				for _, value := range present {
+					if _, ok := index[value]; !ok {
+						index[value] = len(dict)
+						dict = append(dict, value)
+					}
+				}
+				page := plainValues(column.physical, dict)
+				chunk = append(chunk, pageHeader(pageDictionary, int64(len(page)), compressPage(t, options.codec, page),
+					thriftField{7, thriftFields{{1, int64(len(dict))}, {2, int64(encodingPlain)}}})...)
+				body = []byte{8}
+				for _, value := range present {
+					body = binary.AppendUvarint(body, 1<<1)
+					body = append(body, byte(index[value]))
+				}
+				encoding = encodingRLEDictionary
+			} else {
+				body = plainValues(column.physical, present)
+			}
+
+			dataOffset := start + int64(len(chunk))
+			var levels []byte
+			if column.optional {
+				levels = bitPackedLevels(values)
+			}
+			if options.pageV2 {
+				page := append(append([]byte{}, levels...), compressPage(t, options.codec, body)...)
+				chunk = append(chunk, pageHeader(pageDataV2, int64(len(levels)+len(body)), page,
+					thriftField{8, thriftFields{{1, int64(len(values))}, {2, int64(len(values) - len(present))}, {3, int64(len(values))}, {4, encoding}, {5, int64(len(levels))}, {6, int64(0)}}})...)
+			} else {
+				var page []byte
+				if column.optional {
+					page = binary.LittleEndian.AppendUint32(nil, uint32(len(levels)))
+					page = append(page, levels...)
+				}
+				page = append(page, body...)
+				chunk = append(chunk, pageHeader(pageData, int64(len(page)), compressPage(t, options.codec, page),
+					thriftField{5, thriftFields{{1, int64(len(values))}, {2, encoding}, {3, int64(encodingRLE)}, {4, int64(encodingRLE)}}})...)
+			}
+
+			meta = append(meta, thriftField{6, int64(len(chunk))}, thriftField{7, int64(len(chunk))}, thriftField{9, dataOffset})
+			if column.dictionary {
+				meta = append(meta, thriftField{11, start})
+			}
+			file = append(file, chunk...)
+			chunks = append(chunks, thriftFields{{2, start}, {3, meta}})
+		}
+		groups = append(groups, thriftFields{{1, chunks}, {2, int64(0)}, {3, int64(last - first)}})
+	}
+
+	schema := []interface{}{thriftFields{{4, "schema"}, {5, int64(len(columns))}}}
+	for _, column := range columns {
+		repetition := int64(0)
+		if column.optional {
+			repetition = 1
+		}
+		element := thriftFields{{1, column.physical}, {3, repetition}, {4, column.name}}
+		if column.str {
+			element = append(element, thriftField{10, thriftFields{{1, thriftFields{}}}})
+		}
+		schema = append(schema, element)
+	}
+	footer := encodeThrift(nil, thriftFields{{1, int64(1)}, {2, schema}, {3, int64(rows)}, {4, groups}})
+	file = append(file, footer...)
+	file = binary.LittleEndian.AppendUint32(file, uint32(len(footer)))
+	return append(file, "PAR1"...)
+}
+
+// testParquetColumns adalah kolom teks dengan NULL, bilangan bulat, teks
+// berdictionary dan pecahan dengan NULL.
+func testParquetColumns() []testParquetColumn {
+	return []testParquetColumn{
+		{name: "nama", physical: parquetByteArray, optional: true, str: true, values: []interface{}{"apel", nil, "jeruk", "mangga", nil}},
+		{name: "jumlah", physical: parquetInt64, values: []interface{}{int64(10), int64(-3), int64(0), int64(1) << 40, int64(7)}},
+		{name: "kota", physical: parquetByteArray, str: true, dictionary: true, values: []interface{}{"Bandung", "Jakarta", "Bandung", "Bandung", "Medan"}},
+		{name: "harga", physical: parquetDouble, optional: true, dictionary: true, values: []interface{}{1.5, 2.25, nil, 1.5, nil}},
+	}
+}
+
+func readParquetFile(t testing.TB, data []byte) ([]string, [][]string, error) {
+	path := filepath.Join(t.TempDir(), "data.parquet")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := newParquetReader(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer r.Close()
+	var rows [][]string
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return r.Types(), rows, nil
+		}
+		if err != nil {
+			return nil, rows, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestParquetReader(t *testing.T) {
+	want := [][]string{
+		{"nama", "jumlah", "kota", "harga"},
+		{"apel", "10", "Bandung", "1.5"},
+		{"", "-3", "Jakarta", "2.25"},
+		{"jeruk", "0", "Bandung", ""},
+		{"mangga", "1099511627776", "Bandung", "1.5"},
+		{"", "7", "Medan", ""},
+	}
+	wantTypes := []string{"", "BIGINT", "", "DOUBLE"}
+	tests := []struct {
+		name    string
+		options testParquetOptions
+	}{
+		{"tanpa kompresi", testParquetOptions{}},
+		{"snappy", testParquetOptions{codec: 1}},
+		{"gzip", testParquetOptions{codec: 2}},
+		{"halaman v2", testParquetOptions{pageV2: true}},
+		{"halaman v2 snappy", testParquetOptions{codec: 1, pageV2: true}},
+		{"beberapa row group", testParquetOptions{codec: 1, groupRows: 2}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			types, rows, err := readParquetFile(t, buildParquet(t, testParquetColumns(), test.options))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(types, wantTypes) {
+				t.Errorf("Types = %q, ingin %q", types, wantTypes)
+			}
+			if !reflect.DeepEqual(rows, want) {
+				t.Errorf("rows = %q, ingin %q", rows, want)
+			}
+		})
+	}
+}
+
+func TestParquetInvalid(t *testing.T) {
+	valid := buildParquet(t, testParquetColumns(), testParquetOptions{})
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"terlalu pendek", []byte("PAR1PAR1")},
+		{"tanpa penanda", append(append([]byte{}, valid[:len(valid)-4]...), "PAR2"...)},
+		{"panjang footer", append(append([]byte{}, valid[:len(valid)-8]...), 0xFF, 0xFF, 0xFF, 0x7F, 'P', 'A', 'R', '1')},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, _, err := readParquetFile(t, test.data); !errors.Is(err, ErrParquetFormat) {
+				t.Errorf("err = %v, ingin %v", err, ErrParquetFormat)
+			}
+		})
+	}
+}
+
+func FuzzParquet(f *testing.F) {
+	f.Add(buildParquet(f, testParquetColumns(), testParquetOptions{}))
+	f.Add(buildParquet(f, testParquetColumns(), testParquetOptions{codec: 1, pageV2: true, groupRows: 2}))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		readParquetFile(t, data)
+	})
+}
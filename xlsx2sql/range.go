@@ -0,0 +1,114 @@
+package xlsx2sql
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrRange dikembalikan bila Options.Range bukan rentang sel yang valid
+// maupun nama yang terdefinisi pada workbook.
+var ErrRange = errors.New("xlsx2sql: rentang sel tidak valid")
+
+// cellRange adalah rentang sel persegi, dengan baris dan kolom mulai 1.
+// sheet kosong berarti sheet yang dipilih Options.Sheet.
+type cellRange struct {
+	sheet              string
+	firstRow, firstCol int
+	lastRow, lastCol   int
+}
+
+// parseRange mengurai rentang sel seperti B3:F100, $B$3:$F$100 atau
+// 'Data Q1'!B3:F100. Satu sel saja berarti rentang satu sel.
+func parseRange(ref string) (cellRange, error) {
+	var area cellRange
+	ref = strings.TrimPrefix(strings.TrimSpace(ref), "=")
+	if i := strings.LastIndexByte(ref, '!'); i >= 0 {
+		area.sheet = ref[:i]
+		if len(area.sheet) >= 2 && strings.HasPrefix(area.sheet, "'") && strings.HasSuffix(area.sheet, "'") {
+			area.sheet = strings.ReplaceAll(area.sheet[1:len(area.sheet)-1], "''", "'")
+		}
+		ref = ref[i+1:]
+	}
+	first, last, ok := strings.Cut(strings.ReplaceAll(ref, "$", ""), ":")
+	if !ok {
+		last = first
+	}
+	var err error
+	if area.firstCol, area.firstRow, err = excelize.CellNameToCoordinates(first); err != nil {
+		return area, fmt.Errorf("%w: %q", ErrRange, ref)
+	}
+	if area.lastCol, area.lastRow, err = excelize.CellNameToCoordinates(last); err != nil {
+		return area, fmt.Errorf("%w: %q", ErrRange, ref)
+	}
+	if area.lastRow < area.firstRow || area.lastCol < area.firstCol {
+		return area, fmt.Errorf("%w: %q", ErrRange, ref)
+	}
+	return area, nil
+}
+
+// workbookRange mengembalikan rentang name pada workbook xlsx: nama yang
+// terdefinisi (lingkup sheet lebih dulu, lalu lingkup workbook) atau rentang
+// sel biasa. Nama dengan beberapa area tidak didukung.
+func workbookRange(xlsx *excelize.File, name, sheet string) (cellRange, error) {
+	var refersTo string
+	for _, defined := range xlsx.GetDefinedName() {
+		if !strings.EqualFold(defined.Name, name) {
+			continue
+		}
+		if defined.Scope == sheet {
+			refersTo = defined.RefersTo
+			break
+		}
+		if defined.Scope == "" || defined.Scope == "Workbook" {
+			refersTo = defined.RefersTo
+		}
+	}
+	if refersTo == "" {
+		return parseRange(name)
+	}
+	if strings.Contains(refersTo, ",") {
+		return cellRange{}, fmt.Errorf("%w: nama %s berisi beberapa area (%s)", ErrRange, name, refersTo)
+	}
+	area, err := parseRange(refersTo)
+	if err != nil {
+		return area, fmt.Errorf("%w: nama %s merujuk %s", ErrRange, name, refersTo)
+	}
+	return area, nil
+}
+
+// rangeReader membatasi baris r pada rentang area: baris di atasnya dibuang,
+// pembacaan berakhir setelah baris terakhirnya dan hanya kolom di dalam
+// rentang yang dikembalikan.
+type rangeReader struct {
+	RowReader
+	area cellRange
+
+	// row adalah jumlah baris r yang sudah dibaca
+	row int
+}
+
+func (r *rangeReader) Read() ([]string, error) {
+	for r.row < r.area.firstRow-1 {
+		if _, err := r.RowReader.Read(); err != nil {
+			return nil, err
+		}
+		r.row++
+	}
+	if r.row >= r.area.lastRow {
+		return nil, io.EOF
+	}
+	row, err := r.RowReader.Read()
+	if err != nil {
+		return nil, err
+	}
+	r.row++
+	last := min(r.area.lastCol, len(row))
+	if first := r.area.firstCol - 1; first < last {
+		return row[first:last], nil
+	}
+	return []string{}, nil
+}
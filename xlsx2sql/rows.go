@@ -0,0 +1,334 @@
+package xlsx2sql
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// RowReader membaca baris sheet satu per satu. Read mengembalikan io.EOF
+// setelah baris terakhir.
+type RowReader interface {
+	Read() ([]string, error)
+	Close() error
+}
+
+// TypedReader adalah RowReader yang mengetahui tipe kolom dari skema input,
+// misalnya Parquet. Types mengembalikan tipe MariaDB kolom-kolom header; tipe
+// kosong berarti tipe kolom tersebut ditentukan dengan inferensi.
+type TypedReader interface {
+	RowReader
+	Types() []string
+}
+
+// OpenFile membuka path sebagai xlsx, xls, ODS, CSV, JSON, JSON Lines, Parquet
+// atau XML dan mengembalikan RowReader, dibatasi pada opts.Range bila diisi,
+// yang dimulai dari baris opts.HeaderRow. Format ditentukan dari ekstensi
+// file bila opts.Format kosong. Baris xlsx dibaca dengan iterator Rows()
+// excelize sehingga sheet tidak dimuat utuh ke memori.
+func OpenFile(path string, opts Options) (RowReader, error) {
+	format := opts.Format
+	if format == "" {
+		format = "xlsx"
+		switch ext := filepath.Ext(path); {
+		case strings.EqualFold(ext, ".csv"):
+			format = "csv"
+		case strings.EqualFold(ext, ".ods"):
+			format = "ods"
+		case strings.EqualFold(ext, ".xls"):
+			format = "xls"
+		case strings.EqualFold(ext, ".json"):
+			format = "json"
+		case strings.EqualFold(ext, ".jsonl"), strings.EqualFold(ext, ".ndjson"):
+			format = "jsonl"
+		case strings.EqualFold(ext, ".parquet"):
+			format = "parquet"
+		case strings.EqualFold(ext, ".xml"):
+			format = "xml"
+		}
+	}
+
+	// Rentang Options.Range; pada xlsx nama yang terdefinisi diperiksa lebih
+	// dulu di bawah
+	var area *cellRange
+	sheetName := opts.Sheet
+	if opts.Range != "" {
+		if a, err := parseRange(opts.Range); err == nil {
+			area = &a
+			if a.sheet != "" {
+				sheetName = a.sheet
+			}
+		}
+	}
+
+	var r RowReader
+	switch {
+	case strings.EqualFold(format, "csv"):
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		r = newCSVReader(file, file)
+	case strings.EqualFold(format, "ods"):
+		var err error
+		if r, err = newODSReader(path, sheetName); err != nil {
+			return nil, err
+		}
+	case strings.EqualFold(format, "xls"):
+		var err error
+		if r, err = newXLSReader(path, sheetName); err != nil {
+			return nil, err
+		}
+	case strings.EqualFold(format, "parquet"):
+		var err error
+		if r, err = newParquetReader(path); err != nil {
+			return nil, err
+		}
+	case strings.EqualFold(format, "xml"):
+		var err error
+		if r, err = newXMLReader(path, opts.XMLRows); err != nil {
+			return nil, err
+		}
+	case strings.EqualFold(format, "json"), strings.EqualFold(format, "jsonl"):
+		var err error
+		if r, err = newJSONReader(path, strings.EqualFold(format, "jsonl")); err != nil {
+			return nil, err
+		}
+	default:
+		xlsx, err := excelize.OpenFile(path, opts.Excelize)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Range != "" {
+			scope := opts.Sheet
+			if scope == "" {
+				scope = xlsx.GetSheetName(xlsx.GetActiveSheetIndex())
+			}
+			a, err := workbookRange(xlsx, opts.Range, scope)
+			if err != nil {
+				xlsx.Close()
+				return nil, err
+			}
+			area = &a
+			if a.sheet != "" {
+				sheetName = a.sheet
+			}
+		}
+		sheet, err := newSheetReader(xlsx, sheetName)
+		if err != nil {
+			xlsx.Close()
+			return nil, err
+		}
+		if opts.NumberFormats {
+			// Tanpa akses ke arsip zip, misalnya pada workbook terenkripsi,
+			// nilai tetap berupa teks yang ditampilkan
+			sheet.formats, _ = openCellFormats(xlsx, path, sheet.sheet)
+		}
+		if opts.FillMerged {
+			if sheet.merged, err = newMergedCells(xlsx, sheet.sheet); err != nil {
+				sheet.Close()
+				return nil, &SheetError{Sheet: sheet.sheet, Err: err}
+			}
+		}
+		r = sheet
+	}
+
+	if opts.Range != "" {
+		if area == nil {
+			r.Close()
+			return nil, fmt.Errorf("%w: %q", ErrRange, opts.Range)
+		}
+		r = &rangeReader{RowReader: r, area: *area}
+	}
+
+	// Baris di atas baris header diabaikan
+	for i := 1; i < opts.HeaderRow; i++ {
+		if _, err := r.Read(); err == io.EOF {
+			break
+		} else if err != nil {
+			r.Close()
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// csvReader membaca CSV dengan pemisah koma atau titik koma, ditentukan dari
+// baris pertama. BOM UTF-8 di awal file dibuang.
+type csvReader struct {
+	r      *csv.Reader
+	closer io.Closer
+}
+
+func newCSVReader(r io.Reader, closer io.Closer) *csvReader {
+	in := bufio.NewReaderSize(r, 64<<10)
+	if bom, _ := in.Peek(3); bytes.Equal(bom, []byte("\xef\xbb\xbf")) {
+		in.Discard(3)
+	}
+	firstLine, _ := in.Peek(in.Size())
+	if i := bytes.IndexByte(firstLine, '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+
+	cr := csv.NewReader(in)
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		cr.Comma = ';'
+	}
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	return &csvReader{r: cr, closer: closer}
+}
+
+func (r *csvReader) Read() ([]string, error) {
+	return r.r.Read()
+}
+
+func (r *csvReader) Close() error {
+	if r.closer == nil {
+		return nil
+	}
+	return r.closer.Close()
+}
+
+// sheetReader membaca baris sheet xlsx dengan excelize.Rows. Baris kosong di
+// akhir sheet dibuang seperti pada GetRows.
+type sheetReader struct {
+	xlsx  *excelize.File
+	rows  *excelize.Rows
+	sheet string
+
+	// formats, bila tidak nil, mengganti nilai sel berformat angka dengan
+	// nilai mentahnya (Options.NumberFormats); row adalah nomor baris sheet
+	// terakhir dari rows.Next
+	formats *cellFormats
+	row     int
+
+	// merged, bila tidak nil, mengisi sel merged cell (Options.FillMerged)
+	merged *mergedCells
+
+	// empty adalah jumlah baris kosong yang belum dikembalikan, next adalah
+	// baris tidak kosong sesudahnya
+	empty int
+	next  []string
+}
+
+func newSheetReader(xlsx *excelize.File, sheetName string) (*sheetReader, error) {
+	if sheetName == "" {
+		sheetName = xlsx.GetSheetName(xlsx.GetActiveSheetIndex())
+	}
+	rows, err := xlsx.Rows(sheetName)
+	if err != nil {
+		return nil, &SheetError{Sheet: sheetName, Err: err}
+	}
+	return &sheetReader{xlsx: xlsx, rows: rows, sheet: sheetName}, nil
+}
+
+func (r *sheetReader) Read() ([]string, error) {
+	if r.next != nil {
+		if r.empty > 0 {
+			r.empty--
+			return []string{}, nil
+		}
+		row := r.next
+		r.next = nil
+		return row, nil
+	}
+	for r.rows.Next() {
+		r.row++
+		row, err := r.rows.Columns()
+		if err != nil {
+			return nil, &SheetError{Sheet: r.sheet, Err: err}
+		}
+		if r.formats != nil {
+			if row, err = r.formats.apply(r.row, row); err != nil {
+				return nil, &SheetError{Sheet: r.sheet, Err: err}
+			}
+		}
+		if r.merged != nil {
+			row = r.merged.fill(r.row, row)
+		}
+		if len(row) == 0 {
+			r.empty++
+			continue
+		}
+		if r.empty > 0 {
+			r.next = row
+			r.empty--
+			return []string{}, nil
+		}
+		return row, nil
+	}
+	if err := r.rows.Error(); err != nil {
+		return nil, &SheetError{Sheet: r.sheet, Err: err}
+	}
+	return nil, io.EOF
+}
+
+// Close menutup iterator dan file, termasuk menghapus file sementara yang
+// dibuat excelize untuk bagian XML berukuran besar.
+func (r *sheetReader) Close() error {
+	err := r.rows.Close()
+	if r.formats != nil {
+		r.formats.Close()
+	}
+	if closeErr := r.xlsx.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// sliceReader adalah RowReader atas baris yang sudah ada di memori.
+type sliceReader struct {
+	rows [][]string
+}
+
+func (r *sliceReader) Read() ([]string, error) {
+	if len(r.rows) == 0 {
+		return nil, io.EOF
+	}
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+	return row, nil
+}
+
+func (r *sliceReader) Close() error {
+	return nil
+}
+
+// ReadAll membaca semua baris yang tersisa pada r.
+func ReadAll(r RowReader) ([][]string, error) {
+	var rows [][]string
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+// ReadFile membaca semua baris file path mulai dari baris opts.HeaderRow.
+func ReadFile(path string, opts Options) ([][]string, error) {
+	r, err := OpenFile(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ReadAll(r)
+}
+
+// ReadCSV mengurai isi file CSV dengan pemisah koma atau titik koma,
+// ditentukan dari baris pertama. BOM UTF-8 di awal file dibuang.
+func ReadCSV(content []byte) ([][]string, error) {
+	return ReadAll(newCSVReader(bytes.NewReader(content), nil))
+}
@@ -0,0 +1,80 @@
+package xlsx2sql
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ExcelTable adalah Excel Table (ListObject) pada sheet xlsx, yaitu rentang
+// berformat tabel yang diberi nama pada Excel.
+type ExcelTable struct {
+	Name  string
+	Sheet string
+
+	// Range adalah rentang sel tabel tanpa baris total, misalnya B3:F100,
+	// untuk Options.Range. Baris pertamanya adalah header bila Header.
+	Range string
+
+	// Header false berarti baris header tabel disembunyikan sehingga Range
+	// langsung berisi data; nama kolomnya ada pada Columns
+	Header bool
+
+	// Columns adalah nama kolom tabel menurut definisi tabel
+	Columns []string
+}
+
+// tablePart adalah bagian definisi tabel xl/tables/tableN.xml yang tidak
+// dikembalikan excelize GetTables.
+type tablePart struct {
+	Name           string `xml:"name,attr"`
+	HeaderRowCount *int   `xml:"headerRowCount,attr"`
+	TotalsRowCount int    `xml:"totalsRowCount,attr"`
+	Columns        []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"tableColumns>tableColumn"`
+}
+
+// ExcelTables mengembalikan Excel Table pada sheet workbook xlsx sesuai
+// urutan pada sheet, nil bila sheet tidak memiliki tabel. Tabel dengan
+// rentang yang tidak valid dilewati.
+func ExcelTables(xlsx *excelize.File, sheet string) ([]ExcelTable, error) {
+	tables, err := xlsx.GetTables(sheet)
+	if err != nil || len(tables) == 0 {
+		return nil, err
+	}
+	parts := make(map[string]tablePart)
+	xlsx.Pkg.Range(func(name, content interface{}) bool {
+		if name, _ := name.(string); !strings.HasPrefix(name, "xl/tables/") || !strings.HasSuffix(name, ".xml") {
+			return true
+		}
+		var part tablePart
+		if data, ok := content.([]byte); ok && xml.Unmarshal(data, &part) == nil {
+			parts[part.Name] = part
+		}
+		return true
+	})
+	var result []ExcelTable
+	for _, table := range tables {
+		area, err := parseRange(table.Range)
+		if err != nil {
+			continue
+		}
+		part := parts[table.Name]
+		area.lastRow -= part.TotalsRowCount
+		first, _ := excelize.CoordinatesToCellName(area.firstCol, area.firstRow)
+		last, _ := excelize.CoordinatesToCellName(area.lastCol, max(area.lastRow, area.firstRow))
+		excelTable := ExcelTable{
+			Name:   table.Name,
+			Sheet:  sheet,
+			Range:  first + ":" + last,
+			Header: part.HeaderRowCount == nil || *part.HeaderRowCount > 0,
+		}
+		for _, column := range part.Columns {
+			excelTable.Columns = append(excelTable.Columns, column.Name)
+		}
+		result = append(result, excelTable)
+	}
+	return result, nil
+}
@@ -0,0 +1,106 @@
+// Package winpath menangani path Windows: batas panjang path (awalan \\?\),
+// path yang relatif terhadap drive seperti C:data\laporan.xlsx, dan
+// perbandingan nama file yang tidak membedakan huruf besar/kecil. Di sistem
+// operasi lain path dikembalikan apa adanya dan perbandingan tetap
+// membedakan huruf besar/kecil.
+package winpath
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// maxPath adalah panjang path Windows sebelum awalan \\?\ diperlukan. Batasnya
+// 260 karakter, dikurangi 12 karena pembuatan direktori menyisakan ruang
+// untuk nama 8.3.
+const maxPath = 248
+
+const (
+	longPrefix = `\\?\`
+	uncPrefix  = `\\?\UNC\`
+)
+
+var isWindows = runtime.GOOS == "windows"
+
+// Resolve mengubah path menjadi path absolut. Path relatif dihitung dari
+// base (direktori kerja bila base kosong). Di Windows, path dengan huruf drive
+// tanpa "\" (C:data) atau diawali "\" tanpa huruf drive tidak digabung dengan
+// base melainkan dihitung dari direktori kerja drive tersebut, seperti di
+// Command Prompt. Path yang panjang diberi awalan \\?\, lihat Long.
+func Resolve(base, path string) (string, error) {
+	if !filepath.IsAbs(path) && base != "" && !driveRelative(path) {
+		path = filepath.Join(base, path)
+	}
+	abs, err := filepath.Abs(Short(path))
+	if err != nil {
+		return "", err
+	}
+	return Long(abs), nil
+}
+
+// driveRelative mengembalikan true untuk path Windows yang tidak absolut tetapi
+// juga tidak relatif terhadap direktori kerja, yaitu C:data atau \data.
+func driveRelative(path string) bool {
+	if !isWindows || filepath.IsAbs(path) {
+		return false
+	}
+	return filepath.VolumeName(path) != "" || strings.HasPrefix(path, `\`) || strings.HasPrefix(path, "/")
+}
+
+// Long memberi awalan \\?\ (atau \\?\UNC\ untuk share jaringan \\server\share)
+// pada path absolut Windows yang melewati batas MAX_PATH, agar file di
+// direktori yang sangat dalam tetap dapat dibuka. Path yang pendek, relatif
+// atau sudah berawalan dikembalikan apa adanya.
+func Long(path string) string {
+	if !isWindows || len(path) < maxPath || strings.HasPrefix(path, longPrefix) || !filepath.IsAbs(path) {
+		return path
+	}
+	if strings.HasPrefix(path, `\\`) {
+		return uncPrefix + path[2:]
+	}
+	return longPrefix + path
+}
+
+// Short membuang awalan \\?\ dari path untuk ditampilkan ke pengguna atau
+// diolah lebih lanjut dengan package path/filepath.
+func Short(path string) string {
+	switch {
+	case strings.HasPrefix(path, uncPrefix):
+		return `\\` + path[len(uncPrefix):]
+	case strings.HasPrefix(path, longPrefix):
+		return path[len(longPrefix):]
+	}
+	return path
+}
+
+// Key mengembalikan kunci pembanding path. Di Windows huruf besar/kecil dan
+// awalan \\?\ diabaikan, sehingga Data.xlsx dan DATA.XLSX dianggap file yang
+// sama.
+func Key(path string) string {
+	if !isWindows {
+		return path
+	}
+	return strings.ToLower(Short(path))
+}
+
+// Equal mengembalikan true bila a dan b menunjuk file yang sama menurut Key.
+func Equal(a, b string) bool {
+	return Key(a) == Key(b)
+}
+
+// Dedup membuang path yang sama menurut Key, dengan mempertahankan kemunculan
+// pertama. Path yang dibuang dikembalikan sebagai duplicates.
+func Dedup(paths []string) (unique, duplicates []string) {
+	seen := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		key := Key(path)
+		if seen[key] {
+			duplicates = append(duplicates, path)
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, path)
+	}
+	return unique, duplicates
+}
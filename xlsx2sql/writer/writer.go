@@ -0,0 +1,332 @@
+// Package writer menulis baris sheet sebagai pernyataan INSERT INTO ... VALUES
+// yang dipecah per batch, sebagai teks berbatas untuk cpimport, atau sebagai
+// NDJSON.
+package writer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/ddl"
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/inference"
+)
+
+// DefaultBatchRows adalah jumlah tuple per pernyataan INSERT bila BatchRows 0.
+const DefaultBatchRows = 1000000
+
+// InsertWriter menulis baris ke w sebagai satu atau beberapa pernyataan
+// INSERT. Kesalahan tulis pertama disimpan dan dikembalikan oleh Close.
+type InsertWriter struct {
+	// BatchRows adalah jumlah tuple per pernyataan INSERT
+	BatchRows int
+
+	// Encrypted berisi true pada posisi kolom yang nilainya disimpan
+	// terenkripsi: literal selain NULL ditulis sebagai
+	// AES_ENCRYPT(literal, @xlsx2sql_key) (ddl.KeyVariable)
+	Encrypted []bool
+
+	w       io.Writer
+	table   string
+	columns []string
+	types   []string
+	rows    int
+	err     error
+}
+
+// New membuat InsertWriter untuk tabel table. columns berisi nama kolom yang
+// sudah disanitasi dan types tipe kolom pada posisi yang sama.
+func New(w io.Writer, table string, columns, types []string) *InsertWriter {
+	return &InsertWriter{BatchRows: DefaultBatchRows, w: w, table: table, columns: columns, types: types}
+}
+
+func (iw *InsertWriter) write(s string) {
+	if iw.err == nil {
+		_, iw.err = io.WriteString(iw.w, s)
+	}
+}
+
+// WriteRow menulis satu tuple. Sel yang tidak ada pada row ditulis NULL.
+func (iw *InsertWriter) WriteRow(row []string) error {
+	batch := iw.BatchRows
+	if batch <= 0 {
+		batch = DefaultBatchRows
+	}
+	if iw.rows%batch == 0 {
+		if iw.rows > 0 {
+			iw.write(";\n")
+		}
+		iw.write(fmt.Sprintf("INSERT INTO %s (%s) VALUES\n", iw.table, strings.Join(iw.columns, ", ")))
+	} else {
+		iw.write(",\n")
+	}
+
+	var b strings.Builder
+	b.WriteString("(")
+	for j := range iw.columns {
+		if j > 0 {
+			b.WriteString(", ")
+		}
+		if j < len(row) {
+			value := FormatValue(row[j], iw.types[j])
+			if j < len(iw.Encrypted) && iw.Encrypted[j] && value != "NULL" {
+				value = fmt.Sprintf("AES_ENCRYPT(%s, %s)", value, ddl.KeyVariable)
+			}
+			b.WriteString(value)
+		} else {
+			b.WriteString("NULL")
+		}
+	}
+	b.WriteString(")")
+	iw.write(b.String())
+	iw.rows++
+	return iw.err
+}
+
+// Rows mengembalikan jumlah tuple yang sudah ditulis.
+func (iw *InsertWriter) Rows() int {
+	return iw.rows
+}
+
+// Close menutup pernyataan INSERT terakhir. Tidak ada yang ditulis bila
+// belum ada baris.
+func (iw *InsertWriter) Close() error {
+	if iw.rows > 0 {
+		iw.write(";")
+	}
+	return iw.err
+}
+
+// FormatValue mengubah nilai sel menjadi literal SQL sesuai tipe kolom. Sel
+// kosong serta angka, boolean dan tanggal/waktu yang tidak sesuai format
+// menjadi NULL.
+func FormatValue(cell, columnType string) string {
+	sanitizedValue := EscapeString(cell)
+
+	// Handling NULL values and data type constraints
+	if sanitizedValue == "" {
+		return "NULL"
+	}
+	switch inference.BaseType(columnType) {
+	case "INT", "BIGINT", "FLOAT", "DOUBLE", "DECIMAL":
+		// Nilai yang tidak cocok hanya mungkin ada bila inference.Engine
+		// dipakai dengan MinConfidence di bawah 1
+		number := strings.TrimSpace(sanitizedValue)
+		if _, err := strconv.ParseFloat(number, 64); err != nil {
+			return "NULL"
+		}
+		return number
+	case "BOOLEAN":
+		switch value := strings.TrimSpace(sanitizedValue); value {
+		case "true", "false", "1", "0":
+			return value
+		}
+		return "NULL"
+	case "DATE", "DATETIME", "TIMESTAMP", "TIME", "YEAR":
+		if inference.IsValidDateTime(sanitizedValue, columnType) {
+			return fmt.Sprintf("'%s'", sanitizedValue)
+		}
+		return "NULL"
+	default:
+		return fmt.Sprintf("'%s'", sanitizedValue)
+	}
+}
+
+// EscapeString meloloskan backslash dan tanda kutip agar value aman di dalam
+// literal string SQL.
+func EscapeString(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	value = strings.ReplaceAll(value, "'", "\\'")
+	value = strings.ReplaceAll(value, "\"", "\\\"")
+	return value
+}
+
+// RowWriter adalah penulis baris data: InsertWriter atau DelimitedWriter.
+type RowWriter interface {
+	WriteRow(row []string) error
+	Close() error
+}
+
+// DelimitedFormat mengatur bentuk file teks berbatas. Nilai teks dan tanggal
+// selalu diapit Enclosure.
+type DelimitedFormat struct {
+	// Delimiter memisahkan kolom
+	Delimiter byte
+
+	// Enclosure mengapit nilai teks dan tanggal
+	Enclosure byte
+
+	// Null ditulis untuk nilai NULL
+	Null string
+
+	// DoubleEnclosure meloloskan Enclosure dengan menggandakannya dan menulis
+	// baris baru apa adanya seperti CSV (RFC 4180). Bila false, backslash,
+	// Enclosure dan baris baru diloloskan dengan backslash.
+	DoubleEnclosure bool
+}
+
+var (
+	// CPImportFormat dibaca cpimport MariaDB ColumnStore dan LOAD DATA INFILE
+	CPImportFormat = DelimitedFormat{Delimiter: '|', Enclosure: '"', Null: `\N`}
+
+	// CSVFormat adalah CSV RFC 4180 untuk COPY Vertica, Redshift dan
+	// PostgreSQL. NULL berupa field kosong tanpa tanda kutip; sel kosong selalu
+	// menjadi NULL sehingga tidak tertukar dengan teks kosong.
+	CSVFormat = DelimitedFormat{Delimiter: ',', Enclosure: '"', DoubleEnclosure: true}
+)
+
+// DelimitedWriter menulis baris sebagai teks berbatas sesuai DelimitedFormat,
+// satu baris per tuple, misalnya untuk cpimport atau COPY.
+type DelimitedWriter struct {
+	DelimitedFormat
+
+	w     io.Writer
+	types []string
+	rows  int
+	err   error
+}
+
+// NewDelimited membuat DelimitedWriter dengan CPImportFormat dan tipe kolom types.
+func NewDelimited(w io.Writer, types []string) *DelimitedWriter {
+	return &DelimitedWriter{DelimitedFormat: CPImportFormat, w: w, types: types}
+}
+
+// WriteRow menulis satu tuple. Sel yang tidak ada pada row ditulis NULL.
+func (dw *DelimitedWriter) WriteRow(row []string) error {
+	if dw.err != nil {
+		return dw.err
+	}
+	var b strings.Builder
+	for j, columnType := range dw.types {
+		if j > 0 {
+			b.WriteByte(dw.Delimiter)
+		}
+		cell := ""
+		if j < len(row) {
+			cell = row[j]
+		}
+		b.WriteString(dw.formatValue(cell, columnType))
+	}
+	b.WriteByte('\n')
+	_, dw.err = io.WriteString(dw.w, b.String())
+	dw.rows++
+	return dw.err
+}
+
+// formatValue memakai aturan FormatValue, lalu mengubah literal SQL menjadi
+// field teks berbatas.
+func (dw *DelimitedWriter) formatValue(cell, columnType string) string {
+	switch literal := FormatValue(cell, columnType); {
+	case literal == "NULL":
+		return dw.Null
+	case literal == "true":
+		return "1"
+	case literal == "false":
+		return "0"
+	case strings.HasPrefix(literal, "'"):
+		enclosure := string(dw.Enclosure)
+		if dw.DoubleEnclosure {
+			return enclosure + strings.ReplaceAll(cell, enclosure, enclosure+enclosure) + enclosure
+		}
+		value := strings.NewReplacer(`\`, `\\`, enclosure, `\`+enclosure, "\n", `\n`, "\r", `\r`).Replace(cell)
+		return enclosure + value + enclosure
+	default:
+		return literal
+	}
+}
+
+// Rows mengembalikan jumlah tuple yang sudah ditulis.
+func (dw *DelimitedWriter) Rows() int {
+	return dw.rows
+}
+
+// Close mengembalikan kesalahan tulis pertama, bila ada.
+func (dw *DelimitedWriter) Close() error {
+	return dw.err
+}
+
+// JSONWriter menulis setiap baris sebagai satu objek JSON per baris (NDJSON)
+// dengan nama kolom sebagai key, misalnya untuk pipeline event.
+type JSONWriter struct {
+	w       io.Writer
+	columns []string
+	types   []string
+	rows    int
+	err     error
+}
+
+// NewJSON membuat JSONWriter. columns berisi nama kolom yang sudah
+// disanitasi dan types tipe kolom pada posisi yang sama.
+func NewJSON(w io.Writer, columns, types []string) *JSONWriter {
+	return &JSONWriter{w: w, columns: columns, types: types}
+}
+
+// WriteRow menulis satu objek. Sel yang tidak ada pada row ditulis null.
+func (jw *JSONWriter) WriteRow(row []string) error {
+	if jw.err != nil {
+		return jw.err
+	}
+	line := append(JSONRow(jw.columns, jw.types, row), '\n')
+	_, jw.err = jw.w.Write(line)
+	jw.rows++
+	return jw.err
+}
+
+// Rows mengembalikan jumlah objek yang sudah ditulis.
+func (jw *JSONWriter) Rows() int {
+	return jw.rows
+}
+
+// Close mengembalikan kesalahan tulis pertama, bila ada.
+func (jw *JSONWriter) Close() error {
+	return jw.err
+}
+
+// JSONRow membentuk objek JSON satu baris dengan urutan key sesuai columns.
+// Aturan nilai sama dengan FormatValue: nilai yang menjadi NULL ditulis null,
+// angka dan boolean tanpa tanda kutip, selain itu string.
+func JSONRow(columns, types []string, row []string) []byte {
+	b := []byte{'{'}
+	for j, column := range columns {
+		if j > 0 {
+			b = append(b, ',')
+		}
+		key, _ := json.Marshal(column)
+		b = append(b, key...)
+		b = append(b, ':')
+		cell := ""
+		if j < len(row) {
+			cell = row[j]
+		}
+		switch literal := FormatValue(cell, types[j]); {
+		case literal == "NULL":
+			b = append(b, "null"...)
+		case literal == "1" && types[j] == "BOOLEAN", literal == "true":
+			b = append(b, "true"...)
+		case literal == "0" && types[j] == "BOOLEAN", literal == "false":
+			b = append(b, "false"...)
+		case strings.HasPrefix(literal, "'"):
+			value, _ := json.Marshal(cell)
+			b = append(b, value...)
+		default:
+			b = append(b, jsonNumber(literal)...)
+		}
+	}
+	return append(b, '}')
+}
+
+// jsonNumber menulis ulang angka yang bukan angka JSON yang sah, misalnya
+// .5 atau 007; NaN dan Inf menjadi null.
+func jsonNumber(literal string) string {
+	if json.Valid([]byte(literal)) {
+		return literal
+	}
+	f, _ := strconv.ParseFloat(literal, 64)
+	value, err := json.Marshal(f)
+	if err != nil {
+		return "null"
+	}
+	return string(value)
+}
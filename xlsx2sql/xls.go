@@ -0,0 +1,521 @@
+package xlsx2sql
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/richardlehane/mscfb"
+)
+
+var (
+	// ErrXLSEncrypted dikembalikan untuk file xls yang dilindungi password.
+	// Enkripsi RC4 format lama tidak didukung; simpan ulang file tanpa
+	// password atau sebagai xlsx.
+	ErrXLSEncrypted = errors.New("xlsx2sql: file xls terenkripsi tidak didukung")
+
+	// ErrXLSVersion dikembalikan untuk file xls dari Excel 5.0/95 atau lebih
+	// lama (BIFF5), yang tidak didukung.
+	ErrXLSVersion = errors.New("xlsx2sql: format xls sebelum Excel 97 tidak didukung")
+
+	errXLSCorrupt = errors.New("xlsx2sql: record xls terpotong")
+)
+
+// Record BIFF8 yang dibaca.
+const (
+	xlsFormula    = 0x0006
+	xlsEOF        = 0x000A
+	xlsDateMode   = 0x0022
+	xlsFilePass   = 0x002F
+	xlsContinue   = 0x003C
+	xlsWindow1    = 0x003D
+	xlsBoundSheet = 0x0085
+	xlsMulRK      = 0x00BD
+	xlsXF         = 0x00E0
+	xlsSST        = 0x00FC
+	xlsLabelSST   = 0x00FD
+	xlsNumber     = 0x0203
+	xlsLabel      = 0x0204
+	xlsBoolErr    = 0x0205
+	xlsString     = 0x0207
+	xlsArray      = 0x0221
+	xlsTable      = 0x0236
+	xlsRK         = 0x027E
+	xlsShrFmla    = 0x04BC
+	xlsFormat     = 0x041E
+	xlsBOF        = 0x0809
+)
+
+// xlsRecord adalah satu record BIFF: id dan isinya.
+type xlsRecord struct {
+	id   uint16
+	data []byte
+}
+
+// xlsSheet adalah entri BOUNDSHEET: posisi BOF sheet pada stream Workbook.
+// worksheet false untuk chart dan modul VBA.
+type xlsSheet struct {
+	name      string
+	offset    uint32
+	visible   bool
+	worksheet bool
+}
+
+// xlsWorkbook berisi bagian global workbook BIFF8 yang diperlukan untuk
+// membaca sel: daftar sheet, shared strings dan format angka per XF.
+type xlsWorkbook struct {
+	stream   []byte
+	sheets   []xlsSheet
+	active   int
+	strings  []string
+	formats  map[uint16]string
+	xfs      []uint16
+	date1904 bool
+}
+
+// openXLS membaca stream Workbook file xls (BIFF8, Excel 97-2003) dari
+// compound file path dan mengurai bagian globalnya. File xls paling banyak
+// 65536 baris per sheet sehingga stream dibaca utuh ke memori.
+func openXLS(path string) (*xlsWorkbook, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	doc, err := mscfb.New(file)
+	if err != nil {
+		return nil, err
+	}
+	for entry, err := doc.Next(); err == nil; entry, err = doc.Next() {
+		switch entry.Name {
+		case "Book":
+			return nil, ErrXLSVersion
+		case "Workbook":
+			stream, err := io.ReadAll(entry)
+			if err != nil {
+				return nil, err
+			}
+			wb := &xlsWorkbook{stream: stream, formats: make(map[uint16]string)}
+			if err := wb.parseGlobals(); err != nil {
+				return nil, err
+			}
+			return wb, nil
+		}
+	}
+	return nil, errors.New("xlsx2sql: stream Workbook tidak ditemukan pada file xls")
+}
+
+// records memanggil fn untuk setiap record mulai dari offset sampai record
+// EOF. Record CONTINUE sesudah SST diberikan ke fn sebagai record terpisah.
+func (wb *xlsWorkbook) records(offset uint32, fn func(xlsRecord) error) error {
+	pos := int(offset)
+	for pos+4 <= len(wb.stream) {
+		id := binary.LittleEndian.Uint16(wb.stream[pos:])
+		size := int(binary.LittleEndian.Uint16(wb.stream[pos+2:]))
+		pos += 4
+		if pos+size > len(wb.stream) {
+			return errXLSCorrupt
+		}
+		record := xlsRecord{id: id, data: wb.stream[pos : pos+size]}
+		pos += size
+		if id == xlsEOF {
+			return nil
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return errXLSCorrupt
+}
+
+func (wb *xlsWorkbook) parseGlobals() error {
+	var sst [][]byte
+	inSST := false
+	err := wb.records(0, func(r xlsRecord) error {
+		if r.id == xlsContinue && inSST {
+			sst = append(sst, r.data)
+			return nil
+		}
+		inSST = false
+		switch r.id {
+		case xlsBOF:
+			if len(r.data) < 2 || binary.LittleEndian.Uint16(r.data) != 0x0600 {
+				return ErrXLSVersion
+			}
+		case xlsFilePass:
+			return ErrXLSEncrypted
+		case xlsDateMode:
+			wb.date1904 = len(r.data) >= 2 && binary.LittleEndian.Uint16(r.data) == 1
+		case xlsWindow1:
+			if len(r.data) >= 12 {
+				wb.active = int(binary.LittleEndian.Uint16(r.data[10:]))
+			}
+		case xlsBoundSheet:
+			if len(r.data) < 8 {
+				return errXLSCorrupt
+			}
+			s := &xlsStream{chunks: [][]byte{r.data[6:]}}
+			name, err := s.unicodeString(int(s.readByte()))
+			if err != nil {
+				return err
+			}
+			wb.sheets = append(wb.sheets, xlsSheet{
+				name:      name,
+				offset:    binary.LittleEndian.Uint32(r.data),
+				visible:   r.data[4]&0x03 == 0,
+				worksheet: r.data[5] == 0,
+			})
+		case xlsFormat:
+			if len(r.data) < 4 {
+				return errXLSCorrupt
+			}
+			s := &xlsStream{chunks: [][]byte{r.data[2:]}}
+			format, err := s.unicodeString(int(s.readUint16()))
+			if err != nil {
+				return err
+			}
+			wb.formats[binary.LittleEndian.Uint16(r.data)] = format
+		case xlsXF:
+			if len(r.data) < 4 {
+				return errXLSCorrupt
+			}
+			wb.xfs = append(wb.xfs, binary.LittleEndian.Uint16(r.data[2:]))
+		case xlsSST:
+			sst = [][]byte{r.data}
+			inSST = true
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(sst) > 0 && len(sst[0]) >= 8 {
+		// cstTotal dan cstUnique, lalu string-string yang dapat terpotong
+		// di batas record CONTINUE. Setiap string paling sedikit 3 byte
+		// (panjang dan flag), sehingga cstUnique yang melebihi itu berarti
+		// record terpotong.
+		count := binary.LittleEndian.Uint32(sst[0][4:])
+		sst[0] = sst[0][8:]
+		s := &xlsStream{chunks: sst}
+		if uint64(count)*3 > uint64(s.remaining()) {
+			return errXLSCorrupt
+		}
+		wb.strings = make([]string, 0, count)
+		for i := uint32(0); i < count; i++ {
+			text, err := s.unicodeString(int(s.readUint16()))
+			if err != nil {
+				return err
+			}
+			wb.strings = append(wb.strings, text)
+		}
+	}
+	return nil
+}
+
+// sheet mengembalikan worksheet bernama name, atau worksheet aktif (atau
+// worksheet pertama bila sheet aktif berupa chart) bila name kosong.
+func (wb *xlsWorkbook) sheet(name string) (xlsSheet, bool) {
+	if name == "" && wb.active < len(wb.sheets) && wb.sheets[wb.active].worksheet {
+		return wb.sheets[wb.active], true
+	}
+	for _, sheet := range wb.sheets {
+		if sheet.worksheet && (name == "" || sheet.name == name) {
+			return sheet, true
+		}
+	}
+	return xlsSheet{}, false
+}
+
+// readSheet membaca seluruh sel sheet menjadi baris. Sel kosong di akhir
+// baris dan baris kosong di akhir sheet tidak ikut.
+func (wb *xlsWorkbook) readSheet(sheet xlsSheet) ([][]string, error) {
+	var rows [][]string
+	set := func(row, col uint16, value string) {
+		if value == "" {
+			return
+		}
+		for len(rows) <= int(row) {
+			rows = append(rows, []string{})
+		}
+		for len(rows[row]) <= int(col) {
+			rows[row] = append(rows[row], "")
+		}
+		rows[row][col] = value
+	}
+	// Hasil formula string ada pada record STRING sesudah FORMULA
+	var pendingRow, pendingCol uint16
+	pending := false
+	err := wb.records(sheet.offset, func(r xlsRecord) error {
+		switch {
+		case r.id == xlsString && pending:
+			pending = false
+			s := &xlsStream{chunks: [][]byte{r.data}}
+			text, err := s.unicodeString(int(s.readUint16()))
+			set(pendingRow, pendingCol, text)
+			return err
+		case r.id == xlsShrFmla || r.id == xlsArray || r.id == xlsTable:
+			// Definisi formula bersama dapat berada di antara FORMULA dan STRING
+			return nil
+		}
+		pending = false
+		if len(r.data) < 6 {
+			return nil
+		}
+		row := binary.LittleEndian.Uint16(r.data)
+		col := binary.LittleEndian.Uint16(r.data[2:])
+		xf := binary.LittleEndian.Uint16(r.data[4:])
+		switch r.id {
+		case xlsLabelSST:
+			if len(r.data) < 10 {
+				return errXLSCorrupt
+			}
+			if i := int(binary.LittleEndian.Uint32(r.data[6:])); i < len(wb.strings) {
+				set(row, col, wb.strings[i])
+			}
+		case xlsLabel:
+			s := &xlsStream{chunks: [][]byte{r.data[6:]}}
+			text, err := s.unicodeString(int(s.readUint16()))
+			if err != nil {
+				return err
+			}
+			set(row, col, text)
+		case xlsNumber:
+			if len(r.data) < 14 {
+				return errXLSCorrupt
+			}
+			set(row, col, wb.number(math.Float64frombits(binary.LittleEndian.Uint64(r.data[6:])), xf))
+		case xlsRK:
+			if len(r.data) < 10 {
+				return errXLSCorrupt
+			}
+			set(row, col, wb.number(xlsRKValue(binary.LittleEndian.Uint32(r.data[6:])), xf))
+		case xlsMulRK:
+			// rw, colFirst, lalu pasangan (ixfe, RK) dan colLast
+			for i := 4; i+6 <= len(r.data)-2; i += 6 {
+				xf := binary.LittleEndian.Uint16(r.data[i:])
+				set(row, col, wb.number(xlsRKValue(binary.LittleEndian.Uint32(r.data[i+2:])), xf))
+				col++
+			}
+		case xlsBoolErr:
+			// Nilai error seperti #N/A menjadi sel kosong
+			if len(r.data) >= 8 && r.data[7] == 0 {
+				set(row, col, strconv.FormatBool(r.data[6] != 0))
+			}
+		case xlsFormula:
+			if len(r.data) < 14 {
+				return errXLSCorrupt
+			}
+			result := r.data[6:14]
+			if result[6] != 0xFF || result[7] != 0xFF {
+				set(row, col, wb.number(math.Float64frombits(binary.LittleEndian.Uint64(result)), xf))
+				return nil
+			}
+			switch result[0] {
+			case 0:
+				pendingRow, pendingCol, pending = row, col, true
+			case 1:
+				set(row, col, strconv.FormatBool(result[2] != 0))
+			}
+		}
+		return nil
+	})
+	return rows, err
+}
+
+// number memformat angka sel dengan XF xf: tanggal dan waktu menjadi
+// 2006-01-02, 15:04:05 atau 2006-01-02 15:04:05, selain itu angka apa adanya.
+func (wb *xlsWorkbook) number(value float64, xf uint16) string {
+	if int(xf) >= len(wb.xfs) || !xlsDateFormat(wb.xfs[xf], wb.formats[wb.xfs[xf]]) || value < 0 {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	return serialTime(value, wb.date1904)
+}
+
+// xlsDateFormat mengembalikan true bila format angka id (dengan kode format
+// code untuk format buatan pengguna) menampilkan tanggal atau waktu.
+func xlsDateFormat(id uint16, code string) bool {
+	switch {
+	case id >= 14 && id <= 22, id >= 45 && id <= 47, id >= 27 && id <= 36, id >= 50 && id <= 58:
+		return true
+	case code == "":
+		return false
+	}
+	// Teks dalam tanda kutip, karakter yang diloloskan dan warna seperti
+	// [Red] tidak dihitung; [h], [mm] dan [ss] tetap dihitung
+	stripped, ok := stripNumFmt(code)
+	return ok && strings.ContainsAny(strings.ToLower(stripped), "dmyhs")
+}
+
+// xlsRKValue menguraikan angka berformat RK: bilangan bulat 30 bit atau
+// 30 bit teratas double, opsional dibagi 100.
+func xlsRKValue(rk uint32) float64 {
+	var value float64
+	if rk&0x02 != 0 {
+		value = float64(int32(rk) >> 2)
+	} else {
+		value = math.Float64frombits(uint64(rk&0xFFFFFFFC) << 32)
+	}
+	if rk&0x01 != 0 {
+		value /= 100
+	}
+	return value
+}
+
+// xlsStream membaca isi record yang dapat bersambung ke record CONTINUE.
+// Kesalahan karena data terpotong disimpan di err.
+type xlsStream struct {
+	chunks [][]byte
+	err    error
+}
+
+func (s *xlsStream) next() bool {
+	for len(s.chunks) > 0 && len(s.chunks[0]) == 0 {
+		s.chunks = s.chunks[1:]
+	}
+	if len(s.chunks) == 0 {
+		s.err = errXLSCorrupt
+		return false
+	}
+	return true
+}
+
+// read mengembalikan n byte berikutnya, atau nil dengan s.err terisi bila
+// data tidak cukup.
+func (s *xlsStream) read(n int) []byte {
+	var b []byte
+	for n > 0 && s.next() {
+		k := min(n, len(s.chunks[0]))
+		b = append(b, s.chunks[0][:k]...)
+		s.chunks[0] = s.chunks[0][k:]
+		n -= k
+	}
+	if n > 0 {
+		return nil
+	}
+	return b
+}
+
+// skip melewati n byte tanpa menyalinnya.
+func (s *xlsStream) skip(n int) {
+	for n > 0 && s.next() {
+		k := min(n, len(s.chunks[0]))
+		s.chunks[0] = s.chunks[0][k:]
+		n -= k
+	}
+}
+
+// remaining mengembalikan jumlah byte yang belum dibaca.
+func (s *xlsStream) remaining() int {
+	n := 0
+	for _, chunk := range s.chunks {
+		n += len(chunk)
+	}
+	return n
+}
+
+func (s *xlsStream) readByte() byte {
+	if b := s.read(1); len(b) == 1 {
+		return b[0]
+	}
+	return 0
+}
+
+func (s *xlsStream) readUint16() uint16 {
+	if b := s.read(2); len(b) == 2 {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (s *xlsStream) readUint32() uint32 {
+	if b := s.read(4); len(b) == 4 {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+// unicodeString membaca XLUnicodeRichExtendedString dengan panjang count
+// karakter. Bila karakter terpotong di batas record CONTINUE, record
+// berikutnya diawali byte flag baru yang menentukan lebar karakter sisanya.
+func (s *xlsStream) unicodeString(count int) (string, error) {
+	flags := s.readByte()
+	var runs, ext int
+	if flags&0x08 != 0 {
+		runs = int(s.readUint16())
+	}
+	if flags&0x04 != 0 {
+		ext = int(s.readUint32())
+	}
+	units := make([]uint16, 0, min(count, s.remaining()))
+	for count > 0 && s.err == nil && s.next() {
+		width := 1
+		if flags&0x01 != 0 {
+			width = 2
+		}
+		n := min(count, len(s.chunks[0])/width)
+		b := s.read(n * width)
+		for i := 0; i < n; i++ {
+			if width == 2 {
+				units = append(units, binary.LittleEndian.Uint16(b[2*i:]))
+			} else {
+				units = append(units, uint16(b[i]))
+			}
+		}
+		count -= n
+		if count > 0 {
+			if len(s.chunks[0]) != 0 {
+				s.err = errXLSCorrupt
+				break
+			}
+			flags = s.readByte()
+		}
+	}
+	// Format teks (rich text) dan data fonetik tidak dipakai
+	s.skip(4*runs + ext)
+	return string(utf16.Decode(units)), s.err
+}
+
+// xlsReader adalah RowReader atas baris sheet xls yang sudah dibaca.
+type xlsReader struct {
+	sliceReader
+	sheet string
+}
+
+// newXLSReader membuka sheet sheetName pada file xls path, default sheet
+// aktif.
+func newXLSReader(path, sheetName string) (*xlsReader, error) {
+	wb, err := openXLS(path)
+	if err != nil {
+		return nil, err
+	}
+	sheet, ok := wb.sheet(sheetName)
+	if !ok {
+		return nil, &SheetError{Sheet: sheetName, Err: ErrNoSheet}
+	}
+	rows, err := wb.readSheet(sheet)
+	if err != nil {
+		return nil, &SheetError{Sheet: sheet.name, Err: err}
+	}
+	return &xlsReader{sliceReader: sliceReader{rows: rows}, sheet: sheet.name}, nil
+}
+
+// XLSSheets mengembalikan nama worksheet file xls path yang tidak
+// disembunyikan, sesuai urutan pada file.
+func XLSSheets(path string) ([]string, error) {
+	wb, err := openXLS(path)
+	if err != nil {
+		return nil, err
+	}
+	var sheets []string
+	for _, sheet := range wb.sheets {
+		if sheet.worksheet && sheet.visible {
+			sheets = append(sheets, sheet.name)
+		}
+	}
+	return sheets, nil
+}
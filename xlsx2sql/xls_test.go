@@ -0,0 +1,212 @@
+package xlsx2sql
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"unicode/utf16"
+)
+
+// biffRecord membentuk satu record BIFF8.
+func biffRecord(id uint16, data []byte) []byte {
+	b := binary.LittleEndian.AppendUint16(nil, id)
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(data)))
+	return append(b, data...)
+}
+
+// biffString membentuk XLUnicodeString 8 bit tanpa rich text.
+func biffString(text string) []byte {
+	b := binary.LittleEndian.AppendUint16(nil, uint16(len(text)))
+	return append(append(b, 0), text...)
+}
+
+// biffBOF membentuk record BOF BIFF8 untuk globals (0x0005) atau worksheet
+// (0x0010).
+func biffBOF(dt uint16) []byte {
+	data := binary.LittleEndian.AppendUint16(nil, 0x0600)
+	data = binary.LittleEndian.AppendUint16(data, dt)
+	return biffRecord(xlsBOF, append(data, make([]byte, 12)...))
+}
+
+// biffCell membentuk awal record sel: baris, kolom dan XF.
+func biffCell(row, col uint16) []byte {
+	b := binary.LittleEndian.AppendUint16(nil, row)
+	b = binary.LittleEndian.AppendUint16(b, col)
+	return binary.LittleEndian.AppendUint16(b, 0)
+}
+
+// testWorkbookStream membentuk stream Workbook BIFF8 berisi satu worksheet
+// Data dengan header dari SST dan satu baris angka. sst menggantikan isi
+// record SST bila tidak nil.
+func testWorkbookStream(sst []byte) []byte {
+	if sst == nil {
+		sst = binary.LittleEndian.AppendUint32(nil, 3)
+		sst = binary.LittleEndian.AppendUint32(sst, 3)
+		for _, text := range []string{"nama", "nilai", "apel"} {
+			sst = append(sst, biffString(text)...)
+		}
+	}
+	boundSheet := func(offset uint32) []byte {
+		data := binary.LittleEndian.AppendUint32(nil, offset)
+		data = append(data, 0, 0, 4, 0)
+		return biffRecord(xlsBoundSheet, append(data, "Data"...))
+	}
+	globalsSize := len(biffBOF(0x0005)) + len(boundSheet(0)) + len(biffRecord(xlsSST, sst)) + 4
+	var stream []byte
+	stream = append(stream, biffBOF(0x0005)...)
+	stream = append(stream, boundSheet(uint32(globalsSize))...)
+	stream = append(stream, biffRecord(xlsSST, sst)...)
+	stream = append(stream, biffRecord(xlsEOF, nil)...)
+	stream = append(stream, biffBOF(0x0010)...)
+	for col := uint16(0); col < 2; col++ {
+		stream = append(stream, biffRecord(xlsLabelSST, binary.LittleEndian.AppendUint32(biffCell(0, col), uint32(col)))...)
+	}
+	stream = append(stream, biffRecord(xlsLabelSST, binary.LittleEndian.AppendUint32(biffCell(1, 0), 2))...)
+	stream = append(stream, biffRecord(xlsNumber, binary.LittleEndian.AppendUint64(biffCell(1, 1), math.Float64bits(1.5)))...)
+	return append(stream, biffRecord(xlsEOF, nil)...)
+}
+
+// compoundFile membungkus stream Workbook ke compound file v3 minimal:
+// sektor FAT, sektor direktori, lalu sektor-sektor stream. Stream diisi nol
+// sampai 4096 byte agar tidak disimpan di mini stream; sesudah record EOF
+// sheet terakhir isi itu tidak dibaca.
+func compoundFile(stream []byte) []byte {
+	const sector = 512
+	const endOfChain, freeSect, fatSect, noStream = 0xFFFFFFFE, 0xFFFFFFFF, 0xFFFFFFFD, 0xFFFFFFFF
+	data := append([]byte{}, stream...)
+	for len(data) < 4096 || len(data)%sector != 0 {
+		data = append(data, 0)
+	}
+	streamSectors := len(data) / sector
+
+	header := make([]byte, sector)
+	copy(header, []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1})
+	le := binary.LittleEndian
+	le.PutUint16(header[24:], 0x003E)
+	le.PutUint16(header[26:], 3)
+	le.PutUint16(header[28:], 0xFFFE)
+	le.PutUint16(header[30:], 9)
+	le.PutUint16(header[32:], 6)
+	le.PutUint32(header[44:], 1)
+	le.PutUint32(header[48:], 1)
+	le.PutUint32(header[56:], 4096)
+	le.PutUint32(header[60:], endOfChain)
+	le.PutUint32(header[68:], endOfChain)
+	le.PutUint32(header[76:], 0)
+	for i := 80; i < sector; i += 4 {
+		le.PutUint32(header[i:], freeSect)
+	}
+
+	fat := make([]byte, sector)
+	for i := 0; i < sector/4; i++ {
+		le.PutUint32(fat[4*i:], freeSect)
+	}
+	le.PutUint32(fat[0:], fatSect)
+	le.PutUint32(fat[4:], endOfChain)
+	for i := 0; i < streamSectors; i++ {
+		next := uint32(3 + i)
+		if i == streamSectors-1 {
+			next = endOfChain
+		}
+		le.PutUint32(fat[4*(2+i):], next)
+	}
+
+	dir := make([]byte, sector)
+	entry := func(i int, name string, kind byte, child, start uint32, size int) {
+		e := dir[128*i : 128*(i+1)]
+		units := utf16.Encode([]rune(name))
+		for j, u := range units {
+			le.PutUint16(e[2*j:], u)
+		}
+		le.PutUint16(e[64:], uint16(2*len(units)+2))
+		e[66], e[67] = kind, 1
+		le.PutUint32(e[68:], noStream)
+		le.PutUint32(e[72:], noStream)
+		le.PutUint32(e[76:], child)
+		le.PutUint32(e[116:], start)
+		le.PutUint32(e[120:], uint32(size))
+	}
+	entry(0, "Root Entry", 5, 1, endOfChain, 0)
+	entry(1, "Workbook", 2, noStream, 2, len(data))
+	for i := 2; i < 4; i++ {
+		le.PutUint32(dir[128*i+68:], noStream)
+		le.PutUint32(dir[128*i+72:], noStream)
+		le.PutUint32(dir[128*i+76:], noStream)
+	}
+
+	file := append(header, fat...)
+	file = append(file, dir...)
+	return append(file, data...)
+}
+
+func writeXLS(t testing.TB, stream []byte) string {
+	path := filepath.Join(t.TempDir(), "data.xls")
+	if err := os.WriteFile(path, compoundFile(stream), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestXLSReader(t *testing.T) {
+	r, err := newXLSReader(writeXLS(t, testWorkbookStream(nil)), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.sheet != "Data" {
+		t.Errorf("sheet = %q, ingin Data", r.sheet)
+	}
+	want := [][]string{{"nama", "nilai"}, {"apel", "1.5"}}
+	if !reflect.DeepEqual(r.rows, want) {
+		t.Errorf("rows = %q, ingin %q", r.rows, want)
+	}
+}
+
+func TestXLSTruncatedSST(t *testing.T) {
+	header := func(count uint32) []byte {
+		b := binary.LittleEndian.AppendUint32(nil, count)
+		return binary.LittleEndian.AppendUint32(b, count)
+	}
+	tests := []struct {
+		name string
+		sst  []byte
+	}{
+		// cstUnique lebih besar dari isi record
+		{"count", append(header(1000), biffString("nama")...)},
+		// panjang string kedua terpotong setelah satu byte
+		{"length", append(append(header(2), biffString("nama")...), 0x05)},
+		// jumlah karakter melebihi isi record
+		{"chars", append(header(1), 0x10, 0x00, 0, 'a', 'b')},
+		// rich text dengan jumlah run terpotong
+		{"runs", append(header(1), 0x01, 0x00, 0x08, 'a', 0, 0)},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wb := &xlsWorkbook{stream: testWorkbookStream(test.sst), formats: make(map[uint16]string)}
+			if err := wb.parseGlobals(); !errors.Is(err, errXLSCorrupt) {
+				t.Errorf("parseGlobals = %v, ingin %v", err, errXLSCorrupt)
+			}
+			if _, err := newXLSReader(writeXLS(t, testWorkbookStream(test.sst)), ""); !errors.Is(err, errXLSCorrupt) {
+				t.Errorf("newXLSReader = %v, ingin %v", err, errXLSCorrupt)
+			}
+		})
+	}
+}
+
+func FuzzXLS(f *testing.F) {
+	stream := testWorkbookStream(nil)
+	f.Add(stream)
+	f.Add(stream[:len(stream)/2])
+	f.Fuzz(func(t *testing.T, stream []byte) {
+		wb := &xlsWorkbook{stream: stream, formats: make(map[uint16]string)}
+		if wb.parseGlobals() != nil {
+			return
+		}
+		for _, sheet := range wb.sheets {
+			wb.readSheet(sheet)
+		}
+	})
+}
@@ -0,0 +1,909 @@
+// Package xlsx2sql mengonversi sheet Excel atau file CSV menjadi pernyataan
+// CREATE TABLE dan INSERT untuk MariaDB. Program xlsx2mariadb dibangun di atas
+// package ini; package inference, ddl, writer dan loader dapat juga dipakai
+// secara terpisah.
+package xlsx2sql
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/ddl"
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/inference"
+	"github.com/MuhaeminSidiq/GOLearnbyAI/xlsx2sql/writer"
+	"github.com/xuri/excelize/v2"
+)
+
+// Options mengatur satu konversi.
+type Options struct {
+	// Table adalah nama tabel tujuan, dibentuk dengan DDL.Naming (default
+	// ddl.SanitizeTableName)
+	Table string
+
+	// Format adalah "xlsx", "xls", "ods", "csv", "json" (array objek),
+	// "jsonl" (JSON Lines, satu objek per baris), "parquet" atau "xml". Bila
+	// kosong, Convert menebak dari isi input (DetectFormat) dan ConvertFile
+	// dari ekstensi file.
+	Format string
+
+	// XMLRows adalah XPath elemen baris pada input XML, misalnya
+	// /data/record, atau nama elemennya saja (record berarti //record).
+	// Default DefaultXMLRows.
+	XMLRows string
+
+	// Sheet adalah nama sheet xlsx, xls atau ODS yang dibaca, default sheet aktif
+	Sheet string
+
+	// Range membatasi input pada rentang sel, misalnya B3:F100 atau
+	// Data!B3:F100, atau pada xlsx nama yang terdefinisi (named range) seperti
+	// DataPenjualan, sehingga metadata di sekitar blok data template diabaikan.
+	// Nama berlingkup sheet didahulukan; sheet pada rentang menggantikan
+	// Sheet. Baris pertama rentang adalah baris 1 untuk HeaderRow.
+	Range string
+
+	// HeaderRow adalah nomor baris header (mulai 1); baris di atasnya diabaikan
+	HeaderRow int
+
+	// HeaderRows adalah jumlah baris header bertingkat mulai dari HeaderRow,
+	// digabung per kolom dengan MergeHeaderRows (0 atau 1 = satu baris)
+	HeaderRows int
+
+	// SkipRows adalah jumlah baris tepat di bawah header yang diabaikan
+	// sebelum baris data, misalnya baris satuan atau keterangan kolom
+	SkipRows int
+
+	// NoHeader memperlakukan input tanpa baris header: baris HeaderRow
+	// (setelah SkipRows baris) sudah menjadi baris data dan HeaderRows
+	// diabaikan. Nama kolom diambil dari HeaderNames lalu dilengkapi
+	// GeneratedColumn sampai selebar baris terlebar yang dibaca untuk
+	// inferensi.
+	NoHeader bool
+
+	// HeaderNames adalah teks header kolom-kolom pertama pada NoHeader,
+	// dibentuk dengan DDL.Naming seperti header biasa
+	HeaderNames []string
+
+	// ExpectHeader, bila tidak nil, adalah kontrak header: nama dan urutan
+	// kolom header input harus sama persis. Bila berbeda, konversi gagal
+	// dengan *HeaderMismatchError sebelum baris data dibaca.
+	ExpectHeader []string
+
+	// Collations memetakan nama kolom atau teks header ke collation kolom
+	// (ddl.Column.Collation), misalnya {"kode": "utf8mb4_bin"}. Kolom yang
+	// bukan tipe teks diabaikan.
+	Collations map[string]string
+
+	// Encrypt berisi nama kolom atau teks header kolom yang disimpan
+	// terenkripsi (ddl.Column.Encrypted): nilainya ditulis dengan
+	// AES_ENCRYPT dan Result.DecryptView dibentuk. Hanya untuk pernyataan
+	// INSERT MariaDB, tidak untuk Delimited.
+	Encrypt map[string]bool
+
+	// Tenant, bila tidak kosong, ditambahkan sebagai kolom pertama
+	// ddl.TenantColumn pada tabel dan setiap baris, dengan indeks gabungan
+	// pada kolom itu. Kolom lain, termasuk posisi sel pada pesan kesalahan,
+	// tetap mengikuti input.
+	Tenant string
+
+	// DDL mengatur kolom primary key, perilaku terhadap tabel yang sudah ada
+	// dan opsi tabel pada CREATE TABLE. Nilai nol menghasilkan <tabel>_id INT
+	// dengan ENGINE = INNODB.
+	DDL ddl.TableOptions
+
+	// Excelize diteruskan ke excelize saat membuka file xlsx
+	Excelize excelize.Options
+
+	// NumberFormats memakai format angka sel xlsx sebagai petunjuk tipe
+	// kolom: sel bertanggal ditulis sebagai tanggal ISO dan sel berformat
+	// bilangan (mata uang, persen, desimal tetap) sebagai nilai mentahnya,
+	// bukan teks yang ditampilkan. Kolom yang semua sel tidak kosongnya
+	// berformat sama mendapat tipe DATE, DATETIME, TIME, INT, BIGINT,
+	// DECIMAL(p,s) atau, untuk format teks @, VARCHAR tanpa inferensi.
+	NumberFormats bool
+
+	// FillMerged mengisi setiap sel yang tertutup merged cell xlsx dengan
+	// nilai sel kiri atasnya sebelum inferensi dan penulisan, sehingga baris
+	// di bawah sel gabungan seperti nama wilayah tidak kosong
+	FillMerged bool
+
+	// Data menerima pernyataan INSERT. Bila nil, data tidak ditulis dan
+	// hanya skema yang dibentuk.
+	Data io.Writer
+
+	// BatchRows adalah jumlah tuple per INSERT, default writer.DefaultBatchRows
+	BatchRows int
+
+	// Delimited, bila tidak nil, menulis Data sebagai teks berbatas
+	// (writer.DelimitedWriter) dengan format ini, misalnya writer.CPImportFormat
+	// untuk cpimport atau writer.CSVFormat untuk COPY, bukan pernyataan INSERT
+	Delimited *writer.DelimitedFormat
+
+	// Inference menentukan tipe kolom, default inference.DefaultEngine
+	Inference *inference.Engine
+
+	// InferRows membatasi jumlah baris data yang dipakai untuk menentukan tipe
+	// kolom (0 = semua baris). Baris di luar sampel diperiksa saat INSERT
+	// ditulis; baris yang tidak cocok menghasilkan *SampleMismatchError.
+	InferRows int
+
+	// InferRandom memilih InferRows baris secara acak dari seluruh sheet
+	// (reservoir sampling dengan seed tetap), bukan InferRows baris pertama
+	InferRandom bool
+
+	// SampleRows adalah jumlah baris data pertama yang disimpan di Result.Sample
+	SampleRows int
+
+	// OnRow, bila tidak nil, dipanggil untuk setiap baris data saat INSERT
+	// ditulis, misalnya untuk menghitung statistik tanpa membaca ulang file.
+	// result sudah berisi skema tabel.
+	OnRow func(result *Result, row []string)
+
+	// OnReject, bila tidak nil, dipanggil untuk setiap sel tidak kosong yang
+	// tidak sesuai tipe kolomnya sehingga ditulis NULL. Sel seperti ini hanya
+	// ada bila Inference.MinConfidence di bawah 1, misalnya salah ketik pada
+	// kolom angka.
+	OnReject func(Reject)
+
+	// OnEvent, bila tidak nil, menerima kejadian kemajuan (FileStarted,
+	// RowBatchWritten, FileCompleted dan Error) dari goroutine yang menjalankan
+	// konversi. Pakai EventChannel untuk meneruskannya ke channel.
+	OnEvent func(Event)
+}
+
+// Result adalah hasil konversi satu sheet.
+type Result struct {
+	Table string
+
+	// Sheet adalah nama sheet xlsx, xls atau ODS yang dibaca, kosong untuk CSV
+	Sheet string
+
+	// FirstRow dan FirstColumn adalah baris dan kolom (mulai 1) sel kiri atas
+	// Options.Range, 0 bila seluruh sheet dibaca. HeaderRow dan posisi sel
+	// input dihitung dari sel ini.
+	FirstRow, FirstColumn int
+
+	Header      []string
+	Columns     []ddl.Column
+	Types       []string
+	CreateTable string
+
+	// DecryptView berisi fungsi kunci dan view dekripsi dari
+	// ddl.DecryptView, kosong bila tidak ada kolom Options.Encrypt
+	DecryptView string
+
+	// Inferred berisi detector dan confidence setiap kolom
+	Inferred []inference.Result
+
+	// Rows adalah jumlah baris data tanpa header. Dengan InferRows tanpa
+	// InferRandom, dan tanpa Data maupun OnRow, hanya baris yang dibaca untuk
+	// sampel yang terhitung.
+	Rows int
+
+	// Sample berisi paling banyak Options.SampleRows baris data pertama
+	Sample [][]string
+}
+
+// ErrNoData dikembalikan bila input tidak memiliki baris data di bawah header.
+var ErrNoData = errors.New("xlsx2sql: tidak ada baris data")
+
+// ErrEncryptDelimited dikembalikan bila Options.Encrypt dipakai bersama
+// Options.Delimited, yang tidak dapat memuat nilai AES_ENCRYPT.
+var ErrEncryptDelimited = errors.New("xlsx2sql: kolom terenkripsi hanya didukung pada pernyataan INSERT")
+
+// SheetError membungkus kesalahan membaca baris pada sheet tertentu.
+type SheetError struct {
+	Sheet string
+	Err   error
+}
+
+func (e *SheetError) Error() string {
+	return "sheet " + e.Sheet + ": " + e.Err.Error()
+}
+
+func (e *SheetError) Unwrap() error {
+	return e.Err
+}
+
+// DataError membungkus kesalahan menulis ke Options.Data, untuk membedakannya
+// dari kesalahan membaca input.
+type DataError struct {
+	Err error
+}
+
+func (e *DataError) Error() string {
+	return "xlsx2sql: menulis data: " + e.Err.Error()
+}
+
+func (e *DataError) Unwrap() error {
+	return e.Err
+}
+
+// SampleMismatchError dikembalikan bila baris di luar sampel InferRows tidak
+// cocok dengan tipe kolom hasil sampel. Data yang sudah ditulis ke
+// Options.Data tidak lengkap; ulangi konversi dengan InferRows 0.
+type SampleMismatchError struct {
+	Column string
+	Type   string
+	Row    int
+	Value  string
+
+	// Cell adalah referensi sel sumber, misalnya Sheet1!C1043 (lihat CellRef)
+	Cell string
+
+	// Suggestion adalah saran perbaikan nilai dari inference.Suggest, nil
+	// bila tidak ada
+	Suggestion *inference.Suggestion
+}
+
+func (e *SampleMismatchError) Error() string {
+	msg := fmt.Sprintf("xlsx2sql: %s kolom %s: nilai %s tidak sesuai tipe %s hasil sampel", e.Cell, e.Column, Excerpt(e.Value), e.Type)
+	if e.Suggestion != nil {
+		msg += fmt.Sprintf(" (saran: %s)", e.Suggestion)
+	}
+	return msg
+}
+
+// Reject adalah sel yang tidak sesuai tipe kolomnya dan ditulis NULL karena
+// jumlahnya masih di bawah batas inference.Engine.MinConfidence.
+type Reject struct {
+	Column string
+	Type   string
+	Row    int
+	Value  string
+
+	// Cell adalah referensi sel sumber, misalnya Sheet1!C1043 (lihat CellRef)
+	Cell string
+
+	// Suggestion adalah saran perbaikan nilai dari inference.Suggest, nil
+	// bila tidak ada
+	Suggestion *inference.Suggestion
+}
+
+// HeaderMismatchError dikembalikan bila header input tidak sesuai
+// Options.ExpectHeader.
+type HeaderMismatchError struct {
+	Expected []string
+	Actual   []string
+}
+
+func (e *HeaderMismatchError) Error() string {
+	return "xlsx2sql: header tidak sesuai kontrak (- diharapkan, + ditemukan):\n" + strings.Join(e.Diff(), "\n")
+}
+
+// Diff mengembalikan perbedaan Expected dan Actual per kolom seperti diff
+// unified: "- nama" untuk kolom yang diharapkan tetapi tidak ada, "+ nama"
+// untuk kolom yang tidak diharapkan dan "  nama" untuk kolom yang sama.
+// Kolom yang berpindah urutan muncul sebagai - dan + pada posisinya.
+func (e *HeaderMismatchError) Diff() []string {
+	a, b := e.Expected, e.Actual
+	// lcs[i][j] adalah panjang subsequence bersama terpanjang a[i:] dan b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, "  "+a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "- "+a[i])
+			i++
+		default:
+			lines = append(lines, "+ "+b[j])
+			j++
+		}
+	}
+	return lines
+}
+
+// checkHeader membandingkan header (tanpa spasi di awal dan akhir nama
+// kolom) dengan kontrak expected.
+func checkHeader(header, expected []string) error {
+	actual := make([]string, len(header))
+	for i, name := range header {
+		actual[i] = strings.TrimSpace(name)
+	}
+	if slices.Equal(actual, expected) {
+		return nil
+	}
+	return &HeaderMismatchError{Expected: expected, Actual: actual}
+}
+
+// CellRef mengembalikan referensi sel gaya Excel untuk kolom dan baris sheet
+// (mulai 1), misalnya Sheet1!C1043. Nama sheet yang bukan huruf, angka dan
+// garis bawah saja diapit tanda kutip tunggal seperti pada rumus Excel, dan
+// tanpa nama sheet (CSV, JSON) hanya alamat sel yang dikembalikan.
+func CellRef(sheet string, column, row int) string {
+	cell, err := excelize.CoordinatesToCellName(column, row)
+	if err != nil {
+		cell = fmt.Sprintf("R%dC%d", row, column)
+	}
+	if sheet == "" {
+		return cell
+	}
+	for _, c := range sheet {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' {
+			return "'" + strings.ReplaceAll(sheet, "'", "''") + "'!" + cell
+		}
+	}
+	return sheet + "!" + cell
+}
+
+// ExcerptLength adalah panjang maksimum nilai sel yang dikutip Excerpt.
+const ExcerptLength = 40
+
+// Excerpt mengutip value untuk pesan kesalahan, dipotong menjadi
+// ExcerptLength karakter dengan ... bila lebih panjang.
+func Excerpt(value string) string {
+	if runes := []rune(value); len(runes) > ExcerptLength {
+		value = string(runes[:ExcerptLength]) + "..."
+	}
+	return strconv.Quote(value)
+}
+
+// cellRef mengembalikan referensi sel sumber kolom input ke-column pada baris
+// ke-row (mulai 1) input, yang dimulai dari sel kiri atas Options.Range bila
+// diisi.
+func (r *Result) cellRef(column, row int) string {
+	return CellRef(r.Sheet, max(r.FirstColumn, 1)-1+column, max(r.FirstRow, 1)-1+row)
+}
+
+// cellRow mengembalikan nomor baris input untuk baris data ke-row (mulai 1)
+// di bawah baris header dan baris yang diabaikan menurut opts.
+func cellRow(opts Options, row int) int {
+	header := max(opts.HeaderRows, 1)
+	if opts.NoHeader {
+		header = 0
+	}
+	return max(opts.HeaderRow, 1) + header - 1 + opts.SkipRows + row
+}
+
+// GeneratedColumn mengembalikan teks header kolom ke-n (mulai 1) pada
+// Options.NoHeader tanpa HeaderNames, misalnya col_3.
+func GeneratedColumn(n int) string {
+	return "col_" + strconv.Itoa(n)
+}
+
+// headerNames mengembalikan names yang dilengkapi GeneratedColumn sampai
+// width kolom.
+func headerNames(names []string, width int) []string {
+	header := slices.Clone(names)
+	for len(header) < width {
+		header = append(header, GeneratedColumn(len(header)+1))
+	}
+	return header
+}
+
+// readHeader membaca opts.HeaderRows baris header r, menggabungkannya bila
+// lebih dari satu, lalu membuang opts.SkipRows baris di bawahnya. Pada
+// opts.NoHeader tidak ada baris yang dibaca sebagai header; header berisi
+// opts.HeaderNames saja dan dilengkapi inferSchema.
+func readHeader(r RowReader, opts Options) ([]string, error) {
+	if opts.NoHeader {
+		if err := skipRows(r, opts.SkipRows); err != nil {
+			return nil, err
+		}
+		return headerNames(opts.HeaderNames, 0), nil
+	}
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	if opts.HeaderRows > 1 {
+		rows := [][]string{header}
+		for len(rows) < opts.HeaderRows {
+			row, err := r.Read()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+		header = MergeHeaderRows(rows)
+	}
+	if err := skipRows(r, opts.SkipRows); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// skipRows membuang n baris r.
+func skipRows(r RowReader, n int) error {
+	for i := 0; i < n; i++ {
+		if _, err := r.Read(); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HeaderSeparator memisahkan teks setiap tingkat header pada MergeHeaderRows.
+// Pemisah ini bukan huruf atau angka sehingga Sales|Q1 menjadi sales_q1 pada
+// ddl.NamingSnake dan salesq1 pada ddl.NamingCompact.
+const HeaderSeparator = "|"
+
+// MergeHeaderRows menggabungkan header bertingkat rows (baris teratas lebih
+// dulu) menjadi satu teks header per kolom, misalnya Sales di atas Q1 menjadi
+// Sales|Q1. Sel kosong pada baris selain baris terakhir mewarisi teks di
+// kirinya seperti sel gabungan Excel yang hanya berisi teks di sel
+// pertamanya, selama kelompok di atasnya sama dan kolom di kirinya memiliki
+// tingkat di bawahnya; kolom yang hanya berisi teks pada baris atas (sel
+// gabungan vertikal) tidak diwarisi. Teks yang sama dengan tingkat di atasnya
+// tidak diulang.
+func MergeHeaderRows(rows [][]string) []string {
+	width := 0
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+	cell := func(k, j int) string {
+		if j < len(rows[k]) {
+			return strings.TrimSpace(rows[k][j])
+		}
+		return ""
+	}
+	// below mengembalikan true bila kolom j memiliki teks di bawah baris k
+	below := func(k, j int) bool {
+		for m := k + 1; m < len(rows); m++ {
+			if cell(m, j) != "" {
+				return true
+			}
+		}
+		return false
+	}
+	filled := make([][]string, len(rows))
+	for k := range rows {
+		filled[k] = make([]string, width)
+		for j := 0; j < width; j++ {
+			filled[k][j] = cell(k, j)
+			if filled[k][j] != "" || j == 0 || k == len(rows)-1 || !below(k, j-1) {
+				continue
+			}
+			if k == 0 || filled[k-1][j] == filled[k-1][j-1] {
+				filled[k][j] = filled[k][j-1]
+			}
+		}
+	}
+	header := make([]string, width)
+	for j := range header {
+		var parts []string
+		for k := range rows {
+			if text := filled[k][j]; text != "" && (len(parts) == 0 || parts[len(parts)-1] != text) {
+				parts = append(parts, text)
+			}
+		}
+		header[j] = strings.Join(parts, HeaderSeparator)
+	}
+	return header
+}
+
+// Convert membaca r sebagai xlsx, xls, ODS, CSV, JSON, JSON Lines, Parquet atau XML lalu membentuk CREATE TABLE dan,
+// bila opts.Data tidak nil, menulis pernyataan INSERT ke opts.Data. Input
+// disalin ke file sementara agar dapat dibaca dua kali tanpa dimuat ke memori.
+func Convert(ctx context.Context, r io.Reader, opts Options) (*Result, error) {
+	in := bufio.NewReader(r)
+	if opts.Format == "" {
+		header, _ := in.Peek(odsMimetypeEnd)
+		opts.Format = DetectFormat(header)
+	}
+
+	file, err := os.CreateTemp("", "xlsx2sql-*."+opts.Format)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	_, err = io.Copy(file, in)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return generate(ctx, "", func() (RowReader, error) { return OpenFile(file.Name(), opts) }, opts)
+}
+
+// odsMimetypeEnd adalah panjang awal file ODS sampai akhir isi entri
+// mimetype, yang selalu menjadi entri pertama arsip tanpa kompresi.
+const odsMimetypeEnd = 30 + len("mimetype") + len("application/vnd.oasis.opendocument.spreadsheet")
+
+// DetectFormat menebak format input dari awal isinya: arsip zip dengan
+// mimetype OpenDocument spreadsheet adalah "ods", arsip zip lain "xlsx", OLE
+// compound file "xls", file berawalan PAR1 "parquet", teks yang diawali [
+// "json", teks yang diawali { "jsonl", teks yang diawali < "xml", dan selain
+// itu "csv".
+func DetectFormat(header []byte) string {
+	if bytes.HasPrefix(header, []byte("PAR1")) {
+		return "parquet"
+	}
+	if bytes.HasPrefix(header, []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}) {
+		return "xls"
+	}
+	switch text := bytes.TrimLeft(bytes.TrimPrefix(header, []byte("\xef\xbb\xbf")), " \t\r\n"); {
+	case bytes.HasPrefix(text, []byte("[")):
+		return "json"
+	case bytes.HasPrefix(text, []byte("{")):
+		return "jsonl"
+	case bytes.HasPrefix(text, []byte("<")):
+		return "xml"
+	}
+	if !bytes.HasPrefix(header, []byte("PK\x03\x04")) {
+		return "csv"
+	}
+	if bytes.Contains(header, []byte("application/vnd.oasis.opendocument.spreadsheet")) {
+		return "ods"
+	}
+	return "xlsx"
+}
+
+// ConvertFile seperti Convert tetapi membaca file path. File dibaca dua kali
+// baris demi baris: pertama untuk menentukan tipe kolom, kedua untuk menulis
+// INSERT, sehingga sheet tidak pernah dimuat utuh ke memori.
+func ConvertFile(ctx context.Context, path string, opts Options) (*Result, error) {
+	return generate(ctx, path, func() (RowReader, error) { return OpenFile(path, opts) }, opts)
+}
+
+// Generate seperti ConvertFile untuk baris yang sudah ada di memori. Baris
+// pertama adalah header.
+func Generate(ctx context.Context, rows [][]string, opts Options) (*Result, error) {
+	return generate(ctx, "", func() (RowReader, error) { return &sliceReader{rows: rows}, nil }, opts)
+}
+
+// generate menjalankan convertRows dan mengirim kejadian FileStarted,
+// FileCompleted atau Error untuk file (kosong bila input bukan file).
+func generate(ctx context.Context, file string, open func() (RowReader, error), opts Options) (*Result, error) {
+	table := opts.DDL.Naming.Table(opts.Table)
+	opts.emit(Event{Kind: FileStarted, File: file, Table: table})
+	result, err := convertRows(ctx, file, open, opts)
+	if err != nil {
+		opts.emit(Event{Kind: Error, File: file, Table: table, Err: err})
+		return nil, err
+	}
+	opts.emit(Event{Kind: FileCompleted, File: file, Table: table, Rows: result.Rows, Result: result})
+	return result, nil
+}
+
+// convertRows menentukan tipe kolom dari baris-baris open() (baris pertama
+// adalah header), membentuk CREATE TABLE lalu membuka ulang input untuk
+// menulis INSERT ke opts.Data. Pembatalan ctx diperiksa setiap 10.000 baris.
+func convertRows(ctx context.Context, file string, open func() (RowReader, error), opts Options) (*Result, error) {
+	result, err := inferSchema(ctx, open, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Data == nil && opts.OnRow == nil {
+		return result, nil
+	}
+
+	r, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	if _, err := readHeader(r, opts); err != nil {
+		return nil, err
+	}
+
+	var w writer.RowWriter
+	switch {
+	case opts.Data != nil && opts.Delimited != nil:
+		if result.DecryptView != "" {
+			return nil, ErrEncryptDelimited
+		}
+		dw := writer.NewDelimited(opts.Data, result.Types)
+		dw.DelimitedFormat = *opts.Delimited
+		w = dw
+	case opts.Data != nil:
+		names := make([]string, len(result.Columns))
+		for i, column := range result.Columns {
+			names[i] = column.Name
+		}
+		iw := writer.New(opts.Data, result.Table, names, result.Types)
+		if result.DecryptView != "" {
+			iw.Encrypted = make([]bool, len(result.Columns))
+			for i, column := range result.Columns {
+				iw.Encrypted[i] = column.Encrypted
+			}
+		}
+		if opts.BatchRows > 0 {
+			iw.BatchRows = opts.BatchRows
+		}
+		w = iw
+	}
+	sampled := opts.InferRows > 0 && result.Rows > opts.InferRows
+	// tolerance adalah proporsi sel tidak kosong setiap kolom yang boleh
+	// tidak sesuai tipenya; di luar sampel, kolom yang melewatinya dianggap
+	// salah tipe seperti tanpa tolerance
+	tolerance := 0.0
+	if engine := opts.Inference; engine != nil && engine.MinConfidence > 0 && engine.MinConfidence < 1 {
+		tolerance = 1 - engine.MinConfidence
+	}
+	rejected := make([]int, len(result.Columns))
+	filled := make([]int, len(result.Columns))
+	// offset adalah jumlah kolom tambahan di depan kolom input
+	offset := 0
+	if opts.Tenant != "" {
+		offset = 1
+	}
+	result.Rows = 0
+	for i := 0; ; i++ {
+		if i%10000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Rows++
+		if offset > 0 {
+			row = withTenant(opts.Tenant, row)
+		}
+		if sampled || tolerance > 0 {
+			for j, column := range result.Columns {
+				if j < offset || j >= len(row) || strings.TrimSpace(row[j]) == "" {
+					continue
+				}
+				filled[j]++
+				if inference.Fits(row[j], column.Type) {
+					continue
+				}
+				cell := result.cellRef(j+1-offset, cellRow(opts, i+1))
+				var suggestion *inference.Suggestion
+				if s, ok := inference.Suggest(row[j], column.Type); ok {
+					suggestion = &s
+				}
+				rejected[j]++
+				if tolerance == 0 || sampled && result.Rows > opts.InferRows && float64(rejected[j]) > tolerance*float64(filled[j]) {
+					return nil, &SampleMismatchError{Column: column.Name, Type: column.Type, Row: i + 1, Value: row[j], Cell: cell, Suggestion: suggestion}
+				}
+				if opts.OnReject != nil {
+					opts.OnReject(Reject{Column: column.Name, Type: column.Type, Row: i + 1, Value: row[j], Cell: cell, Suggestion: suggestion})
+				}
+			}
+		}
+		if opts.OnRow != nil {
+			opts.OnRow(result, row)
+		}
+		if w != nil {
+			if err := w.WriteRow(row); err != nil {
+				return nil, &DataError{Err: err}
+			}
+		}
+		if result.Rows%EventRows == 0 {
+			opts.emit(Event{Kind: RowBatchWritten, File: file, Table: result.Table, Rows: result.Rows, Result: result})
+		}
+	}
+	if w != nil {
+		if err := w.Close(); err != nil {
+			return nil, &DataError{Err: err}
+		}
+	}
+	if result.Rows%EventRows != 0 {
+		opts.emit(Event{Kind: RowBatchWritten, File: file, Table: result.Table, Rows: result.Rows, Result: result})
+	}
+	return result, nil
+}
+
+// inferSchema membaca baris sekali untuk menentukan tipe kolom. Dengan
+// InferRows, hanya sampel baris yang disimpan; tanpa InferRandom pembacaan
+// berhenti setelah sampel terkumpul sehingga Result.Rows baru dihitung penuh
+// pada pembacaan kedua.
+func inferSchema(ctx context.Context, open func() (RowReader, error), opts Options) (*Result, error) {
+	r, err := open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	header, err := readHeader(r, opts)
+	if err == io.EOF {
+		return nil, ErrNoData
+	}
+	if err != nil {
+		return nil, err
+	}
+	if opts.ExpectHeader != nil && !opts.NoHeader {
+		if err := checkHeader(header, opts.ExpectHeader); err != nil {
+			return nil, err
+		}
+	}
+	result := &Result{Table: opts.DDL.Naming.Table(opts.Table), Header: header}
+	// base adalah reader format input di bawah rentang Options.Range, yang
+	// kolom pertamanya adalah kolom ke-skip+1 sheet
+	base, skip := r, 0
+	if rr, ok := r.(*rangeReader); ok {
+		base, skip = rr.RowReader, rr.area.firstCol-1
+		result.FirstRow, result.FirstColumn = rr.area.firstRow, rr.area.firstCol
+	}
+	var formats *cellFormats
+	if s, ok := base.(*sheetReader); ok && s.formats != nil {
+		formats = s.formats
+		formats.track = true
+	}
+
+	engine := opts.Inference
+	if engine == nil {
+		engine = inference.DefaultEngine
+	}
+	switch s := base.(type) {
+	case *sheetReader:
+		result.Sheet = s.sheet
+	case *odsReader:
+		result.Sheet = s.sheet
+	case *xlsReader:
+		result.Sheet = s.sheet
+	}
+	columns := make([]*inference.Column, len(header))
+	for i := range columns {
+		columns[i] = engine.NewColumn()
+	}
+	// Sampel acak baru diketahui setelah semua baris dibaca, sehingga hanya
+	// baris sampel (paling banyak InferRows) yang disimpan
+	var random *rand.Rand
+	var reservoir [][]string
+	if opts.InferRows > 0 && opts.InferRandom {
+		random = rand.New(rand.NewSource(1))
+	}
+	for {
+		if result.Rows%10000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if opts.NoHeader && len(row) > len(header) {
+			header = headerNames(header, len(row))
+			for len(columns) < len(header) {
+				columns = append(columns, engine.NewColumn())
+			}
+		}
+		// slot adalah posisi baris pada sampel, -1 bila tidak diambil
+		slot := result.Rows
+		if opts.InferRows > 0 && result.Rows >= opts.InferRows {
+			slot = -1
+			if random != nil {
+				if n := random.Intn(result.Rows + 1); n < opts.InferRows {
+					slot = n
+				}
+			}
+		}
+		switch {
+		case random != nil && slot == len(reservoir):
+			reservoir = append(reservoir, row)
+		case random != nil && slot >= 0:
+			reservoir[slot] = row
+		case slot >= 0:
+			addRow(columns, row)
+		}
+		if result.Rows < opts.SampleRows {
+			result.Sample = append(result.Sample, row)
+		}
+		result.Rows++
+		if opts.InferRows > 0 && random == nil && result.Rows >= opts.InferRows && result.Rows >= opts.SampleRows {
+			// Satu baris lagi dibaca agar generate tahu masih ada baris di luar sampel
+			if _, err := r.Read(); err == nil {
+				result.Rows++
+			}
+			break
+		}
+	}
+	if result.Rows == 0 {
+		return nil, ErrNoData
+	}
+
+	for _, row := range reservoir {
+		addRow(columns, row)
+	}
+	result.Types = make([]string, len(header))
+	result.Inferred = make([]inference.Result, len(header))
+	for i, column := range columns {
+		result.Inferred[i] = column.Result()
+		result.Types[i] = result.Inferred[i].Type
+	}
+	// Format angka yang seragam pada semua sel kolom menggantikan hasil inferensi
+	if formats != nil {
+		for i := range result.Types {
+			if columnType := formats.columnType(skip+i, result.Inferred[i].MaxLength); columnType != "" {
+				result.Types[i] = columnType
+				result.Inferred[i] = inference.Result{Type: columnType, Detector: "numfmt", Confidence: 1, NonEmpty: result.Inferred[i].NonEmpty, MaxLength: result.Inferred[i].MaxLength}
+			}
+		}
+	}
+	// Tipe dari skema input menggantikan hasil inferensi
+	if typed, ok := base.(TypedReader); ok {
+		for i, columnType := range typed.Types()[min(skip, len(typed.Types())):] {
+			if i < len(result.Types) && columnType != "" {
+				result.Types[i] = columnType
+				result.Inferred[i] = inference.Result{Type: columnType, Detector: "schema", Confidence: 1, NonEmpty: result.Inferred[i].NonEmpty, MaxLength: result.Inferred[i].MaxLength}
+			}
+		}
+	}
+	result.Header = header
+	result.Columns = opts.DDL.Naming.Columns(header, result.Types)
+	// Nama buatan seperti col_1 tetap bergaris bawah pada ddl.NamingCompact
+	if opts.NoHeader {
+		for i := len(opts.HeaderNames); i < len(header); i++ {
+			result.Columns[i].Name = opts.DDL.Naming.Fixed(header[i])
+		}
+	}
+	for i, column := range result.Columns {
+		collation, ok := opts.Collations[column.Name]
+		if !ok {
+			collation = opts.Collations[column.Comment]
+		}
+		if ddl.Collatable(column.Type) {
+			result.Columns[i].Collation = collation
+		}
+		result.Columns[i].Encrypted = opts.Encrypt[column.Name] || opts.Encrypt[column.Comment]
+	}
+	if opts.Tenant != "" {
+		addTenant(result, opts.Tenant, opts.DDL.Naming)
+		opts.DDL.Tenant = true
+	}
+	result.CreateTable = ddl.CreateTableWithOptions(result.Table, result.Columns, opts.DDL)
+	result.DecryptView = ddl.DecryptView(result.Table, result.Columns, opts.DDL)
+	return result, nil
+}
+
+// addTenant menambahkan kolom ddl.TenantColumn di depan skema dan sampel
+// result, dengan nama kolom menurut naming.
+func addTenant(result *Result, tenant string, naming ddl.Naming) {
+	inferred := inference.Result{Type: ddl.TenantType, Detector: "tenant", Confidence: 1, NonEmpty: result.Rows, MaxLength: len(tenant)}
+	result.Header = append([]string{ddl.TenantColumn}, result.Header...)
+	result.Types = append([]string{ddl.TenantType}, result.Types...)
+	result.Inferred = append([]inference.Result{inferred}, result.Inferred...)
+	result.Columns = append([]ddl.Column{{Name: naming.TenantColumn(), Type: ddl.TenantType, Comment: ddl.TenantColumn}}, result.Columns...)
+	for i, row := range result.Sample {
+		result.Sample[i] = withTenant(tenant, row)
+	}
+}
+
+// withTenant mengembalikan row dengan tenant sebagai kolom pertama.
+func withTenant(tenant string, row []string) []string {
+	return append([]string{tenant}, row...)
+}
+
+// addRow menambahkan nilai-nilai row ke state inferensi setiap kolom. Kolom
+// di luar panjang row dianggap kosong.
+func addRow(columns []*inference.Column, row []string) {
+	for i, column := range columns {
+		if i < len(row) {
+			column.Add(row[i])
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package xlsx2sql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMergeHeaderRows(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]string
+		want []string
+	}{
+		{
+			"satu baris",
+			[][]string{{"nama", " harga "}},
+			[]string{"nama", "harga"},
+		},
+		{
+			// Sales digabung di atas Q1 dan Q2, Nama digabung vertikal
+			"gabungan horizontal dan vertikal",
+			[][]string{
+				{"Nama", "Sales", "", "Biaya", ""},
+				{"", "Q1", "Q2", "Q1", "Q2"},
+			},
+			[]string{"Nama", "Sales|Q1", "Sales|Q2", "Biaya|Q1", "Biaya|Q2"},
+		},
+		{
+			// Kolom kosong sesudah kolom yang hanya bertingkat satu tidak
+			// mewarisi teksnya
+			"tanpa tingkat bawah",
+			[][]string{
+				{"Kode", "", "Sales", ""},
+				{"", "Catatan", "Q1", "Q2"},
+			},
+			[]string{"Kode", "Catatan", "Sales|Q1", "Sales|Q2"},
+		},
+		{
+			"tiga tingkat",
+			[][]string{
+				{"2024", "", "", ""},
+				{"Semester 1", "", "Semester 2", ""},
+				{"Jan", "Feb", "Jul", "Agu"},
+			},
+			[]string{"2024|Semester 1|Jan", "2024|Semester 1|Feb", "2024|Semester 2|Jul", "2024|Semester 2|Agu"},
+		},
+		{
+			// Tingkat tengah tidak mewarisi teks dari kelompok atas lain
+			"batas kelompok atas",
+			[][]string{
+				{"A", "", "B", ""},
+				{"x", "", "", "y"},
+				{"1", "2", "3", "4"},
+			},
+			[]string{"A|x|1", "A|x|2", "B|3", "B|y|4"},
+		},
+		{
+			// Teks yang sama dengan tingkat di atasnya tidak diulang dan
+			// baris yang lebih pendek dianggap berisi sel kosong
+			"teks berulang",
+			[][]string{
+				{"Total", "Harga"},
+				{"Total", "Satuan", "Diskon"},
+			},
+			[]string{"Total", "Harga|Satuan", "Harga|Diskon"},
+		},
+		{
+			"kosong",
+			[][]string{{"", ""}, {"", ""}},
+			[]string{"", ""},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := MergeHeaderRows(test.rows); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("MergeHeaderRows = %q, ingin %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGenerateHeaderRows(t *testing.T) {
+	rows := [][]string{
+		{"Nama", "Sales", ""},
+		{"", "Q1", "Q2"},
+		{"catatan", "", ""},
+		{"apel", "10", "20"},
+	}
+	result, err := Generate(context.Background(), rows, Options{Table: "penjualan", HeaderRows: 2, SkipRows: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Nama", "Sales|Q1", "Sales|Q2"}; !reflect.DeepEqual(result.Header, want) {
+		t.Errorf("Header = %q, ingin %q", result.Header, want)
+	}
+	if result.Rows != 1 {
+		t.Errorf("Rows = %d, ingin 1", result.Rows)
+	}
+	var names []string
+	for _, column := range result.Columns {
+		names = append(names, column.Name)
+	}
+	// Pemisah header hilang pada penamaan default ddl.NamingCompact
+	if want := []string{"nama", "salesq1", "salesq2"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("nama kolom = %q, ingin %q", names, want)
+	}
+}
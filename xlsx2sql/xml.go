@@ -0,0 +1,154 @@
+package xlsx2sql
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/antchfx/xmlquery"
+)
+
+// ErrXMLRows dikembalikan bila Options.XMLRows bukan XPath yang valid.
+var ErrXMLRows = errors.New("xlsx2sql: XPath baris XML tidak valid")
+
+// DefaultXMLRows adalah XPath baris XML bila Options.XMLRows kosong: setiap
+// anak elemen root, misalnya <record> pada <data><record>...</record></data>.
+const DefaultXMLRows = "/*/*"
+
+// xmlReader membaca file XML sebagai baris: setiap elemen yang cocok dengan
+// XPath baris menjadi satu baris data, dengan atribut dan elemen anaknya
+// sebagai kolom. Baris pertama berisi gabungan nama kolom semua baris sesuai
+// urutan kemunculannya. Seperti jsonReader, file dibaca dua kali secara
+// streaming dengan xmlquery.StreamParser sehingga isinya tidak dimuat utuh ke
+// memori.
+type xmlReader struct {
+	file   *os.File
+	parser *xmlquery.StreamParser
+	header []string
+	index  map[string]int
+}
+
+// newXMLReader membuka path dengan XPath baris rows, atau DefaultXMLRows bila
+// kosong.
+func newXMLReader(path, rows string) (*xmlReader, error) {
+	rows = xmlRowsXPath(rows)
+	header, err := xmlColumns(path, rows)
+	if err != nil {
+		return nil, err
+	}
+	file, parser, err := openXML(path, rows)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[name] = i
+	}
+	return &xmlReader{file: file, parser: parser, header: header, index: index}, nil
+}
+
+func (r *xmlReader) Read() ([]string, error) {
+	if r.header != nil {
+		header := r.header
+		r.header = nil
+		return header, nil
+	}
+	node, err := r.parser.Read()
+	if err != nil {
+		return nil, err
+	}
+	row := make([]string, len(r.index))
+	xmlFields(node, func(name, value string) {
+		i := r.index[name]
+		if row[i] != "" {
+			row[i] += "\n"
+		}
+		row[i] += value
+	})
+	return row, nil
+}
+
+func (r *xmlReader) Close() error {
+	return r.file.Close()
+}
+
+// xmlRowsXPath melengkapi rows: kosong menjadi DefaultXMLRows dan nama elemen
+// saja, misalnya record, menjadi //record.
+func xmlRowsXPath(rows string) string {
+	switch {
+	case rows == "":
+		return DefaultXMLRows
+	case !strings.ContainsAny(rows, "/[]()@*:|"):
+		return "//" + rows
+	}
+	return rows
+}
+
+// xmlColumns mengumpulkan nama kolom semua baris XML sesuai urutan
+// kemunculan pertamanya.
+func xmlColumns(path, rows string) ([]string, error) {
+	file, parser, err := openXML(path, rows)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	columns := []string{}
+	seen := make(map[string]bool)
+	for {
+		node, err := parser.Read()
+		if err == io.EOF {
+			return columns, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		xmlFields(node, func(name, value string) {
+			if !seen[name] {
+				seen[name] = true
+				columns = append(columns, name)
+			}
+		})
+	}
+}
+
+func openXML(path, rows string) (*os.File, *xmlquery.StreamParser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	parser, err := xmlquery.CreateStreamParser(bufio.NewReader(file), rows)
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("%w: %q: %v", ErrXMLRows, rows, err)
+	}
+	return file, parser, nil
+}
+
+// xmlFields memanggil fn untuk setiap atribut (tanpa deklarasi namespace)
+// dan elemen anak node. Elemen anak tanpa elemen di dalamnya menjadi teksnya,
+// sedangkan elemen anak bersarang ditulis sebagai teks XML isinya. Elemen
+// anak berulang dengan nama sama digabung dengan baris baru oleh pemanggil.
+func xmlFields(node *xmlquery.Node, fn func(name, value string)) {
+	for _, attr := range node.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		fn(attr.Name.Local, attr.Value)
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != xmlquery.ElementNode {
+			continue
+		}
+		value := strings.TrimSpace(child.InnerText())
+		for c := child.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == xmlquery.ElementNode {
+				value = strings.TrimSpace(child.OutputXML(false))
+				break
+			}
+		}
+		fn(child.Data, value)
+	}
+}